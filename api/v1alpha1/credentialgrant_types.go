@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialGrantSpec defines which namespaces may reference the credential secrets of the
+// namespace the grant lives in.
+type CredentialGrantSpec struct {
+	// From lists the namespaces, and optionally the kinds, allowed to reference the secrets.
+	// +kubebuilder:validation:MinItems=1
+	From []CredentialGrantFrom `json:"from"`
+	// To lists the secrets that may be referenced. Every secret of the namespace may be
+	// referenced when empty.
+	// +optional
+	To []CredentialGrantTo `json:"to,omitempty"`
+}
+
+// CredentialGrantFrom describes the resources allowed to reference the secrets.
+type CredentialGrantFrom struct {
+	// Namespace of the referencing resources.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Kind of the referencing resources, e.g. EntraSecurityGroup. Any kind when empty.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// CredentialGrantTo names a secret that may be referenced.
+type CredentialGrantTo struct {
+	// Name of the secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+
+// CredentialGrant allows resources in other namespaces to use the credential secrets of its
+// namespace, similar to the Gateway API ReferenceGrant. References to a secret in the same
+// namespace never need a grant.
+type CredentialGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CredentialGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CredentialGrantList contains a list of CredentialGrant
+type CredentialGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CredentialGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CredentialGrant{}, &CredentialGrantList{})
+}
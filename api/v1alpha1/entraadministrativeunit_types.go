@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraAdministrativeUnitSpec defines the desired state of EntraAdministrativeUnit. Directory
+// roles scoped to the administrative unit delegate the administration of its members, e.g. the
+// groups of a business unit created in it.
+// +kubebuilder:validation:XValidation:rule="has(self.membershipRule) == (self.membershipType == 'Dynamic')",message="membershipRule must be set exactly when membershipType is Dynamic"
+// +kubebuilder:validation:XValidation:rule="(has(self.isMemberManagementRestricted) && self.isMemberManagementRestricted) == (has(oldSelf.isMemberManagementRestricted) && oldSelf.isMemberManagementRestricted)",message="isMemberManagementRestricted is immutable"
+type EntraAdministrativeUnitSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// DisplayName is the name of the administrative unit.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	DisplayName string `json:"displayName,omitempty"`
+	// Description of the administrative unit.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// MembershipType is Assigned when members are added explicitly, e.g. groups created in the
+	// administrative unit, or Dynamic when they are selected by MembershipRule.
+	// +kubebuilder:validation:Enum=Assigned;Dynamic
+	// +kubebuilder:default=Assigned
+	// +optional
+	MembershipType string `json:"membershipType,omitempty"`
+	// MembershipRule selects the members of a dynamic administrative unit, e.g.
+	// (user.department -eq "Finance").
+	// +kubebuilder:validation:MaxLength=3072
+	// +optional
+	MembershipRule string `json:"membershipRule,omitempty"`
+	// IsMemberManagementRestricted creates a restricted management administrative unit: its
+	// members can only be managed by roles scoped to the unit. It can only be set when the
+	// administrative unit is created.
+	// +optional
+	IsMemberManagementRestricted bool `json:"isMemberManagementRestricted,omitempty"`
+}
+
+// EntraAdministrativeUnitStatus defines the observed state of EntraAdministrativeUnit
+type EntraAdministrativeUnitStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraAdministrativeUnit.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraAdministrativeUnit.
+	Phase string `json:"phase,omitempty"`
+	// ID is the object ID of the administrative unit in Entra.
+	ID string `json:"id,omitempty"`
+	// DisplayName is the display name of the administrative unit in Entra.
+	DisplayName string `json:"displayName,omitempty"`
+	// MembershipType is the membership type of the administrative unit in Entra.
+	MembershipType string `json:"membershipType,omitempty"`
+	// IsMemberManagementRestricted reports whether the administrative unit is a restricted
+	// management administrative unit in Entra.
+	IsMemberManagementRestricted bool `json:"isMemberManagementRestricted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraAdministrativeUnit"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraAdministrativeUnit"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the EntraAdministrativeUnit in Entra"
+// +kubebuilder:printcolumn:name="Membership",type="string",JSONPath=".status.membershipType",description="The membership type of the EntraAdministrativeUnit",priority=1
+
+// EntraAdministrativeUnit is the Schema for the entraadministrativeunits API
+type EntraAdministrativeUnit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraAdministrativeUnitSpec   `json:"spec,omitempty"`
+	Status EntraAdministrativeUnitStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraAdministrativeUnitList contains a list of EntraAdministrativeUnit
+type EntraAdministrativeUnitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraAdministrativeUnit `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraAdministrativeUnit{}, &EntraAdministrativeUnitList{})
+}
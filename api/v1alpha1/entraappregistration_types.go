@@ -51,6 +51,27 @@ type AppRegCredConfig struct {
 	ServiceAccountRef string `json:"serviceAccountRef,omitempty"`
 	// +kubebuilder:validation:Optional
 	CredentialSecretRef string `json:"credentialSecretRef,omitempty"`
+	// CredentialSecretNamespace is the namespace of the credential secret. Defaults to the
+	// namespace of the resource; other namespaces must allow it with a CredentialGrant.
+	// +kubebuilder:validation:Optional
+	CredentialSecretNamespace string `json:"credentialSecretNamespace,omitempty"`
+	// AuthMethod selects the credential read from the secret. Detected from the keys of the
+	// secret when empty: clientCertificate or tls.crt select ClientCertificate.
+	// +kubebuilder:validation:Enum=ClientSecret;ClientCertificate
+	// +kubebuilder:validation:Optional
+	AuthMethod string `json:"authMethod,omitempty"`
+	// Cloud is the Azure cloud of the tenant, it selects the token authority and the Microsoft
+	// Graph endpoint. Defaults to Public.
+	// +kubebuilder:validation:Enum=Public;USGovernment;USGovernmentDoD;China;Custom
+	// +kubebuilder:validation:Optional
+	Cloud string `json:"cloud,omitempty"`
+	// CustomCloud holds the endpoints of the cloud when Cloud is Custom.
+	// +kubebuilder:validation:Optional
+	CustomCloud *CustomCloudSpec `json:"customCloud,omitempty"`
+	// ManagedIdentity authenticates with the Azure managed identity of the controller instead of
+	// a credential secret. Requires the controller to run with --enable-managed-identity.
+	// +kubebuilder:validation:Optional
+	ManagedIdentity *ManagedIdentitySpec `json:"managedIdentity,omitempty"`
 }
 
 // EntraAppRegistrationStatus defines the observed state of EntraAppRegistration
@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraAppRoleAssignmentSpec defines the desired state of EntraAppRoleAssignment
+type EntraAppRoleAssignmentSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// Principal is the user, group or service principal assigned the app role.
+	// +kubebuilder:validation:Required
+	Principal AppRoleAssignmentPrincipal `json:"principal"`
+	// Resource is the service principal of the application defining the app role.
+	// +kubebuilder:validation:Required
+	Resource AppRoleAssignmentResource `json:"resource"`
+	// AppRole is the value of the app role assigned, e.g. Reader. The default access role is
+	// assigned when empty.
+	// +optional
+	AppRole string `json:"appRole,omitempty"`
+}
+
+// AppRoleAssignmentPrincipal references the principal of an app role assignment by object ID
+// or by the resource managing it.
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.ref)",message="exactly one of id or ref must be set"
+type AppRoleAssignmentPrincipal struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=User;Group;ServicePrincipal
+	Type string `json:"type"`
+	// Id is the object ID of the principal in Entra.
+	// +optional
+	Id string `json:"id,omitempty"`
+	// Ref is the name of the EntraUser, EntraSecurityGroup or EntraServicePrincipal of the
+	// namespace, depending on type. The assignment is created once it exists in Entra.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+}
+
+// AppRoleAssignmentResource references the resource service principal of an app role
+// assignment by object ID or by the EntraServicePrincipal managing it.
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.servicePrincipalRef)",message="exactly one of id or servicePrincipalRef must be set"
+type AppRoleAssignmentResource struct {
+	// Id is the object ID of the resource service principal in Entra.
+	// +optional
+	Id string `json:"id,omitempty"`
+	// ServicePrincipalRef is the name of an EntraServicePrincipal of the namespace.
+	// +optional
+	ServicePrincipalRef string `json:"servicePrincipalRef,omitempty"`
+}
+
+// EntraAppRoleAssignmentStatus defines the observed state of EntraAppRoleAssignment
+type EntraAppRoleAssignmentStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraAppRoleAssignment.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraAppRoleAssignment.
+	Phase string `json:"phase,omitempty"`
+	// ID is the ID of the app role assignment in Entra.
+	ID string `json:"id,omitempty"`
+	// PrincipalID is the object ID of the assigned principal.
+	PrincipalID string `json:"principalId,omitempty"`
+	// ResourceID is the object ID of the resource service principal.
+	ResourceID string `json:"resourceId,omitempty"`
+	// AppRoleID is the ID of the assigned app role.
+	AppRoleID string `json:"appRoleId,omitempty"`
+	// Adopted reports that the assignment existed before the resource. It is left in Entra when
+	// the resource is deleted.
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraAppRoleAssignment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraAppRoleAssignment"
+// +kubebuilder:printcolumn:name="AppRole",type="string",JSONPath=".spec.appRole",description="The value of the assigned app role"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the app role assignment in Entra",priority=1
+
+// EntraAppRoleAssignment is the Schema for the entraapproleassignments API
+type EntraAppRoleAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraAppRoleAssignmentSpec   `json:"spec,omitempty"`
+	Status EntraAppRoleAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraAppRoleAssignmentList contains a list of EntraAppRoleAssignment
+type EntraAppRoleAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraAppRoleAssignment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraAppRoleAssignment{}, &EntraAppRoleAssignmentList{})
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraConditionalAccessPolicySpec defines the desired state of EntraConditionalAccessPolicy.
+// Policies are created report-only: their result is logged at sign-in without being enforced
+// until State is set to enabled.
+// +kubebuilder:validation:XValidation:rule="has(self.grantControls) || has(self.sessionControls)",message="at least one of grantControls or sessionControls must be set"
+type EntraConditionalAccessPolicySpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// DisplayName is the name of the policy.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	DisplayName string `json:"displayName,omitempty"`
+	// State is enabledForReportingButNotEnforced to evaluate the policy without enforcing it,
+	// enabled to enforce it or disabled.
+	// +kubebuilder:validation:Enum=enabledForReportingButNotEnforced;enabled;disabled
+	// +kubebuilder:default=enabledForReportingButNotEnforced
+	// +optional
+	State string `json:"state,omitempty"`
+	// Conditions select the sign-ins the policy applies to.
+	// +kubebuilder:validation:Required
+	Conditions ConditionalAccessConditions `json:"conditions"`
+	// GrantControls are required to complete the sign-ins the policy applies to.
+	// +optional
+	GrantControls *ConditionalAccessGrantControls `json:"grantControls,omitempty"`
+	// SessionControls restrict the sessions of the sign-ins the policy applies to.
+	// +optional
+	SessionControls *ConditionalAccessSessionControls `json:"sessionControls,omitempty"`
+}
+
+// ConditionalAccessConditions select the sign-ins a policy applies to. A sign-in has to match
+// every condition that is set.
+type ConditionalAccessConditions struct {
+	// Users the policy applies to.
+	// +kubebuilder:validation:Required
+	Users ConditionalAccessUsers `json:"users"`
+	// Applications the policy applies to.
+	// +kubebuilder:validation:Required
+	Applications ConditionalAccessApplications `json:"applications"`
+	// ClientAppTypes the policy applies to.
+	// +kubebuilder:default={"all"}
+	// +optional
+	ClientAppTypes []ConditionalAccessClientApp `json:"clientAppTypes,omitempty"`
+	// Platforms are the device platforms the policy applies to, all when unset.
+	// +optional
+	Platforms *ConditionalAccessPlatforms `json:"platforms,omitempty"`
+	// Locations are the named locations the policy applies to, all when unset.
+	// +optional
+	Locations *ConditionalAccessLocations `json:"locations,omitempty"`
+	// SignInRiskLevels are the sign-in risk levels the policy applies to, all when unset.
+	// +optional
+	SignInRiskLevels []ConditionalAccessRiskLevel `json:"signInRiskLevels,omitempty"`
+	// UserRiskLevels are the user risk levels the policy applies to, all when unset.
+	// +optional
+	UserRiskLevels []ConditionalAccessRiskLevel `json:"userRiskLevels,omitempty"`
+}
+
+// ConditionalAccessUsers selects users by object ID, by group or role membership or by
+// reference to the EntraUsers and EntraSecurityGroups of the namespace. The policy is not
+// changed while a referenced resource is not created yet.
+// +kubebuilder:validation:XValidation:rule="has(self.includeUsers) || has(self.includeUserRefs) || has(self.includeGroups) || has(self.includeGroupRefs) || has(self.includeRoles)",message="at least one user, group or role must be included"
+type ConditionalAccessUsers struct {
+	// IncludeUsers are user object IDs, All, None or GuestsOrExternalUsers.
+	// +optional
+	IncludeUsers []string `json:"includeUsers,omitempty"`
+	// ExcludeUsers are user object IDs or GuestsOrExternalUsers.
+	// +optional
+	ExcludeUsers []string `json:"excludeUsers,omitempty"`
+	// IncludeUserRefs are names of EntraUsers in the namespace of the policy.
+	// +optional
+	IncludeUserRefs []string `json:"includeUserRefs,omitempty"`
+	// ExcludeUserRefs are names of EntraUsers in the namespace of the policy.
+	// +optional
+	ExcludeUserRefs []string `json:"excludeUserRefs,omitempty"`
+	// IncludeGroups are group object IDs.
+	// +optional
+	IncludeGroups []string `json:"includeGroups,omitempty"`
+	// ExcludeGroups are group object IDs.
+	// +optional
+	ExcludeGroups []string `json:"excludeGroups,omitempty"`
+	// IncludeGroupRefs are names of EntraSecurityGroups in the namespace of the policy.
+	// +optional
+	IncludeGroupRefs []string `json:"includeGroupRefs,omitempty"`
+	// ExcludeGroupRefs are names of EntraSecurityGroups in the namespace of the policy.
+	// +optional
+	ExcludeGroupRefs []string `json:"excludeGroupRefs,omitempty"`
+	// IncludeRoles are directory role template IDs.
+	// +optional
+	IncludeRoles []string `json:"includeRoles,omitempty"`
+	// ExcludeRoles are directory role template IDs.
+	// +optional
+	ExcludeRoles []string `json:"excludeRoles,omitempty"`
+}
+
+// ConditionalAccessApplications selects the cloud apps or the user actions a policy applies to.
+// +kubebuilder:validation:XValidation:rule="has(self.includeApplications) || has(self.includeUserActions)",message="at least one of includeApplications or includeUserActions must be set"
+type ConditionalAccessApplications struct {
+	// IncludeApplications are application (client) IDs, All, None or Office365.
+	// +optional
+	IncludeApplications []string `json:"includeApplications,omitempty"`
+	// ExcludeApplications are application (client) IDs or Office365.
+	// +optional
+	ExcludeApplications []string `json:"excludeApplications,omitempty"`
+	// IncludeUserActions are user actions, e.g. urn:user:registersecurityinfo.
+	// +optional
+	IncludeUserActions []string `json:"includeUserActions,omitempty"`
+}
+
+// ConditionalAccessPlatforms selects the device platforms a policy applies to.
+type ConditionalAccessPlatforms struct {
+	// +kubebuilder:validation:MinItems=1
+	IncludePlatforms []ConditionalAccessPlatform `json:"includePlatforms"`
+	// +optional
+	ExcludePlatforms []ConditionalAccessPlatform `json:"excludePlatforms,omitempty"`
+}
+
+// ConditionalAccessLocations selects the named locations a policy applies to, by ID or by
+// reference to the EntraNamedLocations of the namespace. The policy is not changed while a
+// referenced named location is not created yet.
+// +kubebuilder:validation:XValidation:rule="has(self.includeLocations) || has(self.includeLocationRefs)",message="at least one location must be included"
+type ConditionalAccessLocations struct {
+	// IncludeLocations are named location IDs, All or AllTrusted.
+	// +optional
+	IncludeLocations []string `json:"includeLocations,omitempty"`
+	// ExcludeLocations are named location IDs or AllTrusted.
+	// +optional
+	ExcludeLocations []string `json:"excludeLocations,omitempty"`
+	// IncludeLocationRefs are names of EntraNamedLocations in the namespace of the policy.
+	// +optional
+	IncludeLocationRefs []string `json:"includeLocationRefs,omitempty"`
+	// ExcludeLocationRefs are names of EntraNamedLocations in the namespace of the policy.
+	// +optional
+	ExcludeLocationRefs []string `json:"excludeLocationRefs,omitempty"`
+}
+
+// ConditionalAccessGrantControls are the controls users have to satisfy to sign in.
+// +kubebuilder:validation:XValidation:rule="!self.builtInControls.exists(c, c == 'block') || self.builtInControls.size() == 1",message="block cannot be combined with other controls"
+type ConditionalAccessGrantControls struct {
+	// Operator is AND when every control is required, OR when one of them is.
+	// +kubebuilder:validation:Enum=AND;OR
+	// +kubebuilder:default=OR
+	// +optional
+	Operator string `json:"operator,omitempty"`
+	// BuiltInControls are the controls, block denies the sign-in.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=7
+	BuiltInControls []ConditionalAccessGrantControl `json:"builtInControls"`
+}
+
+// ConditionalAccessSessionControls restrict the sessions of the users that signed in.
+type ConditionalAccessSessionControls struct {
+	// SignInFrequency is how often users have to sign in again.
+	// +optional
+	SignInFrequency *ConditionalAccessSignInFrequency `json:"signInFrequency,omitempty"`
+	// PersistentBrowser is always to keep browser sessions after the browser is closed or never
+	// to end them.
+	// +kubebuilder:validation:Enum=always;never
+	// +optional
+	PersistentBrowser string `json:"persistentBrowser,omitempty"`
+	// ApplicationEnforcedRestrictions lets supported applications restrict the session, e.g.
+	// block downloads on unmanaged devices.
+	// +optional
+	ApplicationEnforcedRestrictions bool `json:"applicationEnforcedRestrictions,omitempty"`
+	// DisableResilienceDefaults blocks sign-ins instead of extending existing sessions while the
+	// policy cannot be evaluated during an outage.
+	// +optional
+	DisableResilienceDefaults bool `json:"disableResilienceDefaults,omitempty"`
+}
+
+// ConditionalAccessSignInFrequency is a sign-in frequency in hours or days.
+type ConditionalAccessSignInFrequency struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	Value int32 `json:"value"`
+	// +kubebuilder:validation:Enum=hours;days
+	Type string `json:"type"`
+}
+
+// +kubebuilder:validation:Enum=all;browser;mobileAppsAndDesktopClients;exchangeActiveSync;other
+type ConditionalAccessClientApp string
+
+// +kubebuilder:validation:Enum=all;android;iOS;windows;windowsPhone;macOS;linux
+type ConditionalAccessPlatform string
+
+// +kubebuilder:validation:Enum=low;medium;high;hidden;none
+type ConditionalAccessRiskLevel string
+
+// +kubebuilder:validation:Enum=block;mfa;compliantDevice;domainJoinedDevice;approvedApplication;compliantApplication;passwordChange
+type ConditionalAccessGrantControl string
+
+// EntraConditionalAccessPolicyStatus defines the observed state of EntraConditionalAccessPolicy
+type EntraConditionalAccessPolicyStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraConditionalAccessPolicy.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraConditionalAccessPolicy.
+	Phase string `json:"phase,omitempty"`
+	// ID is the ID of the conditional access policy in Entra.
+	ID string `json:"id,omitempty"`
+	// DisplayName is the display name of the conditional access policy in Entra.
+	DisplayName string `json:"displayName,omitempty"`
+	// State is the state of the conditional access policy in Entra.
+	State string `json:"state,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraConditionalAccessPolicy"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="The state of the policy in Entra"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraConditionalAccessPolicy"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the EntraConditionalAccessPolicy in Entra",priority=1
+
+// EntraConditionalAccessPolicy is the Schema for the entraconditionalaccesspolicies API
+type EntraConditionalAccessPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraConditionalAccessPolicySpec   `json:"spec,omitempty"`
+	Status EntraConditionalAccessPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraConditionalAccessPolicyList contains a list of EntraConditionalAccessPolicy
+type EntraConditionalAccessPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraConditionalAccessPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraConditionalAccessPolicy{}, &EntraConditionalAccessPolicyList{})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraDirectoryRoleAssignmentSpec defines the desired state of EntraDirectoryRoleAssignment
+type EntraDirectoryRoleAssignmentSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// Principal is the user, role-assignable group or service principal assigned the role.
+	// +kubebuilder:validation:Required
+	Principal DirectoryRolePrincipal `json:"principal"`
+	// Role is the built-in or custom directory role assigned.
+	// +kubebuilder:validation:Required
+	Role DirectoryRoleDefinition `json:"role"`
+	// AdministrativeUnitID scopes the assignment to the members of an administrative unit. The
+	// role applies to the whole tenant when empty.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	// +optional
+	AdministrativeUnitID string `json:"administrativeUnitId,omitempty"`
+}
+
+// DirectoryRolePrincipal references the principal of a directory role assignment by object ID
+// or by the resource managing it.
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.ref)",message="exactly one of id or ref must be set"
+type DirectoryRolePrincipal struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=User;Group;ServicePrincipal
+	Type string `json:"type"`
+	// Id is the object ID of the principal in Entra.
+	// +optional
+	Id string `json:"id,omitempty"`
+	// Ref is the name of the EntraUser, EntraSecurityGroup or EntraServicePrincipal of the
+	// namespace, depending on type. Groups must be created with isAssignableToRole. The
+	// assignment is created once the principal exists in Entra.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+}
+
+// DirectoryRoleDefinition selects a directory role by template ID or display name.
+// +kubebuilder:validation:XValidation:rule="has(self.templateId) != has(self.displayName)",message="exactly one of templateId or displayName must be set"
+type DirectoryRoleDefinition struct {
+	// TemplateID is the template ID of the role, the same in every tenant for built-in roles,
+	// e.g. fe930be7-5e62-47db-91af-98c3a49a38b1 for User Administrator.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	// +optional
+	TemplateID string `json:"templateId,omitempty"`
+	// DisplayName is the display name of the role, e.g. User Administrator.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// EntraDirectoryRoleAssignmentStatus defines the observed state of EntraDirectoryRoleAssignment
+type EntraDirectoryRoleAssignmentStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraDirectoryRoleAssignment.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraDirectoryRoleAssignment.
+	Phase string `json:"phase,omitempty"`
+	// ID is the ID of the role assignment in Entra.
+	ID string `json:"id,omitempty"`
+	// PrincipalID is the object ID of the assigned principal.
+	PrincipalID string `json:"principalId,omitempty"`
+	// RoleDefinitionID is the ID of the assigned role definition.
+	RoleDefinitionID string `json:"roleDefinitionId,omitempty"`
+	// RoleDisplayName is the display name of the assigned role.
+	RoleDisplayName string `json:"roleDisplayName,omitempty"`
+	// DirectoryScopeID is the scope of the assignment, / for the tenant or
+	// /administrativeUnits/{id}.
+	DirectoryScopeID string `json:"directoryScopeId,omitempty"`
+	// Adopted reports that the assignment existed before the resource. It is left in Entra when
+	// the resource is deleted.
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraDirectoryRoleAssignment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraDirectoryRoleAssignment"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".status.roleDisplayName",description="The assigned directory role"
+// +kubebuilder:printcolumn:name="Scope",type="string",JSONPath=".status.directoryScopeId",description="The directory scope of the assignment",priority=1
+
+// EntraDirectoryRoleAssignment is the Schema for the entradirectoryroleassignments API
+type EntraDirectoryRoleAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraDirectoryRoleAssignmentSpec   `json:"spec,omitempty"`
+	Status EntraDirectoryRoleAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraDirectoryRoleAssignmentList contains a list of EntraDirectoryRoleAssignment
+type EntraDirectoryRoleAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraDirectoryRoleAssignment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraDirectoryRoleAssignment{}, &EntraDirectoryRoleAssignmentList{})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraEligibleRoleAssignmentSpec defines the desired state of EntraEligibleRoleAssignment
+// +kubebuilder:validation:XValidation:rule="has(self.directoryRole) != has(self.group)",message="exactly one of directoryRole or group must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.group) || self.principal.type != 'ServicePrincipal'",message="group eligibility requires a User or Group principal"
+// +kubebuilder:validation:XValidation:rule="has(self.group) == has(oldSelf.group)",message="switching between directoryRole and group is not supported"
+type EntraEligibleRoleAssignmentSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// Principal is the user, group or service principal made eligible. Groups made eligible for
+	// a directory role must be created with isAssignableToRole.
+	// +kubebuilder:validation:Required
+	Principal DirectoryRolePrincipal `json:"principal"`
+	// DirectoryRole is the directory role the principal is eligible for.
+	// +optional
+	DirectoryRole *EligibleDirectoryRole `json:"directoryRole,omitempty"`
+	// Group is the membership or ownership of a group managed by PIM for groups the principal is
+	// eligible for.
+	// +optional
+	Group *EligibleGroupAccess `json:"group,omitempty"`
+	// Schedule of the eligibility. The eligibility does not expire when it has no end.
+	// +optional
+	Schedule EligibilitySchedule `json:"schedule,omitempty"`
+	// Justification is recorded with every schedule request in the PIM audit history.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=500
+	Justification string `json:"justification"`
+}
+
+// EligibleDirectoryRole selects a directory role and the scope of the eligibility.
+type EligibleDirectoryRole struct {
+	// Role is the built-in or custom directory role.
+	// +kubebuilder:validation:Required
+	Role DirectoryRoleDefinition `json:"role"`
+	// AdministrativeUnitID scopes the eligibility to the members of an administrative unit. The
+	// role applies to the whole tenant when empty.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	// +optional
+	AdministrativeUnitID string `json:"administrativeUnitId,omitempty"`
+}
+
+// EligibleGroupAccess selects a group by object ID or by the EntraSecurityGroup managing it, and
+// the access to it.
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.ref)",message="exactly one of id or ref must be set"
+type EligibleGroupAccess struct {
+	// Id is the object ID of the group in Entra.
+	// +optional
+	Id string `json:"id,omitempty"`
+	// Ref is the name of an EntraSecurityGroup in the namespace. The eligibility is requested
+	// once the group exists in Entra.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+	// AccessID is the access the principal is eligible for, member or owner.
+	// +kubebuilder:validation:Enum=member;owner
+	// +kubebuilder:default=member
+	// +optional
+	AccessID string `json:"accessId,omitempty"`
+}
+
+// EligibilitySchedule is the time window of an eligibility. It ends at EndDateTime, or Duration
+// after its start; eligibilities with a Duration are extended by another Duration RenewBefore
+// their end.
+// +kubebuilder:validation:XValidation:rule="!(has(self.endDateTime) && has(self.duration))",message="at most one of endDateTime or duration may be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.renewBefore) || (has(self.duration) && duration(self.renewBefore) < duration(self.duration))",message="renewBefore requires a longer duration"
+type EligibilitySchedule struct {
+	// StartDateTime is the start of the eligibility, when it is requested when empty.
+	// +optional
+	StartDateTime *metav1.Time `json:"startDateTime,omitempty"`
+	// EndDateTime is the end of the eligibility.
+	// +optional
+	EndDateTime *metav1.Time `json:"endDateTime,omitempty"`
+	// Duration of the eligibility, e.g. 2160h for 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// RenewBefore is how long before its end an eligibility with a Duration is extended.
+	// Defaults to 7 days, or half the duration for shorter eligibilities.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// EntraEligibleRoleAssignmentStatus defines the observed state of EntraEligibleRoleAssignment
+type EntraEligibleRoleAssignmentStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraEligibleRoleAssignment.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraEligibleRoleAssignment.
+	Phase string `json:"phase,omitempty"`
+	// ScheduleID is the ID of the eligibility schedule in Entra.
+	ScheduleID string `json:"scheduleId,omitempty"`
+	// ScheduleStatus is the status of the eligibility schedule, e.g. Provisioned.
+	ScheduleStatus string `json:"scheduleStatus,omitempty"`
+	// RequestID is the ID of the last schedule request submitted.
+	RequestID string `json:"requestId,omitempty"`
+	// RequestStatus is the status of the last schedule request, e.g. Provisioned or
+	// PendingApproval.
+	RequestStatus string `json:"requestStatus,omitempty"`
+	// PrincipalID is the object ID of the eligible principal.
+	PrincipalID string `json:"principalId,omitempty"`
+	// RoleDefinitionID is the ID of the directory role the principal is eligible for.
+	RoleDefinitionID string `json:"roleDefinitionId,omitempty"`
+	// RoleDisplayName is the display name of the directory role.
+	RoleDisplayName string `json:"roleDisplayName,omitempty"`
+	// DirectoryScopeID is the scope of the directory role eligibility, / for the tenant or
+	// /administrativeUnits/{id}.
+	DirectoryScopeID string `json:"directoryScopeId,omitempty"`
+	// GroupID is the object ID of the group the principal is eligible for.
+	GroupID string `json:"groupId,omitempty"`
+	// AccessID is the group access the principal is eligible for, member or owner.
+	AccessID string `json:"accessId,omitempty"`
+	// StartDateTime is the start of the eligibility.
+	StartDateTime *metav1.Time `json:"startDateTime,omitempty"`
+	// EndDateTime is the end of the eligibility, empty when it does not expire.
+	EndDateTime *metav1.Time `json:"endDateTime,omitempty"`
+	// LastRenewalTime is when the eligibility was last extended.
+	LastRenewalTime *metav1.Time `json:"lastRenewalTime,omitempty"`
+	// Adopted reports that the eligibility existed before the resource. It is left in Entra when
+	// the resource is deleted.
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraEligibleRoleAssignment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraEligibleRoleAssignment"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.endDateTime",description="The end of the eligibility"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".status.roleDisplayName",description="The directory role of the eligibility",priority=1
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=".status.groupId",description="The group of the eligibility",priority=1
+
+// EntraEligibleRoleAssignment is the Schema for the entraeligibleroleassignments API
+type EntraEligibleRoleAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraEligibleRoleAssignmentSpec   `json:"spec,omitempty"`
+	Status EntraEligibleRoleAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraEligibleRoleAssignmentList contains a list of EntraEligibleRoleAssignment
+type EntraEligibleRoleAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraEligibleRoleAssignment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraEligibleRoleAssignment{}, &EntraEligibleRoleAssignmentList{})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraNamedLocationSpec defines the desired state of EntraNamedLocation. Named locations are IP
+// ranges or countries that conditional access policies include or exclude, by ID or with
+// includeLocationRefs and excludeLocationRefs.
+// +kubebuilder:validation:XValidation:rule="has(self.ip) != has(self.countries)",message="exactly one of ip or countries must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.ip) == has(oldSelf.ip)",message="the type of a named location cannot be changed"
+type EntraNamedLocationSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// DisplayName is the name of the named location.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	DisplayName string `json:"displayName,omitempty"`
+	// IP makes the named location a set of IP ranges.
+	// +optional
+	IP *IPNamedLocation `json:"ip,omitempty"`
+	// Countries makes the named location a set of countries and regions.
+	// +optional
+	Countries *CountryNamedLocation `json:"countries,omitempty"`
+}
+
+// IPNamedLocation is a named location of IPv4 and IPv6 ranges.
+type IPNamedLocation struct {
+	// IPRanges are ranges in CIDR notation, e.g. 203.0.113.0/24 or 2001:db8::/48. Ranges must
+	// not have host bits set and their prefix must be at least /8.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2000
+	IPRanges []string `json:"ipRanges"`
+	// IsTrusted marks the ranges as trusted, e.g. the egress of the corporate network. Trusted
+	// locations are selected by AllTrusted in policies and lower the sign-in risk.
+	// +optional
+	IsTrusted bool `json:"isTrusted,omitempty"`
+}
+
+// CountryNamedLocation is a named location of countries and regions.
+type CountryNamedLocation struct {
+	// CountriesAndRegions are ISO 3166-1 alpha-2 codes, e.g. US or NL.
+	// +kubebuilder:validation:MinItems=1
+	CountriesAndRegions []CountryCode `json:"countriesAndRegions"`
+	// IncludeUnknownCountriesAndRegions includes the IP addresses that are not mapped to a
+	// country or region.
+	// +optional
+	IncludeUnknownCountriesAndRegions bool `json:"includeUnknownCountriesAndRegions,omitempty"`
+	// CountryLookupMethod determines the country of a sign-in from its IP address or from the
+	// GPS location of the Authenticator app.
+	// +kubebuilder:validation:Enum=clientIpAddress;authenticatorAppGps
+	// +kubebuilder:default=clientIpAddress
+	// +optional
+	CountryLookupMethod string `json:"countryLookupMethod,omitempty"`
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country or region code.
+// +kubebuilder:validation:Pattern=`^[A-Z]{2}$`
+type CountryCode string
+
+// EntraNamedLocationStatus defines the observed state of EntraNamedLocation
+type EntraNamedLocationStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraNamedLocation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraNamedLocation.
+	Phase string `json:"phase,omitempty"`
+	// ID is the ID of the named location in Entra.
+	ID string `json:"id,omitempty"`
+	// DisplayName is the display name of the named location in Entra.
+	DisplayName string `json:"displayName,omitempty"`
+	// Type is ip or country.
+	Type string `json:"type,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraNamedLocation"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".status.type",description="The type of the named location"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraNamedLocation"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the EntraNamedLocation in Entra",priority=1
+
+// EntraNamedLocation is the Schema for the entranamedlocations API
+type EntraNamedLocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraNamedLocationSpec   `json:"spec,omitempty"`
+	Status EntraNamedLocationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraNamedLocationList contains a list of EntraNamedLocation
+type EntraNamedLocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraNamedLocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraNamedLocation{}, &EntraNamedLocationList{})
+}
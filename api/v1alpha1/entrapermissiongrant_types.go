@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraPermissionGrantSpec defines the desired state of EntraPermissionGrant. It grants admin
+// consent to the permissions of an EntraAppRegistration on the APIs it calls.
+type EntraPermissionGrantSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// AppRegistrationRef is the name of the EntraAppRegistration of the namespace consent is
+	// granted to. Its service principal is created when the application has none yet.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	AppRegistrationRef string `json:"appRegistrationRef"`
+	// Resources are the APIs, e.g. Microsoft Graph, and the permissions consented on each.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=resourceAppId
+	Resources []PermissionGrantResource `json:"resources"`
+}
+
+// PermissionGrantResource lists the permissions consented on an API.
+type PermissionGrantResource struct {
+	// ResourceAppID is the application ID of the API, e.g. 00000003-0000-0000-c000-000000000000
+	// for Microsoft Graph.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	ResourceAppID string `json:"resourceAppId"`
+	// DelegatedScopes are the delegated permissions consented for all users, e.g. User.Read. The
+	// tenant-wide grant of the application on the API is set to exactly these scopes.
+	// +optional
+	DelegatedScopes []string `json:"delegatedScopes,omitempty"`
+	// ApplicationPermissions are the application permissions, e.g. User.Read.All, assigned to the
+	// service principal of the application.
+	// +optional
+	ApplicationPermissions []string `json:"applicationPermissions,omitempty"`
+}
+
+// EntraPermissionGrantStatus defines the observed state of EntraPermissionGrant
+type EntraPermissionGrantStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraPermissionGrant.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraPermissionGrant.
+	Phase string `json:"phase,omitempty"`
+	// ClientServicePrincipalID is the object ID of the service principal of the application.
+	ClientServicePrincipalID string `json:"clientServicePrincipalId,omitempty"`
+	// DelegatedGrants are the tenant-wide delegated permission grants managed by the resource.
+	DelegatedGrants []DelegatedGrantStatus `json:"delegatedGrants,omitempty"`
+	// ApplicationGrants are the application permissions assigned by the resource.
+	ApplicationGrants []ApplicationGrantStatus `json:"applicationGrants,omitempty"`
+}
+
+// DelegatedGrantStatus is an oauth2PermissionGrant managed by an EntraPermissionGrant.
+type DelegatedGrantStatus struct {
+	// ID is the ID of the oauth2PermissionGrant in Entra.
+	ID string `json:"id"`
+	// ResourceID is the object ID of the service principal of the API.
+	ResourceID string `json:"resourceId"`
+	// Scope is the space separated list of the granted scopes.
+	Scope string `json:"scope,omitempty"`
+}
+
+// ApplicationGrantStatus is an app role assignment managed by an EntraPermissionGrant.
+type ApplicationGrantStatus struct {
+	// ID is the ID of the app role assignment in Entra.
+	ID string `json:"id"`
+	// ResourceID is the object ID of the service principal of the API.
+	ResourceID string `json:"resourceId"`
+	// Permission is the value of the assigned app role, e.g. User.Read.All.
+	Permission string `json:"permission"`
+	// AppRoleID is the ID of the assigned app role.
+	AppRoleID string `json:"appRoleId"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraPermissionGrant"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraPermissionGrant"
+// +kubebuilder:printcolumn:name="App",type="string",JSONPath=".spec.appRegistrationRef",description="The EntraAppRegistration consent is granted to"
+
+// EntraPermissionGrant is the Schema for the entrapermissiongrants API
+type EntraPermissionGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraPermissionGrantSpec   `json:"spec,omitempty"`
+	Status EntraPermissionGrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraPermissionGrantList contains a list of EntraPermissionGrant
+type EntraPermissionGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraPermissionGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraPermissionGrant{}, &EntraPermissionGrantList{})
+}
@@ -21,6 +21,9 @@ import (
 )
 
 // EntraSecurityGroupSpec defines the desired state of EntraSecurityGroup
+// +kubebuilder:validation:XValidation:rule="(has(self.isAssignableToRole) && self.isAssignableToRole) == (has(oldSelf.isAssignableToRole) && oldSelf.isAssignableToRole)",message="isAssignableToRole is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.isAssignableToRole) || !self.isAssignableToRole || self.securityEnabled",message="isAssignableToRole requires securityEnabled"
+// +kubebuilder:validation:XValidation:rule="has(self.administrativeUnit) == has(oldSelf.administrativeUnit)",message="administrativeUnit is immutable"
 type EntraSecurityGroupSpec struct {
 	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
 	// +kubebuilder:validation:Required
@@ -41,18 +44,52 @@ type EntraSecurityGroupSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=true
 	SecurityEnabled bool `json:"securityEnabled,omitempty"`
+	// IsAssignableToRole creates a group that can be assigned Entra directory roles. It can only
+	// be set when the group is created and requires RoleManagement.ReadWrite.Directory.
+	// +optional
+	IsAssignableToRole bool `json:"isAssignableToRole,omitempty"`
+	// AdministrativeUnit is the administrative unit the group is created in, so that its
+	// administration can be delegated with roles scoped to the unit. It can only be set when the
+	// group is created and requires AdministrativeUnit.ReadWrite.All.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="administrativeUnit is immutable"
+	// +optional
+	AdministrativeUnit *GroupAdministrativeUnit `json:"administrativeUnit,omitempty"`
 	// +kubebuilder:validation:Optional
 	Owners *[]Owners `json:"owners,omitempty"`
 	// +kubebuilder:validation:Optional
 	Members *[]Members `json:"members,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="[has(self.id), has(self.userRef), has(self.servicePrincipalRef)].filter(x, x).size() == 1",message="exactly one of id, userRef or servicePrincipalRef must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.userRef) || self.type == 'User'",message="userRef requires type User"
+// +kubebuilder:validation:XValidation:rule="!has(self.servicePrincipalRef) || self.type == 'ServicePrincipal'",message="servicePrincipalRef requires type ServicePrincipal"
 type Members struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=User;Group;ServicePrincipal
 	Type string `json:"type,omitempty"`
-	// +kubebuilder:validation:Required
+	// Id is the object ID of the member in Entra.
+	// +kubebuilder:validation:Optional
+	Id string `json:"id,omitempty"`
+	// UserRef is the name of an EntraUser in the namespace of the group. The user is added
+	// once it was created in Entra.
+	// +kubebuilder:validation:Optional
+	UserRef string `json:"userRef,omitempty"`
+	// ServicePrincipalRef is the name of an EntraServicePrincipal in the namespace of the
+	// group. The service principal is added once it was created in Entra.
+	// +kubebuilder:validation:Optional
+	ServicePrincipalRef string `json:"servicePrincipalRef,omitempty"`
+}
+
+// GroupAdministrativeUnit references the administrative unit a group is created in.
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.ref)",message="exactly one of id or ref must be set"
+type GroupAdministrativeUnit struct {
+	// Id is the object ID of the administrative unit in Entra.
+	// +optional
 	Id string `json:"id,omitempty"`
+	// Ref is the name of an EntraAdministrativeUnit in the namespace of the group. The group is
+	// created once the administrative unit was created in Entra.
+	// +optional
+	Ref string `json:"ref,omitempty"`
 }
 
 type Owners struct {
@@ -63,9 +100,64 @@ type Owners struct {
 	Id string `json:"id,omitempty"`
 }
 
+// MemberFailure records a member that could not be added to or removed from the group.
+type MemberFailure struct {
+	// ID is the object ID of the member.
+	ID string `json:"id"`
+	// Type is the member type (User, Group or ServicePrincipal).
+	Type string `json:"type,omitempty"`
+	// Operation is the membership change that failed (Add or Remove).
+	Operation string `json:"operation"`
+	// StatusCode is the HTTP status code returned by Microsoft Graph.
+	StatusCode int32 `json:"statusCode,omitempty"`
+	// Message is the error message returned by Microsoft Graph.
+	Message string `json:"message,omitempty"`
+}
+
 type ProviderSpec struct {
 	CredentialSecretRef string `json:"credentialSecretRef,omitempty"`
-	ServiceAccountRef   string `json:"serviceAccountRef,omitempty"`
+	// CredentialSecretNamespace is the namespace of the credential secret. Defaults to the
+	// namespace of the resource; other namespaces must allow it with a CredentialGrant.
+	// +optional
+	CredentialSecretNamespace string `json:"credentialSecretNamespace,omitempty"`
+	// AuthMethod selects the credential read from the secret. Detected from the keys of the
+	// secret when empty: clientCertificate or tls.crt select ClientCertificate.
+	// +kubebuilder:validation:Enum=ClientSecret;ClientCertificate
+	// +optional
+	AuthMethod string `json:"authMethod,omitempty"`
+	// Cloud is the Azure cloud of the tenant, it selects the token authority and the Microsoft
+	// Graph endpoint. Defaults to Public.
+	// +kubebuilder:validation:Enum=Public;USGovernment;USGovernmentDoD;China;Custom
+	// +optional
+	Cloud string `json:"cloud,omitempty"`
+	// CustomCloud holds the endpoints of the cloud when Cloud is Custom.
+	// +optional
+	CustomCloud *CustomCloudSpec `json:"customCloud,omitempty"`
+	// ManagedIdentity authenticates with the Azure managed identity of the controller instead of
+	// a credential secret. Requires the controller to run with --enable-managed-identity.
+	// +optional
+	ManagedIdentity   *ManagedIdentitySpec `json:"managedIdentity,omitempty"`
+	ServiceAccountRef string               `json:"serviceAccountRef,omitempty"`
+}
+
+// ManagedIdentitySpec selects the managed identity used to call Microsoft Graph.
+type ManagedIdentitySpec struct {
+	// ClientID is the client ID of a user-assigned managed identity. The system-assigned
+	// identity is used when empty.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// CustomCloudSpec defines the endpoints of a cloud that is not built in.
+type CustomCloudSpec struct {
+	// AuthorityHost is the Microsoft Entra authority, e.g. https://login.microsoftonline.us/.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://`
+	AuthorityHost string `json:"authorityHost"`
+	// GraphEndpoint is the Microsoft Graph endpoint without API version, e.g. https://graph.microsoft.us.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://`
+	GraphEndpoint string `json:"graphEndpoint"`
 }
 
 // EntraSecurityGroupStatus defines the observed state of EntraSecurityGroup
@@ -80,6 +172,8 @@ type EntraSecurityGroupStatus struct {
 	ID string `json:"id,omitempty"`
 	// DisplayName is the display name of the EntraSecurityGroup.
 	DisplayName string `json:"displayName,omitempty"`
+	// AdministrativeUnitID is the object ID of the administrative unit the group was created in.
+	AdministrativeUnitID string `json:"administrativeUnitId,omitempty"`
 	// Users as members of the EntraSecurityGroup.
 	ManagedMemberUsers []string `json:"managedMemberUsers,omitempty"`
 	// groups as members of the EntraSecurityGroup.
@@ -92,6 +186,10 @@ type EntraSecurityGroupStatus struct {
 	OwnerGroups []string `json:"ownerGroups,omitempty"`
 	// SPA as Owners of the EntraSecurityGroup.
 	OwnerServicePrincipals []string `json:"ownerServicePrincipals,omitempty"`
+	// MemberCount is the number of direct members of the group in Entra.
+	MemberCount int32 `json:"memberCount,omitempty"`
+	// MemberFailures lists the members that failed to sync during the last reconciliation.
+	MemberFailures []MemberFailure `json:"memberFailures,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -99,6 +197,7 @@ type EntraSecurityGroupStatus struct {
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraSecurityGroup"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraSecurityGroup"
 // +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The ID of the EntraSecurityGroup in Entra"
+// +kubebuilder:printcolumn:name="Members",type="integer",JSONPath=".status.memberCount",description="The number of direct members of the EntraSecurityGroup",priority=1
 
 // EntraSecurityGroup is the Schema for the entrasecuritygroups API
 type EntraSecurityGroup struct {
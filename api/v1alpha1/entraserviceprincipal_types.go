@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraServicePrincipalSpec defines the desired state of EntraServicePrincipal
+type EntraServicePrincipalSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// AppID is the application (client) ID of the application, which may live in another tenant
+	// for multi-tenant and SaaS applications.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="appId is immutable"
+	AppID string `json:"appId"`
+	// Tags of the service principal. Tags set outside of the resource are kept when empty.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// AppRoleAssignmentRequired restricts sign-in and tokens to the users, groups and service
+	// principals assigned an app role.
+	// +optional
+	AppRoleAssignmentRequired bool `json:"appRoleAssignmentRequired,omitempty"`
+	// Notes about the service principal, e.g. its owner team or purpose.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Notes string `json:"notes,omitempty"`
+	// Owners of the service principal.
+	// +optional
+	Owners []ServicePrincipalOwner `json:"owners,omitempty"`
+}
+
+// ServicePrincipalOwner is a user or service principal owning a service principal.
+type ServicePrincipalOwner struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=User;ServicePrincipal
+	Type string `json:"type"`
+	// +kubebuilder:validation:Required
+	Id string `json:"id"`
+}
+
+// EntraServicePrincipalStatus defines the observed state of EntraServicePrincipal
+type EntraServicePrincipalStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraServicePrincipal.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraServicePrincipal.
+	Phase string `json:"phase,omitempty"`
+	// ID is the object ID of the service principal in Entra, used for group membership and
+	// role assignments.
+	ID string `json:"id,omitempty"`
+	// AppID is the application ID of the service principal.
+	AppID string `json:"appId,omitempty"`
+	// DisplayName is the display name of the service principal, inherited from the application.
+	DisplayName string `json:"displayName,omitempty"`
+	// Adopted reports that the service principal existed before the resource. It is left in
+	// Entra when the resource is deleted.
+	Adopted bool `json:"adopted,omitempty"`
+	// Owners are the owners of the service principal managed by the resource.
+	Owners []string `json:"owners,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraServicePrincipal"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraServicePrincipal"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="The object ID of the service principal in Entra"
+// +kubebuilder:printcolumn:name="AppID",type="string",JSONPath=".spec.appId",description="The application ID of the service principal",priority=1
+
+// EntraServicePrincipal is the Schema for the entraserviceprincipals API
+type EntraServicePrincipal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraServicePrincipalSpec   `json:"spec,omitempty"`
+	Status EntraServicePrincipalStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraServicePrincipalList contains a list of EntraServicePrincipal
+type EntraServicePrincipalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraServicePrincipal `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraServicePrincipal{}, &EntraServicePrincipalList{})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EntraUserSpec defines the desired state of EntraUser. The user is either an external user
+// invited as a guest (invitation) or a cloud-only member user (userPrincipalName).
+// +kubebuilder:validation:XValidation:rule="has(self.invitation) != has(self.userPrincipalName)",message="exactly one of invitation or userPrincipalName must be set"
+type EntraUserSpec struct {
+	ForProvider *ProviderSpec `json:"forProvider,omitempty"`
+	// DisplayName is the name displayed in the address book of the user.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	DisplayName string `json:"displayName,omitempty"`
+	// AccountEnabled enables or disables sign-in of the user. Defaults to true.
+	// +optional
+	AccountEnabled *bool `json:"accountEnabled,omitempty"`
+	// Invitation invites an external user to the tenant as a guest.
+	// +optional
+	Invitation *InvitationSpec `json:"invitation,omitempty"`
+	// UserPrincipalName creates a cloud-only user signing in with this name, e.g.
+	// svc-backup@contoso.onmicrosoft.com. Its generated password is written to a Secret.
+	// +kubebuilder:validation:Pattern=`^[^@\s]+@[^@\s]+$`
+	// +optional
+	UserPrincipalName string `json:"userPrincipalName,omitempty"`
+	// MailNickname is the mail alias of a cloud-only user. Defaults to the part of the user
+	// principal name before the @.
+	// +optional
+	MailNickname string `json:"mailNickname,omitempty"`
+	// PasswordSecretName is the Secret of the namespace the generated password of a cloud-only
+	// user is written to. Defaults to <name>-password.
+	// +optional
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+	// ForceChangePasswordNextSignIn makes a cloud-only user change the generated password on
+	// first sign-in.
+	// +optional
+	ForceChangePasswordNextSignIn bool `json:"forceChangePasswordNextSignIn,omitempty"`
+}
+
+// InvitationSpec describes the invitation sent to an external user.
+type InvitationSpec struct {
+	// Email is the email address of the invited user.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[^@\s]+@[^@\s]+$`
+	Email string `json:"email"`
+	// RedirectURL is where the user lands after redeeming the invitation.
+	// +kubebuilder:default="https://myapps.microsoft.com"
+	// +optional
+	RedirectURL string `json:"redirectUrl,omitempty"`
+	// SendInvitationMessage makes Entra email the invitation to the user. Otherwise the redeem
+	// URL in the status has to be shared with the user.
+	// +optional
+	SendInvitationMessage bool `json:"sendInvitationMessage,omitempty"`
+	// Message is added to the invitation email.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// EntraUserStatus defines the observed state of EntraUser
+type EntraUserStatus struct {
+	// ObservedGeneration is the latest observed generation of the resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions of the EntraUser.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the EntraUser.
+	Phase string `json:"phase,omitempty"`
+	// ID is the object ID of the user in Entra.
+	ID string `json:"id,omitempty"`
+	// UserPrincipalName is the user principal name of the user in Entra.
+	UserPrincipalName string `json:"userPrincipalName,omitempty"`
+	// AccountEnabled reports whether sign-in of the user is enabled in Entra.
+	AccountEnabled bool `json:"accountEnabled,omitempty"`
+	// InviteRedeemURL is the URL the invited user redeems the invitation with.
+	InviteRedeemURL string `json:"inviteRedeemUrl,omitempty"`
+	// InvitationState is the state of the invitation of a guest, PendingAcceptance or Accepted.
+	InvitationState string `json:"invitationState,omitempty"`
+	// PasswordSecretName is the Secret holding the generated password of a cloud-only user.
+	PasswordSecretName string `json:"passwordSecretName,omitempty"`
+	// Adopted reports that a user with the user principal name existed before the resource. It
+	// is neither updated nor deleted in Entra, and no password is generated for it.
+	Adopted bool `json:"adopted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the EntraUser"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The age of the EntraUser"
+// +kubebuilder:printcolumn:name="UPN",type="string",JSONPath=".status.userPrincipalName",description="The user principal name of the EntraUser in Entra"
+// +kubebuilder:printcolumn:name="Invitation",type="string",JSONPath=".status.invitationState",description="The invitation state of a guest EntraUser",priority=1
+
+// EntraUser is the Schema for the entrausers API
+type EntraUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EntraUserSpec   `json:"spec,omitempty"`
+	Status EntraUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// EntraUserList contains a list of EntraUser
+type EntraUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EntraUser{}, &EntraUserList{})
+}
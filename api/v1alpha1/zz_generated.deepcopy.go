@@ -28,20 +28,1622 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppRegCredConfig) DeepCopyInto(out *AppRegCredConfig) {
 	*out = *in
+	if in.CustomCloud != nil {
+		in, out := &in.CustomCloud, &out.CustomCloud
+		*out = new(CustomCloudSpec)
+		**out = **in
+	}
+	if in.ManagedIdentity != nil {
+		in, out := &in.ManagedIdentity, &out.ManagedIdentity
+		*out = new(ManagedIdentitySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRegCredConfig.
+func (in *AppRegCredConfig) DeepCopy() *AppRegCredConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRegCredConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRoleAssignmentPrincipal) DeepCopyInto(out *AppRoleAssignmentPrincipal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRoleAssignmentPrincipal.
+func (in *AppRoleAssignmentPrincipal) DeepCopy() *AppRoleAssignmentPrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRoleAssignmentPrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRoleAssignmentResource) DeepCopyInto(out *AppRoleAssignmentResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRoleAssignmentResource.
+func (in *AppRoleAssignmentResource) DeepCopy() *AppRoleAssignmentResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRoleAssignmentResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationGrantStatus) DeepCopyInto(out *ApplicationGrantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationGrantStatus.
+func (in *ApplicationGrantStatus) DeepCopy() *ApplicationGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessApplications) DeepCopyInto(out *ConditionalAccessApplications) {
+	*out = *in
+	if in.IncludeApplications != nil {
+		in, out := &in.IncludeApplications, &out.IncludeApplications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeApplications != nil {
+		in, out := &in.ExcludeApplications, &out.ExcludeApplications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeUserActions != nil {
+		in, out := &in.IncludeUserActions, &out.IncludeUserActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessApplications.
+func (in *ConditionalAccessApplications) DeepCopy() *ConditionalAccessApplications {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessApplications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessConditions) DeepCopyInto(out *ConditionalAccessConditions) {
+	*out = *in
+	in.Users.DeepCopyInto(&out.Users)
+	in.Applications.DeepCopyInto(&out.Applications)
+	if in.ClientAppTypes != nil {
+		in, out := &in.ClientAppTypes, &out.ClientAppTypes
+		*out = make([]ConditionalAccessClientApp, len(*in))
+		copy(*out, *in)
+	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = new(ConditionalAccessPlatforms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = new(ConditionalAccessLocations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignInRiskLevels != nil {
+		in, out := &in.SignInRiskLevels, &out.SignInRiskLevels
+		*out = make([]ConditionalAccessRiskLevel, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserRiskLevels != nil {
+		in, out := &in.UserRiskLevels, &out.UserRiskLevels
+		*out = make([]ConditionalAccessRiskLevel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessConditions.
+func (in *ConditionalAccessConditions) DeepCopy() *ConditionalAccessConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessGrantControls) DeepCopyInto(out *ConditionalAccessGrantControls) {
+	*out = *in
+	if in.BuiltInControls != nil {
+		in, out := &in.BuiltInControls, &out.BuiltInControls
+		*out = make([]ConditionalAccessGrantControl, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessGrantControls.
+func (in *ConditionalAccessGrantControls) DeepCopy() *ConditionalAccessGrantControls {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessGrantControls)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessLocations) DeepCopyInto(out *ConditionalAccessLocations) {
+	*out = *in
+	if in.IncludeLocations != nil {
+		in, out := &in.IncludeLocations, &out.IncludeLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLocations != nil {
+		in, out := &in.ExcludeLocations, &out.ExcludeLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeLocationRefs != nil {
+		in, out := &in.IncludeLocationRefs, &out.IncludeLocationRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeLocationRefs != nil {
+		in, out := &in.ExcludeLocationRefs, &out.ExcludeLocationRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessLocations.
+func (in *ConditionalAccessLocations) DeepCopy() *ConditionalAccessLocations {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessLocations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessPlatforms) DeepCopyInto(out *ConditionalAccessPlatforms) {
+	*out = *in
+	if in.IncludePlatforms != nil {
+		in, out := &in.IncludePlatforms, &out.IncludePlatforms
+		*out = make([]ConditionalAccessPlatform, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePlatforms != nil {
+		in, out := &in.ExcludePlatforms, &out.ExcludePlatforms
+		*out = make([]ConditionalAccessPlatform, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessPlatforms.
+func (in *ConditionalAccessPlatforms) DeepCopy() *ConditionalAccessPlatforms {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessPlatforms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessSessionControls) DeepCopyInto(out *ConditionalAccessSessionControls) {
+	*out = *in
+	if in.SignInFrequency != nil {
+		in, out := &in.SignInFrequency, &out.SignInFrequency
+		*out = new(ConditionalAccessSignInFrequency)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessSessionControls.
+func (in *ConditionalAccessSessionControls) DeepCopy() *ConditionalAccessSessionControls {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessSessionControls)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessSignInFrequency) DeepCopyInto(out *ConditionalAccessSignInFrequency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessSignInFrequency.
+func (in *ConditionalAccessSignInFrequency) DeepCopy() *ConditionalAccessSignInFrequency {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessSignInFrequency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionalAccessUsers) DeepCopyInto(out *ConditionalAccessUsers) {
+	*out = *in
+	if in.IncludeUsers != nil {
+		in, out := &in.IncludeUsers, &out.IncludeUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeUsers != nil {
+		in, out := &in.ExcludeUsers, &out.ExcludeUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeUserRefs != nil {
+		in, out := &in.IncludeUserRefs, &out.IncludeUserRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeUserRefs != nil {
+		in, out := &in.ExcludeUserRefs, &out.ExcludeUserRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGroups != nil {
+		in, out := &in.IncludeGroups, &out.IncludeGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeGroups != nil {
+		in, out := &in.ExcludeGroups, &out.ExcludeGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGroupRefs != nil {
+		in, out := &in.IncludeGroupRefs, &out.IncludeGroupRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeGroupRefs != nil {
+		in, out := &in.ExcludeGroupRefs, &out.ExcludeGroupRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeRoles != nil {
+		in, out := &in.IncludeRoles, &out.IncludeRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeRoles != nil {
+		in, out := &in.ExcludeRoles, &out.ExcludeRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalAccessUsers.
+func (in *ConditionalAccessUsers) DeepCopy() *ConditionalAccessUsers {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionalAccessUsers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CountryNamedLocation) DeepCopyInto(out *CountryNamedLocation) {
+	*out = *in
+	if in.CountriesAndRegions != nil {
+		in, out := &in.CountriesAndRegions, &out.CountriesAndRegions
+		*out = make([]CountryCode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CountryNamedLocation.
+func (in *CountryNamedLocation) DeepCopy() *CountryNamedLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(CountryNamedLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialGrant) DeepCopyInto(out *CredentialGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialGrant.
+func (in *CredentialGrant) DeepCopy() *CredentialGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CredentialGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialGrantFrom) DeepCopyInto(out *CredentialGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialGrantFrom.
+func (in *CredentialGrantFrom) DeepCopy() *CredentialGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialGrantList) DeepCopyInto(out *CredentialGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CredentialGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialGrantList.
+func (in *CredentialGrantList) DeepCopy() *CredentialGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CredentialGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialGrantSpec) DeepCopyInto(out *CredentialGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]CredentialGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]CredentialGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialGrantSpec.
+func (in *CredentialGrantSpec) DeepCopy() *CredentialGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialGrantTo) DeepCopyInto(out *CredentialGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialGrantTo.
+func (in *CredentialGrantTo) DeepCopy() *CredentialGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomCloudSpec) DeepCopyInto(out *CustomCloudSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomCloudSpec.
+func (in *CustomCloudSpec) DeepCopy() *CustomCloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomCloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegatedGrantStatus) DeepCopyInto(out *DelegatedGrantStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegatedGrantStatus.
+func (in *DelegatedGrantStatus) DeepCopy() *DelegatedGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegatedGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectoryRoleDefinition) DeepCopyInto(out *DirectoryRoleDefinition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectoryRoleDefinition.
+func (in *DirectoryRoleDefinition) DeepCopy() *DirectoryRoleDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectoryRoleDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectoryRolePrincipal) DeepCopyInto(out *DirectoryRolePrincipal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectoryRolePrincipal.
+func (in *DirectoryRolePrincipal) DeepCopy() *DirectoryRolePrincipal {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectoryRolePrincipal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EligibilitySchedule) DeepCopyInto(out *EligibilitySchedule) {
+	*out = *in
+	if in.StartDateTime != nil {
+		in, out := &in.StartDateTime, &out.StartDateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndDateTime != nil {
+		in, out := &in.EndDateTime, &out.EndDateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EligibilitySchedule.
+func (in *EligibilitySchedule) DeepCopy() *EligibilitySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(EligibilitySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EligibleDirectoryRole) DeepCopyInto(out *EligibleDirectoryRole) {
+	*out = *in
+	out.Role = in.Role
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EligibleDirectoryRole.
+func (in *EligibleDirectoryRole) DeepCopy() *EligibleDirectoryRole {
+	if in == nil {
+		return nil
+	}
+	out := new(EligibleDirectoryRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EligibleGroupAccess) DeepCopyInto(out *EligibleGroupAccess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EligibleGroupAccess.
+func (in *EligibleGroupAccess) DeepCopy() *EligibleGroupAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(EligibleGroupAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAdministrativeUnit) DeepCopyInto(out *EntraAdministrativeUnit) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAdministrativeUnit.
+func (in *EntraAdministrativeUnit) DeepCopy() *EntraAdministrativeUnit {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAdministrativeUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAdministrativeUnit) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAdministrativeUnitList) DeepCopyInto(out *EntraAdministrativeUnitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraAdministrativeUnit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAdministrativeUnitList.
+func (in *EntraAdministrativeUnitList) DeepCopy() *EntraAdministrativeUnitList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAdministrativeUnitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAdministrativeUnitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAdministrativeUnitSpec) DeepCopyInto(out *EntraAdministrativeUnitSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAdministrativeUnitSpec.
+func (in *EntraAdministrativeUnitSpec) DeepCopy() *EntraAdministrativeUnitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAdministrativeUnitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAdministrativeUnitStatus) DeepCopyInto(out *EntraAdministrativeUnitStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAdministrativeUnitStatus.
+func (in *EntraAdministrativeUnitStatus) DeepCopy() *EntraAdministrativeUnitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAdministrativeUnitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRegistration) DeepCopyInto(out *EntraAppRegistration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistration.
+func (in *EntraAppRegistration) DeepCopy() *EntraAppRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAppRegistration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRegistrationList) DeepCopyInto(out *EntraAppRegistrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraAppRegistration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationList.
+func (in *EntraAppRegistrationList) DeepCopy() *EntraAppRegistrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRegistrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAppRegistrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRegistrationSpec) DeepCopyInto(out *EntraAppRegistrationSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(AppRegCredConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationSpec.
+func (in *EntraAppRegistrationSpec) DeepCopy() *EntraAppRegistrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRegistrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRegistrationStatus) DeepCopyInto(out *EntraAppRegistrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationStatus.
+func (in *EntraAppRegistrationStatus) DeepCopy() *EntraAppRegistrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRegistrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRoleAssignment) DeepCopyInto(out *EntraAppRoleAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRoleAssignment.
+func (in *EntraAppRoleAssignment) DeepCopy() *EntraAppRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAppRoleAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRoleAssignmentList) DeepCopyInto(out *EntraAppRoleAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraAppRoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRoleAssignmentList.
+func (in *EntraAppRoleAssignmentList) DeepCopy() *EntraAppRoleAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRoleAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraAppRoleAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRoleAssignmentSpec) DeepCopyInto(out *EntraAppRoleAssignmentSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Principal = in.Principal
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRoleAssignmentSpec.
+func (in *EntraAppRoleAssignmentSpec) DeepCopy() *EntraAppRoleAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRoleAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraAppRoleAssignmentStatus) DeepCopyInto(out *EntraAppRoleAssignmentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRoleAssignmentStatus.
+func (in *EntraAppRoleAssignmentStatus) DeepCopy() *EntraAppRoleAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraAppRoleAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraConditionalAccessPolicy) DeepCopyInto(out *EntraConditionalAccessPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraConditionalAccessPolicy.
+func (in *EntraConditionalAccessPolicy) DeepCopy() *EntraConditionalAccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraConditionalAccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraConditionalAccessPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraConditionalAccessPolicyList) DeepCopyInto(out *EntraConditionalAccessPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraConditionalAccessPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraConditionalAccessPolicyList.
+func (in *EntraConditionalAccessPolicyList) DeepCopy() *EntraConditionalAccessPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraConditionalAccessPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraConditionalAccessPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraConditionalAccessPolicySpec) DeepCopyInto(out *EntraConditionalAccessPolicySpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	if in.GrantControls != nil {
+		in, out := &in.GrantControls, &out.GrantControls
+		*out = new(ConditionalAccessGrantControls)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionControls != nil {
+		in, out := &in.SessionControls, &out.SessionControls
+		*out = new(ConditionalAccessSessionControls)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraConditionalAccessPolicySpec.
+func (in *EntraConditionalAccessPolicySpec) DeepCopy() *EntraConditionalAccessPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraConditionalAccessPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraConditionalAccessPolicyStatus) DeepCopyInto(out *EntraConditionalAccessPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraConditionalAccessPolicyStatus.
+func (in *EntraConditionalAccessPolicyStatus) DeepCopy() *EntraConditionalAccessPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraConditionalAccessPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraDirectoryRoleAssignment) DeepCopyInto(out *EntraDirectoryRoleAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraDirectoryRoleAssignment.
+func (in *EntraDirectoryRoleAssignment) DeepCopy() *EntraDirectoryRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraDirectoryRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraDirectoryRoleAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraDirectoryRoleAssignmentList) DeepCopyInto(out *EntraDirectoryRoleAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraDirectoryRoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraDirectoryRoleAssignmentList.
+func (in *EntraDirectoryRoleAssignmentList) DeepCopy() *EntraDirectoryRoleAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraDirectoryRoleAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraDirectoryRoleAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraDirectoryRoleAssignmentSpec) DeepCopyInto(out *EntraDirectoryRoleAssignmentSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Principal = in.Principal
+	out.Role = in.Role
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraDirectoryRoleAssignmentSpec.
+func (in *EntraDirectoryRoleAssignmentSpec) DeepCopy() *EntraDirectoryRoleAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraDirectoryRoleAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraDirectoryRoleAssignmentStatus) DeepCopyInto(out *EntraDirectoryRoleAssignmentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraDirectoryRoleAssignmentStatus.
+func (in *EntraDirectoryRoleAssignmentStatus) DeepCopy() *EntraDirectoryRoleAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraDirectoryRoleAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraEligibleRoleAssignment) DeepCopyInto(out *EntraEligibleRoleAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraEligibleRoleAssignment.
+func (in *EntraEligibleRoleAssignment) DeepCopy() *EntraEligibleRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraEligibleRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraEligibleRoleAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraEligibleRoleAssignmentList) DeepCopyInto(out *EntraEligibleRoleAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraEligibleRoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraEligibleRoleAssignmentList.
+func (in *EntraEligibleRoleAssignmentList) DeepCopy() *EntraEligibleRoleAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraEligibleRoleAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraEligibleRoleAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraEligibleRoleAssignmentSpec) DeepCopyInto(out *EntraEligibleRoleAssignmentSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Principal = in.Principal
+	if in.DirectoryRole != nil {
+		in, out := &in.DirectoryRole, &out.DirectoryRole
+		*out = new(EligibleDirectoryRole)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(EligibleGroupAccess)
+		**out = **in
+	}
+	in.Schedule.DeepCopyInto(&out.Schedule)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraEligibleRoleAssignmentSpec.
+func (in *EntraEligibleRoleAssignmentSpec) DeepCopy() *EntraEligibleRoleAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraEligibleRoleAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraEligibleRoleAssignmentStatus) DeepCopyInto(out *EntraEligibleRoleAssignmentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartDateTime != nil {
+		in, out := &in.StartDateTime, &out.StartDateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndDateTime != nil {
+		in, out := &in.EndDateTime, &out.EndDateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRenewalTime != nil {
+		in, out := &in.LastRenewalTime, &out.LastRenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraEligibleRoleAssignmentStatus.
+func (in *EntraEligibleRoleAssignmentStatus) DeepCopy() *EntraEligibleRoleAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraEligibleRoleAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraNamedLocation) DeepCopyInto(out *EntraNamedLocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraNamedLocation.
+func (in *EntraNamedLocation) DeepCopy() *EntraNamedLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraNamedLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraNamedLocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraNamedLocationList) DeepCopyInto(out *EntraNamedLocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraNamedLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraNamedLocationList.
+func (in *EntraNamedLocationList) DeepCopy() *EntraNamedLocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraNamedLocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraNamedLocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraNamedLocationSpec) DeepCopyInto(out *EntraNamedLocationSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IP != nil {
+		in, out := &in.IP, &out.IP
+		*out = new(IPNamedLocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = new(CountryNamedLocation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraNamedLocationSpec.
+func (in *EntraNamedLocationSpec) DeepCopy() *EntraNamedLocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraNamedLocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraNamedLocationStatus) DeepCopyInto(out *EntraNamedLocationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraNamedLocationStatus.
+func (in *EntraNamedLocationStatus) DeepCopy() *EntraNamedLocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraNamedLocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraPermissionGrant) DeepCopyInto(out *EntraPermissionGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraPermissionGrant.
+func (in *EntraPermissionGrant) DeepCopy() *EntraPermissionGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraPermissionGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraPermissionGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraPermissionGrantList) DeepCopyInto(out *EntraPermissionGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraPermissionGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraPermissionGrantList.
+func (in *EntraPermissionGrantList) DeepCopy() *EntraPermissionGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraPermissionGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraPermissionGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraPermissionGrantSpec) DeepCopyInto(out *EntraPermissionGrantSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PermissionGrantResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraPermissionGrantSpec.
+func (in *EntraPermissionGrantSpec) DeepCopy() *EntraPermissionGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraPermissionGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraPermissionGrantStatus) DeepCopyInto(out *EntraPermissionGrantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DelegatedGrants != nil {
+		in, out := &in.DelegatedGrants, &out.DelegatedGrants
+		*out = make([]DelegatedGrantStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationGrants != nil {
+		in, out := &in.ApplicationGrants, &out.ApplicationGrants
+		*out = make([]ApplicationGrantStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraPermissionGrantStatus.
+func (in *EntraPermissionGrantStatus) DeepCopy() *EntraPermissionGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraPermissionGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraSecurityGroup) DeepCopyInto(out *EntraSecurityGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroup.
+func (in *EntraSecurityGroup) DeepCopy() *EntraSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraSecurityGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraSecurityGroupList) DeepCopyInto(out *EntraSecurityGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EntraSecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupList.
+func (in *EntraSecurityGroupList) DeepCopy() *EntraSecurityGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraSecurityGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EntraSecurityGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraSecurityGroupSpec) DeepCopyInto(out *EntraSecurityGroupSpec) {
+	*out = *in
+	if in.ForProvider != nil {
+		in, out := &in.ForProvider, &out.ForProvider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupTypes != nil {
+		in, out := &in.GroupTypes, &out.GroupTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdministrativeUnit != nil {
+		in, out := &in.AdministrativeUnit, &out.AdministrativeUnit
+		*out = new(GroupAdministrativeUnit)
+		**out = **in
+	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = new([]Owners)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]Owners, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = new([]Members)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]Members, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRegCredConfig.
-func (in *AppRegCredConfig) DeepCopy() *AppRegCredConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupSpec.
+func (in *EntraSecurityGroupSpec) DeepCopy() *EntraSecurityGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AppRegCredConfig)
+	out := new(EntraSecurityGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraAppRegistration) DeepCopyInto(out *EntraAppRegistration) {
+func (in *EntraSecurityGroupStatus) DeepCopyInto(out *EntraSecurityGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedMemberUsers != nil {
+		in, out := &in.ManagedMemberUsers, &out.ManagedMemberUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedMemberGroups != nil {
+		in, out := &in.ManagedMemberGroups, &out.ManagedMemberGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedMemberServicePrincipals != nil {
+		in, out := &in.ManagedMemberServicePrincipals, &out.ManagedMemberServicePrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OwnerGroups != nil {
+		in, out := &in.OwnerGroups, &out.OwnerGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OwnerServicePrincipals != nil {
+		in, out := &in.OwnerServicePrincipals, &out.OwnerServicePrincipals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemberFailures != nil {
+		in, out := &in.MemberFailures, &out.MemberFailures
+		*out = make([]MemberFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupStatus.
+func (in *EntraSecurityGroupStatus) DeepCopy() *EntraSecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EntraSecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntraServicePrincipal) DeepCopyInto(out *EntraServicePrincipal) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -49,18 +1651,18 @@ func (in *EntraAppRegistration) DeepCopyInto(out *EntraAppRegistration) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistration.
-func (in *EntraAppRegistration) DeepCopy() *EntraAppRegistration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraServicePrincipal.
+func (in *EntraServicePrincipal) DeepCopy() *EntraServicePrincipal {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraAppRegistration)
+	out := new(EntraServicePrincipal)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EntraAppRegistration) DeepCopyObject() runtime.Object {
+func (in *EntraServicePrincipal) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -68,31 +1670,31 @@ func (in *EntraAppRegistration) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraAppRegistrationList) DeepCopyInto(out *EntraAppRegistrationList) {
+func (in *EntraServicePrincipalList) DeepCopyInto(out *EntraServicePrincipalList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]EntraAppRegistration, len(*in))
+		*out = make([]EntraServicePrincipal, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationList.
-func (in *EntraAppRegistrationList) DeepCopy() *EntraAppRegistrationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraServicePrincipalList.
+func (in *EntraServicePrincipalList) DeepCopy() *EntraServicePrincipalList {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraAppRegistrationList)
+	out := new(EntraServicePrincipalList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EntraAppRegistrationList) DeepCopyObject() runtime.Object {
+func (in *EntraServicePrincipalList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -100,27 +1702,37 @@ func (in *EntraAppRegistrationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraAppRegistrationSpec) DeepCopyInto(out *EntraAppRegistrationSpec) {
+func (in *EntraServicePrincipalSpec) DeepCopyInto(out *EntraServicePrincipalSpec) {
 	*out = *in
 	if in.ForProvider != nil {
 		in, out := &in.ForProvider, &out.ForProvider
-		*out = new(AppRegCredConfig)
-		**out = **in
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]ServicePrincipalOwner, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationSpec.
-func (in *EntraAppRegistrationSpec) DeepCopy() *EntraAppRegistrationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraServicePrincipalSpec.
+func (in *EntraServicePrincipalSpec) DeepCopy() *EntraServicePrincipalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraAppRegistrationSpec)
+	out := new(EntraServicePrincipalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraAppRegistrationStatus) DeepCopyInto(out *EntraAppRegistrationStatus) {
+func (in *EntraServicePrincipalStatus) DeepCopyInto(out *EntraServicePrincipalStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -129,20 +1741,25 @@ func (in *EntraAppRegistrationStatus) DeepCopyInto(out *EntraAppRegistrationStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraAppRegistrationStatus.
-func (in *EntraAppRegistrationStatus) DeepCopy() *EntraAppRegistrationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraServicePrincipalStatus.
+func (in *EntraServicePrincipalStatus) DeepCopy() *EntraServicePrincipalStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraAppRegistrationStatus)
+	out := new(EntraServicePrincipalStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraSecurityGroup) DeepCopyInto(out *EntraSecurityGroup) {
+func (in *EntraUser) DeepCopyInto(out *EntraUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -150,18 +1767,18 @@ func (in *EntraSecurityGroup) DeepCopyInto(out *EntraSecurityGroup) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroup.
-func (in *EntraSecurityGroup) DeepCopy() *EntraSecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraUser.
+func (in *EntraUser) DeepCopy() *EntraUser {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraSecurityGroup)
+	out := new(EntraUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EntraSecurityGroup) DeepCopyObject() runtime.Object {
+func (in *EntraUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -169,31 +1786,31 @@ func (in *EntraSecurityGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraSecurityGroupList) DeepCopyInto(out *EntraSecurityGroupList) {
+func (in *EntraUserList) DeepCopyInto(out *EntraUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]EntraSecurityGroup, len(*in))
+		*out = make([]EntraUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupList.
-func (in *EntraSecurityGroupList) DeepCopy() *EntraSecurityGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraUserList.
+func (in *EntraUserList) DeepCopy() *EntraUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraSecurityGroupList)
+	out := new(EntraUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EntraSecurityGroupList) DeepCopyObject() runtime.Object {
+func (in *EntraUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -201,50 +1818,37 @@ func (in *EntraSecurityGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraSecurityGroupSpec) DeepCopyInto(out *EntraSecurityGroupSpec) {
+func (in *EntraUserSpec) DeepCopyInto(out *EntraUserSpec) {
 	*out = *in
 	if in.ForProvider != nil {
 		in, out := &in.ForProvider, &out.ForProvider
 		*out = new(ProviderSpec)
-		**out = **in
-	}
-	if in.GroupTypes != nil {
-		in, out := &in.GroupTypes, &out.GroupTypes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Owners != nil {
-		in, out := &in.Owners, &out.Owners
-		*out = new([]Owners)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]Owners, len(*in))
-			copy(*out, *in)
-		}
+	if in.AccountEnabled != nil {
+		in, out := &in.AccountEnabled, &out.AccountEnabled
+		*out = new(bool)
+		**out = **in
 	}
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = new([]Members)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]Members, len(*in))
-			copy(*out, *in)
-		}
+	if in.Invitation != nil {
+		in, out := &in.Invitation, &out.Invitation
+		*out = new(InvitationSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupSpec.
-func (in *EntraSecurityGroupSpec) DeepCopy() *EntraSecurityGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraUserSpec.
+func (in *EntraUserSpec) DeepCopy() *EntraUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraSecurityGroupSpec)
+	out := new(EntraUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntraSecurityGroupStatus) DeepCopyInto(out *EntraSecurityGroupStatus) {
+func (in *EntraUserStatus) DeepCopyInto(out *EntraUserStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -253,44 +1857,94 @@ func (in *EntraSecurityGroupStatus) DeepCopyInto(out *EntraSecurityGroupStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ManagedMemberUsers != nil {
-		in, out := &in.ManagedMemberUsers, &out.ManagedMemberUsers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraUserStatus.
+func (in *EntraUserStatus) DeepCopy() *EntraUserStatus {
+	if in == nil {
+		return nil
 	}
-	if in.ManagedMemberGroups != nil {
-		in, out := &in.ManagedMemberGroups, &out.ManagedMemberGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(EntraUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupAdministrativeUnit) DeepCopyInto(out *GroupAdministrativeUnit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupAdministrativeUnit.
+func (in *GroupAdministrativeUnit) DeepCopy() *GroupAdministrativeUnit {
+	if in == nil {
+		return nil
 	}
-	if in.ManagedMemberServicePrincipals != nil {
-		in, out := &in.ManagedMemberServicePrincipals, &out.ManagedMemberServicePrincipals
+	out := new(GroupAdministrativeUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPNamedLocation) DeepCopyInto(out *IPNamedLocation) {
+	*out = *in
+	if in.IPRanges != nil {
+		in, out := &in.IPRanges, &out.IPRanges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Owners != nil {
-		in, out := &in.Owners, &out.Owners
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPNamedLocation.
+func (in *IPNamedLocation) DeepCopy() *IPNamedLocation {
+	if in == nil {
+		return nil
 	}
-	if in.OwnerGroups != nil {
-		in, out := &in.OwnerGroups, &out.OwnerGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(IPNamedLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvitationSpec) DeepCopyInto(out *InvitationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvitationSpec.
+func (in *InvitationSpec) DeepCopy() *InvitationSpec {
+	if in == nil {
+		return nil
 	}
-	if in.OwnerServicePrincipals != nil {
-		in, out := &in.OwnerServicePrincipals, &out.OwnerServicePrincipals
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(InvitationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedIdentitySpec) DeepCopyInto(out *ManagedIdentitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedIdentitySpec.
+func (in *ManagedIdentitySpec) DeepCopy() *ManagedIdentitySpec {
+	if in == nil {
+		return nil
 	}
+	out := new(ManagedIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntraSecurityGroupStatus.
-func (in *EntraSecurityGroupStatus) DeepCopy() *EntraSecurityGroupStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberFailure) DeepCopyInto(out *MemberFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberFailure.
+func (in *MemberFailure) DeepCopy() *MemberFailure {
 	if in == nil {
 		return nil
 	}
-	out := new(EntraSecurityGroupStatus)
+	out := new(MemberFailure)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -325,9 +1979,44 @@ func (in *Owners) DeepCopy() *Owners {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionGrantResource) DeepCopyInto(out *PermissionGrantResource) {
+	*out = *in
+	if in.DelegatedScopes != nil {
+		in, out := &in.DelegatedScopes, &out.DelegatedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationPermissions != nil {
+		in, out := &in.ApplicationPermissions, &out.ApplicationPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionGrantResource.
+func (in *PermissionGrantResource) DeepCopy() *PermissionGrantResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionGrantResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
+	if in.CustomCloud != nil {
+		in, out := &in.CustomCloud, &out.CustomCloud
+		*out = new(CustomCloudSpec)
+		**out = **in
+	}
+	if in.ManagedIdentity != nil {
+		in, out := &in.ManagedIdentity, &out.ManagedIdentity
+		*out = new(ManagedIdentitySpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
@@ -339,3 +2028,18 @@ func (in *ProviderSpec) DeepCopy() *ProviderSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePrincipalOwner) DeepCopyInto(out *ServicePrincipalOwner) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePrincipalOwner.
+func (in *ServicePrincipalOwner) DeepCopy() *ServicePrincipalOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePrincipalOwner)
+	in.DeepCopyInto(out)
+	return out
+}
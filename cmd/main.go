@@ -17,18 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -38,8 +45,19 @@ import (
 	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 	"github.com/vimal-vijayan/entra-governance/internal/client"
 	"github.com/vimal-vijayan/entra-governance/internal/controller"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/administrativeunits"
 	appregistration "github.com/vimal-vijayan/entra-governance/internal/services/applications"
+	"github.com/vimal-vijayan/entra-governance/internal/services/approleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/services/conditionalaccesspolicies"
+	"github.com/vimal-vijayan/entra-governance/internal/services/directoryroleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/services/eligibleroleassignments"
 	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/services/namedlocations"
+	"github.com/vimal-vijayan/entra-governance/internal/services/permissiongrants"
+	"github.com/vimal-vijayan/entra-governance/internal/services/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/services/users"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -61,6 +79,13 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var groupDeltaInterval time.Duration
+	var groupDeltaConfigMap string
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var traceSampleRatio float64
+	var enableManagedIdentity bool
+	var highPrivilegePermissions string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -72,6 +97,23 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&groupDeltaInterval, "group-delta-interval", 2*time.Minute,
+		"Interval between Microsoft Graph group delta queries used to detect changes to managed groups. "+
+			"Set to 0 to disable delta change detection and rely on periodic requeues only.")
+	flag.StringVar(&groupDeltaConfigMap, "group-delta-configmap", "entra-governance-group-delta",
+		"Name of the ConfigMap in the controller namespace (POD_NAMESPACE) that stores group delta links.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector traces are exported to. Leave empty to disable tracing.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false,
+		"If set, traces are exported to the OTLP collector without TLS.")
+	flag.Float64Var(&traceSampleRatio, "trace-sample-ratio", 1.0,
+		"Fraction of reconciliations that are traced, between 0 and 1.")
+	flag.BoolVar(&enableManagedIdentity, "enable-managed-identity", false,
+		"If set, resources can authenticate to Microsoft Graph with the Azure managed identity of the controller "+
+			"instead of a credential secret. Any namespace can then act with the permissions of that identity.")
+	flag.StringVar(&highPrivilegePermissions, "high-privilege-permissions", strings.Join(controller.DefaultHighPrivilegePermissions, ","),
+		"Comma separated permissions an EntraPermissionGrant only grants once they are listed in its "+
+			"iam.entra.governance.com/approved-permissions annotation. Set to an empty string to disable the approval.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -79,6 +121,17 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:    otlpEndpoint,
+		Insecure:    otlpInsecure,
+		SampleRatio: traceSampleRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -130,6 +183,11 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "c018f4f0.entra.governance.com",
+		// Secrets are watched metadata-only and read from the API server, so that the data of
+		// every Secret in the cluster is never held in the informer cache.
+		Client: ctrlclient.Options{
+			Cache: &ctrlclient.CacheOptions{DisableFor: []ctrlclient.Object{&corev1.Secret{}}},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -147,10 +205,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := metrics.RegisterManagedObjectsCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register managed objects metrics")
+		os.Exit(1)
+	}
+
 	// Initialize client factory and services
-	clientFactory := client.NewClientFactory(mgr.GetClient())
+	clientFactory := client.NewClientFactory(mgr.GetClient(), client.WithManagedIdentity(enableManagedIdentity))
 	groupService := groups.NewService(clientFactory)
 	appService := appregistration.NewService(clientFactory)
+	userService := users.NewService(clientFactory)
+	servicePrincipalService := serviceprincipals.NewService(clientFactory)
+	appRoleAssignmentService := approleassignments.NewService(clientFactory)
+	permissionGrantService := permissiongrants.NewService(clientFactory)
+	directoryRoleAssignmentService := directoryroleassignments.NewService(clientFactory)
+	eligibleRoleAssignmentService := eligibleroleassignments.NewService(clientFactory)
+	administrativeUnitService := administrativeunits.NewService(clientFactory)
+	conditionalAccessService := conditionalaccesspolicies.NewService(clientFactory)
+	namedLocationService := namedlocations.NewService(clientFactory)
 
 	if err = (&controller.EntraAppRegistrationReconciler{
 		Client:     mgr.GetClient(),
@@ -160,14 +232,116 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "EntraAppRegistration")
 		os.Exit(1)
 	}
+
+	// Group delta watcher enqueues EntraSecurityGroups that changed in Entra
+	var groupEvents chan event.GenericEvent
+	podNamespace := os.Getenv("POD_NAMESPACE")
+	if groupDeltaInterval > 0 && podNamespace == "" {
+		setupLog.Info("POD_NAMESPACE is not set, group delta change detection is disabled")
+	}
+	if groupDeltaInterval > 0 && podNamespace != "" {
+		groupEvents = make(chan event.GenericEvent)
+		if err = mgr.Add(&controller.GroupDeltaWatcher{
+			Client:       mgr.GetClient(),
+			Reader:       mgr.GetAPIReader(),
+			GroupService: groupService,
+			Interval:     groupDeltaInterval,
+			TokenStore:   types.NamespacedName{Namespace: podNamespace, Name: groupDeltaConfigMap},
+			Events:       groupEvents,
+		}); err != nil {
+			setupLog.Error(err, "unable to add group delta watcher")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.EntraSecurityGroupReconciler{
 		Client:       mgr.GetClient(),
 		Scheme:       mgr.GetScheme(),
 		GroupService: groupService,
+		GroupEvents:  groupEvents,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EntraSecurityGroup")
 		os.Exit(1)
 	}
+	if err = (&controller.EntraUserReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		UserService: userService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraUser")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraServicePrincipalReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		ServicePrincipalService: servicePrincipalService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraServicePrincipal")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraAppRoleAssignmentReconciler{
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		AppRoleAssignmentService: appRoleAssignmentService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraAppRoleAssignment")
+		os.Exit(1)
+	}
+	approvalRequired := []string{}
+	for _, permission := range strings.Split(highPrivilegePermissions, ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			approvalRequired = append(approvalRequired, permission)
+		}
+	}
+	if err = (&controller.EntraPermissionGrantReconciler{
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		PermissionGrantService:   permissionGrantService,
+		HighPrivilegePermissions: approvalRequired,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraPermissionGrant")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraDirectoryRoleAssignmentReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		RoleAssignmentService: directoryRoleAssignmentService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraDirectoryRoleAssignment")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraEligibleRoleAssignmentReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		EligibilityService: eligibleRoleAssignmentService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraEligibleRoleAssignment")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraAdministrativeUnitReconciler{
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		AdministrativeUnitService: administrativeUnitService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraAdministrativeUnit")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraConditionalAccessPolicyReconciler{
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		ConditionalAccessService: conditionalAccessService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraConditionalAccessPolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.EntraNamedLocationReconciler{
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		NamedLocationService: namedLocationService,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EntraNamedLocation")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -180,8 +354,15 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// flush spans that are still buffered
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
 }
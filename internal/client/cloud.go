@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// Azure clouds the Graph clients can be created for.
+const (
+	CloudPublic          = "Public"
+	CloudUSGovernment    = "USGovernment"
+	CloudUSGovernmentDoD = "USGovernmentDoD"
+	CloudChina           = "China"
+	CloudCustom          = "Custom"
+)
+
+// Cloud selects the token authority and Microsoft Graph endpoint of a tenant.
+type Cloud struct {
+	// Name is one of the Cloud constants, Public when empty.
+	Name string
+	// AuthorityHost and GraphEndpoint are only used by the Custom cloud.
+	AuthorityHost string
+	GraphEndpoint string
+}
+
+// cloudEndpoints are the resolved endpoints of a Cloud.
+type cloudEndpoints struct {
+	configuration cloud.Configuration
+	// graphEndpoint is the Graph endpoint without API version, e.g. https://graph.microsoft.us
+	graphEndpoint string
+	// custom authorities are not known to instance discovery
+	custom bool
+}
+
+// national cloud deployments of Microsoft Graph
+// api doc: https://learn.microsoft.com/en-us/graph/deployments
+var builtinClouds = map[string]cloudEndpoints{
+	CloudPublic:          {configuration: cloud.AzurePublic, graphEndpoint: "https://graph.microsoft.com"},
+	CloudUSGovernment:    {configuration: cloud.AzureGovernment, graphEndpoint: "https://graph.microsoft.us"},
+	CloudUSGovernmentDoD: {configuration: cloud.AzureGovernment, graphEndpoint: "https://dod-graph.microsoft.us"},
+	CloudChina:           {configuration: cloud.AzureChina, graphEndpoint: "https://microsoftgraph.chinacloudapi.cn"},
+}
+
+func (c Cloud) endpoints() (cloudEndpoints, error) {
+	name := c.Name
+	if name == "" {
+		name = CloudPublic
+	}
+	if name != CloudCustom {
+		endpoints, ok := builtinClouds[name]
+		if !ok {
+			return cloudEndpoints{}, fmt.Errorf("unsupported cloud %q", c.Name)
+		}
+		return endpoints, nil
+	}
+
+	if c.AuthorityHost == "" || c.GraphEndpoint == "" {
+		return cloudEndpoints{}, fmt.Errorf("custom cloud requires an authority host and a graph endpoint")
+	}
+	for _, endpoint := range []string{c.AuthorityHost, c.GraphEndpoint} {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return cloudEndpoints{}, fmt.Errorf("invalid custom cloud endpoint %q: an https URL is required", endpoint)
+		}
+	}
+	return cloudEndpoints{
+		configuration: cloud.Configuration{ActiveDirectoryAuthorityHost: c.AuthorityHost, Services: map[cloud.ServiceName]cloud.ServiceConfiguration{}},
+		graphEndpoint: strings.TrimSuffix(c.GraphEndpoint, "/"),
+		custom:        true,
+	}, nil
+}
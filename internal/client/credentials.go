@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Authentication methods of the application registration used to call Microsoft Graph.
+const (
+	AuthMethodClientSecret      = "ClientSecret"
+	AuthMethodClientCertificate = "ClientCertificate"
+)
+
+// Secret keys holding the client credentials.
+const (
+	secretKeyTenantID     = "tenantId"
+	secretKeyClientID     = "clientId"
+	secretKeyClientSecret = "clientSecret"
+	// PEM certificate and key, or PFX archive
+	secretKeyClientCertificate         = "clientCertificate"
+	secretKeyClientCertificatePassword = "clientCertificatePassword"
+)
+
+// credentialFromSecret builds the token credential described by secret, issued by the authority
+// of the given cloud. Without an explicit method, a certificate is used when the secret contains
+// one and the client secret otherwise.
+func credentialFromSecret(secret *corev1.Secret, method string, endpoints cloudEndpoints) (azcore.TokenCredential, error) {
+	tenantID, err := getSecretData(secret, secretKeyTenantID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := getSecretData(secret, secretKeyClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if method == "" {
+		method = detectAuthMethod(secret)
+	}
+
+	switch method {
+	case AuthMethodClientSecret:
+		clientSecret, err := getSecretData(secret, secretKeyClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		return azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, &azidentity.ClientSecretCredentialOptions{
+			ClientOptions:            azcore.ClientOptions{Cloud: endpoints.configuration},
+			DisableInstanceDiscovery: endpoints.custom,
+		})
+	case AuthMethodClientCertificate:
+		certData, password, err := certificateData(secret)
+		if err != nil {
+			return nil, err
+		}
+		certs, key, err := azidentity.ParseCertificates(certData, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate in secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		return azidentity.NewClientCertificateCredential(tenantID, clientID, certs, key, &azidentity.ClientCertificateCredentialOptions{
+			ClientOptions:            azcore.ClientOptions{Cloud: endpoints.configuration},
+			DisableInstanceDiscovery: endpoints.custom,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported auth method %q", method)
+	}
+}
+
+func detectAuthMethod(secret *corev1.Secret) string {
+	if _, ok := secret.Data[secretKeyClientCertificate]; ok {
+		return AuthMethodClientCertificate
+	}
+	if _, ok := secret.Data[corev1.TLSCertKey]; ok {
+		return AuthMethodClientCertificate
+	}
+	return AuthMethodClientSecret
+}
+
+// certificateData returns the certificate and private key of secret, either from
+// clientCertificate (PEM or PFX) or from the tls.crt and tls.key keys of a TLS secret.
+func certificateData(secret *corev1.Secret) ([]byte, []byte, error) {
+	if certData, ok := secret.Data[secretKeyClientCertificate]; ok {
+		return certData, secret.Data[secretKeyClientCertificatePassword], nil
+	}
+
+	certData, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("key %s or %s not found in secret %s/%s", secretKeyClientCertificate, corev1.TLSCertKey, secret.Namespace, secret.Name)
+	}
+	keyData, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("key %s not found in secret %s/%s", corev1.TLSPrivateKeyKey, secret.Namespace, secret.Name)
+	}
+
+	pemData := append(append(append([]byte{}, certData...), '\n'), keyData...)
+	return pemData, nil, nil
+}
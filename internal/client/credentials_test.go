@@ -0,0 +1,162 @@
+package client
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// selfSignedCertificate returns a PEM encoded certificate and its PEM encoded private key.
+func selfSignedCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "entra-governance"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func credentialSecret(data map[string][]byte) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "entra-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			secretKeyTenantID: []byte("00000000-0000-0000-0000-000000000001"),
+			secretKeyClientID: []byte("00000000-0000-0000-0000-000000000002"),
+		},
+	}
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	return secret
+}
+
+func TestDetectAuthMethod(t *testing.T) {
+	g := NewWithT(t)
+	certPEM, keyPEM := selfSignedCertificate(t)
+
+	g.Expect(detectAuthMethod(credentialSecret(map[string][]byte{secretKeyClientSecret: []byte("secret")}))).To(Equal(AuthMethodClientSecret))
+	g.Expect(detectAuthMethod(credentialSecret(nil))).To(Equal(AuthMethodClientSecret))
+	g.Expect(detectAuthMethod(credentialSecret(map[string][]byte{secretKeyClientCertificate: certPEM}))).To(Equal(AuthMethodClientCertificate))
+	g.Expect(detectAuthMethod(credentialSecret(map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}))).To(Equal(AuthMethodClientCertificate))
+	// a certificate wins over a client secret left in the same secret
+	g.Expect(detectAuthMethod(credentialSecret(map[string][]byte{
+		secretKeyClientSecret:      []byte("secret"),
+		secretKeyClientCertificate: certPEM,
+	}))).To(Equal(AuthMethodClientCertificate))
+}
+
+func TestCertificateData(t *testing.T) {
+	certPEM, keyPEM := selfSignedCertificate(t)
+
+	t.Run("PEM certificate and key", func(t *testing.T) {
+		g := NewWithT(t)
+		bundle := append(append([]byte{}, certPEM...), keyPEM...)
+		data, password, err := certificateData(credentialSecret(map[string][]byte{secretKeyClientCertificate: bundle}))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(data).To(Equal(bundle))
+		g.Expect(password).To(BeEmpty())
+
+		certs, key, err := azidentity.ParseCertificates(data, password)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(certs).To(HaveLen(1))
+		g.Expect(key).NotTo(BeNil())
+	})
+
+	t.Run("PFX archive with password", func(t *testing.T) {
+		g := NewWithT(t)
+		pfx := []byte{0x30, 0x82, 0x01, 0x00}
+		data, password, err := certificateData(credentialSecret(map[string][]byte{
+			secretKeyClientCertificate:         pfx,
+			secretKeyClientCertificatePassword: []byte("changeit"),
+		}))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(data).To(Equal(pfx))
+		g.Expect(password).To(Equal([]byte("changeit")))
+	})
+
+	t.Run("TLS secret", func(t *testing.T) {
+		g := NewWithT(t)
+		data, password, err := certificateData(credentialSecret(map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(password).To(BeNil())
+
+		certs, key, err := azidentity.ParseCertificates(data, password)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(certs).To(HaveLen(1))
+		g.Expect(key).NotTo(BeNil())
+	})
+
+	t.Run("TLS secret without key", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := certificateData(credentialSecret(map[string][]byte{corev1.TLSCertKey: certPEM}))
+		g.Expect(err).To(MatchError(ContainSubstring("key tls.key not found in secret default/entra-credentials")))
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := certificateData(credentialSecret(nil))
+		g.Expect(err).To(MatchError(ContainSubstring("key clientCertificate or tls.crt not found")))
+	})
+}
+
+func TestCredentialFromSecret(t *testing.T) {
+	certPEM, keyPEM := selfSignedCertificate(t)
+	endpoints := builtinClouds[CloudPublic]
+
+	t.Run("client secret", func(t *testing.T) {
+		g := NewWithT(t)
+		cred, err := credentialFromSecret(credentialSecret(map[string][]byte{secretKeyClientSecret: []byte("secret")}), "", endpoints)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cred).To(BeAssignableToTypeOf(&azidentity.ClientSecretCredential{}))
+	})
+
+	t.Run("detected certificate", func(t *testing.T) {
+		g := NewWithT(t)
+		cred, err := credentialFromSecret(credentialSecret(map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}), "", endpoints)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cred).To(BeAssignableToTypeOf(&azidentity.ClientCertificateCredential{}))
+	})
+
+	t.Run("explicit method without its key", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := credentialFromSecret(credentialSecret(map[string][]byte{secretKeyClientCertificate: certPEM}), AuthMethodClientSecret, endpoints)
+		g.Expect(err).To(MatchError(ContainSubstring("key clientSecret not found")))
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := credentialFromSecret(credentialSecret(map[string][]byte{secretKeyClientCertificate: []byte("not a certificate")}), "", endpoints)
+		g.Expect(err).To(MatchError(ContainSubstring("failed to parse client certificate in secret default/entra-credentials")))
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := credentialFromSecret(credentialSecret(nil), "ManagedIdentity", endpoints)
+		g.Expect(err).To(MatchError(`unsupported auth method "ManagedIdentity"`))
+	})
+}
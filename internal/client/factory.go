@@ -2,22 +2,77 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	azauth "github.com/microsoft/kiota-authentication-azure-go"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
 )
 
+// ErrManagedIdentityDisabled is returned when a resource selects the managed identity of the
+// controller while it was not enabled with --enable-managed-identity.
+var ErrManagedIdentityDisabled = errors.New("managed identity authentication is not enabled on the controller")
+
+// graphAPIVersion is the Graph API version appended to the Graph endpoint of a cloud.
+const graphAPIVersion = "/v1.0"
+
+// defaultGraphHosts are the hosts the msgraph SDK sends tokens to by default.
+var defaultGraphHosts = []string{"graph.microsoft.com", "graph.microsoft.us", "dod-graph.microsoft.us", "graph.microsoft.de", "microsoftgraph.chinacloudapi.cn", "canary.graph.microsoft.com"}
+
+// ClientFactory is the Factory creating Microsoft Graph clients from Kubernetes secrets.
 type ClientFactory struct {
 	k8s client.Client
+
+	// baseURL overrides the Graph endpoint, including the API version
+	baseURL string
+	// credential overrides the credential built from the referenced secret
+	credential azcore.TokenCredential
+	// managedIdentity allows resources to use the managed identity of the controller
+	managedIdentity bool
+}
+
+// Option customizes a ClientFactory.
+type Option func(*ClientFactory)
+
+// WithBaseURL points the Graph clients at baseURL, e.g. http://127.0.0.1:8080/v1.0, instead of
+// the Graph endpoint of the cloud. Tokens are sent to the host of baseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(cf *ClientFactory) {
+		cf.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithCredential authenticates every Graph client with cred. The credential secret is still
+// read and validated, only the credential built from it is replaced.
+func WithCredential(cred azcore.TokenCredential) Option {
+	return func(cf *ClientFactory) {
+		cf.credential = cred
+	}
+}
+
+// WithManagedIdentity allows resources to authenticate with the Azure managed identity of the
+// controller. It is disabled by default as it lets any namespace act with that identity.
+func WithManagedIdentity(enabled bool) Option {
+	return func(cf *ClientFactory) {
+		cf.managedIdentity = enabled
+	}
 }
 
-func (cf *ClientFactory) ForClientSecret(ctx context.Context, ref SecretRef) (*msgraphsdk.GraphServiceClient, error) {
+// ForClientSecret returns a Graph client authenticated with the client credentials stored in the
+// referenced secret, either a client secret or a client certificate.
+func (cf *ClientFactory) ForClientSecret(ctx context.Context, ref SecretRef) (*GraphClient, error) {
 	logger := log.FromContext(ctx)
 
 	if ref.Namespace == "" && ref.Name == "" {
@@ -25,6 +80,11 @@ func (cf *ClientFactory) ForClientSecret(ctx context.Context, ref SecretRef) (*m
 		return nil, fmt.Errorf("invalid secret reference: namespace and name cannot both be empty")
 	}
 
+	if err := cf.checkGrant(ctx, ref); err != nil {
+		logger.Error(err, "credential secret reference denied", "secret", ref.Name, "namespace", ref.Namespace, "fromNamespace", ref.FromNamespace)
+		return nil, err
+	}
+
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
 	if err := cf.k8s.Get(ctx, key, secret); err != nil {
@@ -32,48 +92,105 @@ func (cf *ClientFactory) ForClientSecret(ctx context.Context, ref SecretRef) (*m
 		return nil, err
 	}
 
-	tenantId, err := getSecretData(secret, "tenantId")
+	endpoints, err := ref.Cloud.endpoints()
 	if err != nil {
-		logger.Error(err, "failed to get tenantId from secret", "secret", ref.Name, "namespace", ref.Namespace)
+		logger.Error(err, "invalid cloud configuration", "cloud", ref.Cloud.Name)
 		return nil, err
 	}
-	clientId, err := getSecretData(secret, "clientId")
+
+	cred, err := credentialFromSecret(secret, ref.AuthMethod, endpoints)
 	if err != nil {
-		logger.Error(err, "failed to get clientId from secret", "secret", ref.Name, "namespace", ref.Namespace)
+		logger.Error(err, "failed to create credentials from secret", "secret", ref.Name, "namespace", ref.Namespace)
 		return nil, err
 	}
-	clientSecret, err := getSecretData(secret, "clientSecret")
+
+	logger.Info("Successfully retrieved client credentials from secret", "secret", ref.Name, "namespace", ref.Namespace)
+	if cf.credential != nil {
+		return cf.setupGraphClient(cf.credential, endpoints.graphEndpoint)
+	}
+
+	return cf.setupGraphClient(cred, endpoints.graphEndpoint)
+}
+
+// ForManagedIdentity returns a Graph client authenticated with the Azure managed identity of the
+// node or pod running the controller, system-assigned or user-assigned by client ID.
+func (cf *ClientFactory) ForManagedIdentity(ctx context.Context, ref ManagedIdentityRef) (*GraphClient, error) {
+	logger := log.FromContext(ctx)
+
+	if !cf.managedIdentity {
+		logger.Error(ErrManagedIdentityDisabled, "managed identity requested", "clientId", ref.ClientID)
+		return nil, ErrManagedIdentityDisabled
+	}
+
+	endpoints, err := ref.Cloud.endpoints()
 	if err != nil {
-		logger.Error(err, "failed to get clientSecret from secret", "secret", ref.Name, "namespace", ref.Namespace)
+		logger.Error(err, "invalid cloud configuration", "cloud", ref.Cloud.Name)
 		return nil, err
 	}
 
-	logger.Info("Successfully retrieved client credentials from secret", "secret", ref.Name, "namespace", ref.Namespace)
-	cred, err := azidentity.NewClientSecretCredential(tenantId, clientId, clientSecret, nil)
+	options := &azidentity.ManagedIdentityCredentialOptions{
+		ClientOptions: azcore.ClientOptions{Cloud: endpoints.configuration},
+	}
+	if ref.ClientID != "" {
+		options.ID = azidentity.ClientID(ref.ClientID)
+	}
+	cred, err := azidentity.NewManagedIdentityCredential(options)
 	if err != nil {
-		logger.Error(err, "failed to create client credentials", "secret", ref.Name, "namespace", ref.Namespace)
+		logger.Error(err, "failed to create managed identity credentials", "clientId", ref.ClientID)
 		return nil, err
 	}
 
-	return cf.setupGraphClient(cred)
-
+	if cf.credential != nil {
+		return cf.setupGraphClient(cf.credential, endpoints.graphEndpoint)
+	}
+	return cf.setupGraphClient(cred, endpoints.graphEndpoint)
 }
 
-func (cf *ClientFactory) ForWorkloadIdentity(ctx context.Context, ref ServiceAccountRef) (*msgraphsdk.GraphServiceClient, error) {
+func (cf *ClientFactory) ForWorkloadIdentity(ctx context.Context, ref ServiceAccountRef) (*GraphClient, error) {
 	//TODO: Implement workload identity
 
 	return nil, fmt.Errorf("workload identity is not implemented yet")
 }
 
-func (cf *ClientFactory) setupGraphClient(cred azcore.TokenCredential) (*msgraphsdk.GraphServiceClient, error) {
-	scope := []string{"https://graph.microsoft.com/.default"}
-	sdk, err := msgraphsdk.NewGraphServiceClientWithCredentials(cred, scope)
+// setupGraphClient creates a Graph client for the Graph endpoint of a cloud, e.g.
+// https://graph.microsoft.us. Tokens are requested for that endpoint and member references
+// are bound to it. The base URL of the factory takes precedence when set.
+func (cf *ClientFactory) setupGraphClient(cred azcore.TokenCredential, graphEndpoint string) (*GraphClient, error) {
+	scope := []string{graphEndpoint + "/.default"}
+	baseURL := graphEndpoint + graphAPIVersion
+	if cf.baseURL != "" {
+		baseURL = cf.baseURL
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid graph base url %q: %w", baseURL, err)
+	}
+	validHosts := append([]string{parsed.Hostname()}, defaultGraphHosts...)
+	cred = metrics.InstrumentCredential(cred)
+	auth, err := azauth.NewAzureIdentityAuthenticationProviderWithScopesAndValidHosts(cred, scope, validHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tracing and metrics middlewares go last so that they observe every retry attempt
+	options := msgraphsdk.GetDefaultClientOptions()
+	middlewares := append(msgraphcore.GetDefaultMiddlewaresWithOptions(&options), tracing.NewGraphMiddleware(), metrics.NewGraphMiddleware())
+	httpClient := msgraphcore.GetDefaultClient(&options, middlewares...)
+
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(auth, nil, nil, httpClient)
 	if err != nil {
 		return nil, err
 	}
-	return sdk, nil
+	adapter.SetBaseUrl(baseURL)
+	graphClient := NewGraphClient(msgraphsdk.NewGraphServiceClient(adapter))
+	graphClient.Permissions = &tokenPermissions{cred: cred, scope: scope}
+	return graphClient, nil
 }
 
-func NewClientFactory(k8s client.Client) *ClientFactory {
-	return &ClientFactory{k8s: k8s}
+func NewClientFactory(k8s client.Client, opts ...Option) *ClientFactory {
+	cf := &ClientFactory{k8s: k8s}
+	for _, opt := range opts {
+		opt(cf)
+	}
+	return cf
 }
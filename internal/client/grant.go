@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// ErrCredentialNotGranted is returned when a resource references a credential secret in another
+// namespace and no CredentialGrant of that namespace allows it.
+var ErrCredentialNotGranted = errors.New("credential secret reference is not granted")
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=credentialgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// checkGrant verifies that ref may be used by the resource it was created for.
+func (cf *ClientFactory) checkGrant(ctx context.Context, ref SecretRef) error {
+	if ref.FromNamespace == "" || ref.FromNamespace == ref.Namespace {
+		return nil
+	}
+
+	grants := &v1alpha1.CredentialGrantList{}
+	if err := cf.k8s.List(ctx, grants, client.InNamespace(ref.Namespace)); err != nil {
+		return fmt.Errorf("failed to list credential grants in namespace %s: %w", ref.Namespace, err)
+	}
+	for _, grant := range grants.Items {
+		if grantAllows(grant.Spec, ref) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no CredentialGrant in namespace %s allows %s resources in namespace %s to use secret %s",
+		ErrCredentialNotGranted, ref.Namespace, ref.FromKind, ref.FromNamespace, ref.Name)
+}
+
+func grantAllows(grant v1alpha1.CredentialGrantSpec, ref SecretRef) bool {
+	fromAllowed := false
+	for _, from := range grant.From {
+		if from.Namespace == ref.FromNamespace && (from.Kind == "" || from.Kind == ref.FromKind) {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	if len(grant.To) == 0 {
+		return true
+	}
+	for _, to := range grant.To {
+		if to.Name == ref.Name {
+			return true
+		}
+	}
+	return false
+}
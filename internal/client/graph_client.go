@@ -2,20 +2,48 @@ package client
 
 import (
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/administrativeunits"
 	"github.com/vimal-vijayan/entra-governance/internal/graph/appregistration"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/conditionalaccess"
 	"github.com/vimal-vijayan/entra-governance/internal/graph/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/namedlocations"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/permissiongrants"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/pim"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/rolemanagement"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/users"
 )
 
+// GraphClient bundles the Graph APIs used by the services. Alternative backends can fill it
+// with their own implementations of the APIs.
 type GraphClient struct {
-	sdk             *msgraphsdk.GraphServiceClient
-	Groups          groups.API
-	AppRegistration appregistration.API
+	sdk                 *msgraphsdk.GraphServiceClient
+	Groups              groups.API
+	AppRegistration     appregistration.API
+	Users               users.API
+	ServicePrincipals   serviceprincipals.API
+	PermissionGrants    permissiongrants.API
+	RoleManagement      rolemanagement.API
+	PIM                 pim.API
+	AdministrativeUnits administrativeunits.API
+	ConditionalAccess   conditionalaccess.API
+	NamedLocations      namedlocations.API
+	// Permissions reports the permissions of the credential, nil when unknown.
+	Permissions PermissionsAPI
 }
 
 func NewGraphClient(sdk *msgraphsdk.GraphServiceClient) *GraphClient {
 	return &GraphClient{
-		sdk:             sdk,
-		Groups:          groups.NewAPI(sdk),
-		AppRegistration: appregistration.NewAPI(sdk),
+		sdk:                 sdk,
+		Groups:              groups.NewAPI(sdk),
+		AppRegistration:     appregistration.NewAPI(sdk),
+		Users:               users.NewAPI(sdk),
+		ServicePrincipals:   serviceprincipals.NewAPI(sdk),
+		PermissionGrants:    permissiongrants.NewAPI(sdk),
+		RoleManagement:      rolemanagement.NewAPI(sdk),
+		PIM:                 pim.NewAPI(sdk),
+		AdministrativeUnits: administrativeunits.NewAPI(sdk),
+		ConditionalAccess:   conditionalaccess.NewAPI(sdk),
+		NamedLocations:      namedlocations.NewAPI(sdk),
 	}
 }
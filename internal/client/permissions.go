@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// Permission is a Microsoft Graph application permission required by a resource kind. It is
+// also satisfied by any of its broader alternatives, e.g. Directory.ReadWrite.All.
+type Permission struct {
+	Name         string
+	Alternatives []string
+}
+
+// PermissionsAPI reports the application permissions granted to the credential of a client.
+type PermissionsAPI interface {
+	// Roles returns the roles claim of the access token issued for Microsoft Graph.
+	Roles(ctx context.Context) ([]string, error)
+}
+
+// MissingPermissions returns the names of the required permissions not satisfied by roles.
+func MissingPermissions(roles []string, required []Permission) []string {
+	var missing []string
+	for _, permission := range required {
+		if slices.Contains(roles, permission.Name) {
+			continue
+		}
+		if slices.ContainsFunc(permission.Alternatives, func(alternative string) bool { return slices.Contains(roles, alternative) }) {
+			continue
+		}
+		missing = append(missing, permission.Name)
+	}
+	return missing
+}
+
+// tokenPermissions reads the roles from the access tokens issued by a credential.
+type tokenPermissions struct {
+	cred  azcore.TokenCredential
+	scope []string
+}
+
+var _ PermissionsAPI = &tokenPermissions{}
+
+// Roles acquires a token and decodes its roles claim. The token is not validated, Graph does
+// that; the claim only tells which permissions the credential has been granted.
+func (p *tokenPermissions) Roles(ctx context.Context) ([]string, error) {
+	token, err := p.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: p.scope})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire token: %w", err)
+	}
+
+	parts := strings.Split(token.Token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("access token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode access token claims: %w", err)
+	}
+	claims := struct {
+		Roles []string `json:"roles"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode access token claims: %w", err)
+	}
+	return claims.Roles, nil
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// ForProvider returns a Graph client authenticated with the credential configured in provider
+// on behalf of obj, a resource of the given kind: the managed identity of the controller or a
+// credential secret.
+func ForProvider(ctx context.Context, factory Factory, kind string, obj metav1.Object, provider *v1alpha1.ProviderSpec) (*GraphClient, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("forProvider spec is nil")
+	}
+	if provider.CredentialSecretRef == "" && provider.ManagedIdentity == nil {
+		return nil, fmt.Errorf("credential secret reference or managed identity is required in forProvider spec")
+	}
+
+	if provider.ManagedIdentity != nil {
+		return factory.ForManagedIdentity(ctx, ManagedIdentityRef{
+			ClientID: provider.ManagedIdentity.ClientID,
+			Cloud:    providerCloud(provider),
+		})
+	}
+
+	ref := NewSecretRef(kind, obj, provider.CredentialSecretRef, provider.CredentialSecretNamespace)
+	ref.AuthMethod = provider.AuthMethod
+	ref.Cloud = providerCloud(provider)
+	return factory.ForClientSecret(ctx, ref)
+}
+
+// CredentialSecret returns the namespace and name of the credential secret configured in
+// provider for obj, an empty name when it has none.
+func CredentialSecret(obj metav1.Object, provider *v1alpha1.ProviderSpec) (string, string) {
+	if provider == nil || provider.CredentialSecretRef == "" {
+		return "", ""
+	}
+	namespace := provider.CredentialSecretNamespace
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	return namespace, provider.CredentialSecretRef
+}
+
+// AppRegistrationProvider returns the provider spec of an EntraAppRegistration, whose
+// forProvider has a type of its own with the same fields.
+func AppRegistrationProvider(config *v1alpha1.AppRegCredConfig) *v1alpha1.ProviderSpec {
+	if config == nil {
+		return nil
+	}
+	return &v1alpha1.ProviderSpec{
+		CredentialSecretRef:       config.CredentialSecretRef,
+		CredentialSecretNamespace: config.CredentialSecretNamespace,
+		AuthMethod:                config.AuthMethod,
+		Cloud:                     config.Cloud,
+		CustomCloud:               config.CustomCloud,
+		ManagedIdentity:           config.ManagedIdentity,
+		ServiceAccountRef:         config.ServiceAccountRef,
+	}
+}
+
+func providerCloud(provider *v1alpha1.ProviderSpec) Cloud {
+	cloud := Cloud{Name: provider.Cloud}
+	if provider.CustomCloud != nil {
+		cloud.AuthorityHost = provider.CustomCloud.AuthorityHost
+		cloud.GraphEndpoint = provider.CustomCloud.GraphEndpoint
+	}
+	return cloud
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// recordingFactory records the credential references it is asked for.
+type recordingFactory struct {
+	secretRefs          []SecretRef
+	managedIdentityRefs []ManagedIdentityRef
+}
+
+func (f *recordingFactory) ForClientSecret(_ context.Context, ref SecretRef) (*GraphClient, error) {
+	f.secretRefs = append(f.secretRefs, ref)
+	return &GraphClient{}, nil
+}
+
+func (f *recordingFactory) ForWorkloadIdentity(context.Context, ServiceAccountRef) (*GraphClient, error) {
+	return &GraphClient{}, nil
+}
+
+func (f *recordingFactory) ForManagedIdentity(_ context.Context, ref ManagedIdentityRef) (*GraphClient, error) {
+	f.managedIdentityRefs = append(f.managedIdentityRefs, ref)
+	return &GraphClient{}, nil
+}
+
+func TestForProvider(t *testing.T) {
+	obj := &v1alpha1.EntraUser{ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "team-a"}}
+
+	t.Run("credential secret", func(t *testing.T) {
+		g := NewWithT(t)
+		factory := &recordingFactory{}
+		_, err := ForProvider(context.Background(), factory, "EntraUser", obj, &v1alpha1.ProviderSpec{
+			CredentialSecretRef:       "entra-credentials",
+			CredentialSecretNamespace: "shared",
+			AuthMethod:                AuthMethodClientCertificate,
+			Cloud:                     CloudCustom,
+			CustomCloud:               &v1alpha1.CustomCloudSpec{AuthorityHost: "https://login.example.com/", GraphEndpoint: "https://graph.example.com"},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(factory.secretRefs).To(Equal([]SecretRef{{
+			Name:          "entra-credentials",
+			Namespace:     "shared",
+			FromKind:      "EntraUser",
+			FromNamespace: "team-a",
+			AuthMethod:    AuthMethodClientCertificate,
+			Cloud:         Cloud{Name: CloudCustom, AuthorityHost: "https://login.example.com/", GraphEndpoint: "https://graph.example.com"},
+		}}))
+	})
+
+	t.Run("managed identity", func(t *testing.T) {
+		g := NewWithT(t)
+		factory := &recordingFactory{}
+		_, err := ForProvider(context.Background(), factory, "EntraUser", obj, &v1alpha1.ProviderSpec{
+			ManagedIdentity: &v1alpha1.ManagedIdentitySpec{ClientID: "identity"},
+			Cloud:           CloudUSGovernment,
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(factory.secretRefs).To(BeEmpty())
+		g.Expect(factory.managedIdentityRefs).To(Equal([]ManagedIdentityRef{{ClientID: "identity", Cloud: Cloud{Name: CloudUSGovernment}}}))
+	})
+
+	t.Run("missing credential", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := ForProvider(context.Background(), &recordingFactory{}, "EntraUser", obj, nil)
+		g.Expect(err).To(MatchError("forProvider spec is nil"))
+		_, err = ForProvider(context.Background(), &recordingFactory{}, "EntraUser", obj, &v1alpha1.ProviderSpec{})
+		g.Expect(err).To(MatchError(ContainSubstring("credential secret reference or managed identity is required")))
+	})
+}
+
+func TestCredentialSecret(t *testing.T) {
+	g := NewWithT(t)
+	obj := &v1alpha1.EntraUser{ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "team-a"}}
+
+	namespace, name := CredentialSecret(obj, &v1alpha1.ProviderSpec{CredentialSecretRef: "entra-credentials"})
+	g.Expect(namespace).To(Equal("team-a"))
+	g.Expect(name).To(Equal("entra-credentials"))
+
+	namespace, name = CredentialSecret(obj, &v1alpha1.ProviderSpec{CredentialSecretRef: "entra-credentials", CredentialSecretNamespace: "shared"})
+	g.Expect(namespace).To(Equal("shared"))
+	g.Expect(name).To(Equal("entra-credentials"))
+
+	_, name = CredentialSecret(obj, &v1alpha1.ProviderSpec{ManagedIdentity: &v1alpha1.ManagedIdentitySpec{}})
+	g.Expect(name).To(BeEmpty())
+	_, name = CredentialSecret(obj, nil)
+	g.Expect(name).To(BeEmpty())
+
+	g.Expect(AppRegistrationProvider(nil)).To(BeNil())
+	g.Expect(AppRegistrationProvider(&v1alpha1.AppRegCredConfig{CredentialSecretRef: "entra-credentials", Cloud: CloudChina})).
+		To(Equal(&v1alpha1.ProviderSpec{CredentialSecretRef: "entra-credentials", Cloud: CloudChina}))
+}
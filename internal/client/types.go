@@ -3,12 +3,52 @@ package client
 import (
 	"context"
 
-	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type SecretRef struct {
 	Name      string
 	Namespace string
+	// FromKind and FromNamespace identify the resource referencing the secret. Secrets outside
+	// of FromNamespace are only used when a CredentialGrant allows it.
+	FromKind      string
+	FromNamespace string
+	// AuthMethod selects how to authenticate with the secret, detected from its keys when empty.
+	AuthMethod string
+	// Cloud is the cloud of the tenant the secret authenticates against.
+	Cloud Cloud
+}
+
+// NewSecretRef references the credential secret name on behalf of obj, a resource of the given
+// kind. The secret is looked up in the namespace of obj when namespace is empty.
+func NewSecretRef(kind string, obj metav1.Object, name, namespace string) SecretRef {
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	return SecretRef{
+		Name:          name,
+		Namespace:     namespace,
+		FromKind:      kind,
+		FromNamespace: obj.GetNamespace(),
+	}
+}
+
+// ManagedIdentityRef selects the Azure managed identity of the controller.
+type ManagedIdentityRef struct {
+	// ClientID of a user-assigned identity, the system-assigned identity when empty.
+	ClientID string
+	// Cloud is the cloud of the tenant of the identity.
+	Cloud Cloud
+}
+
+// UniqueName returns the uniqueName the Entra object managed by obj is tagged with. It is derived
+// from the UID of obj, so that an object created by an interrupted reconciliation can be found
+// again and adopted instead of being created twice.
+func UniqueName(obj metav1.Object) string {
+	if obj.GetUID() == "" {
+		return ""
+	}
+	return "entra-governance-" + string(obj.GetUID())
 }
 
 type ServiceAccountRef struct {
@@ -16,7 +56,12 @@ type ServiceAccountRef struct {
 	Namespace string
 }
 
-type GraphClientInterface interface {
-	ForClientSecret(ctx context.Context, ref SecretRef) (*msgraphsdk.GraphServiceClient, error)
-	ForWorkloadIdentity(ctx context.Context, ref ServiceAccountRef) (*msgraphsdk.GraphServiceClient, error)
+// Factory creates Graph clients for the credentials referenced by managed resources. Services
+// depend on it rather than on ClientFactory so that other backends can be plugged in.
+type Factory interface {
+	ForClientSecret(ctx context.Context, ref SecretRef) (*GraphClient, error)
+	ForWorkloadIdentity(ctx context.Context, ref ServiceAccountRef) (*GraphClient, error)
+	ForManagedIdentity(ctx context.Context, ref ManagedIdentityRef) (*GraphClient, error)
 }
+
+var _ Factory = &ClientFactory{}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultHighPrivilegePermissions are the permissions that are only granted once they are listed
+// in the approved permissions annotation of the resource. They allow taking over the tenant or
+// reading and changing the data of every user.
+var DefaultHighPrivilegePermissions = []string{
+	"Application.ReadWrite.All",
+	"AppRoleAssignment.ReadWrite.All",
+	"DelegatedPermissionGrant.ReadWrite.All",
+	"Directory.ReadWrite.All",
+	"RoleManagement.ReadWrite.Directory",
+	"Policy.ReadWrite.ConditionalAccess",
+	"User.ReadWrite.All",
+	"Group.ReadWrite.All",
+	"GroupMember.ReadWrite.All",
+	"Mail.ReadWrite",
+	"Mail.Send",
+	"Files.ReadWrite.All",
+	"Sites.FullControl.All",
+	"Sites.ReadWrite.All",
+}
+
+// ApprovedPermissions returns the permissions listed in the comma separated approved permissions
+// annotation of obj.
+func ApprovedPermissions(obj client.Object) []string {
+	var approved []string
+	for _, permission := range strings.Split(obj.GetAnnotations()[approvedPermissionsAnnotation], ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			approved = append(approved, permission)
+		}
+	}
+	return approved
+}
+
+// UnapprovedPermissions returns the requested permissions that are high privilege and not
+// approved. Permission names are compared case-insensitively, like Entra does.
+func UnapprovedPermissions(requested, highPrivilege, approved []string) []string {
+	contains := func(list []string, permission string) bool {
+		return slices.ContainsFunc(list, func(candidate string) bool {
+			return strings.EqualFold(candidate, permission)
+		})
+	}
+
+	var unapproved []string
+	for _, permission := range requested {
+		if contains(highPrivilege, permission) && !contains(approved, permission) && !contains(unapproved, permission) {
+			unapproved = append(unapproved, permission)
+		}
+	}
+	return unapproved
+}
+
+// SetApprovalCondition records the high privilege permissions waiting for approval in the
+// Approved condition. It returns whether all requested permissions are approved.
+func SetApprovalCondition(conditions *[]metav1.Condition, unapproved []string, generation int64) bool {
+	condition := metav1.Condition{
+		Type:               conditionTypeApproved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "PermissionsApproved",
+		Message:            "all requested high privilege permissions are approved",
+	}
+	if len(unapproved) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ApprovalRequired"
+		condition.Message = "high privilege permissions must be listed in the " + approvedPermissionsAnnotation + " annotation: " + strings.Join(unapproved, ", ")
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+	return len(unapproved) == 0
+}
@@ -5,10 +5,71 @@ import "time"
 const (
 	defaultRequeueDuration           = 10 * time.Minute
 	faildStatusUpdateRequeueDuration = 10 * time.Second
+	deltaWatchRequeueDuration        = 1 * time.Hour
+
+	// pausedAnnotation freezes reconciliation of a resource when set to "true"
+	pausedAnnotation = "iam.entra.governance.com/paused"
+	// approvedPermissionsAnnotation lists the high privilege permissions, comma separated, an
+	// EntraPermissionGrant is approved to grant
+	approvedPermissionsAnnotation = "iam.entra.governance.com/approved-permissions"
+
+	// condition types
+	conditionTypePaused           = "Paused"
+	conditionTypeCredentialsValid = "CredentialsValid"
+	conditionTypeApproved         = "Approved"
+	conditionTypeValid            = "Valid"
 
 	// Entra group constants
 	entraSecurityGroupFinalizer = "finalizer.entraSecurityGroup.iam.entra.governance.com"
+	// memberUserRefField indexes groups by the EntraUsers referenced as members
+	memberUserRefField = ".spec.members.userRef"
+	// memberServicePrincipalRefField indexes groups by the EntraServicePrincipals referenced as members
+	memberServicePrincipalRefField = ".spec.members.servicePrincipalRef"
+	// groupAdministrativeUnitRefField indexes groups by the EntraAdministrativeUnit they are created in
+	groupAdministrativeUnitRefField = ".spec.administrativeUnit.ref"
 
 	// Entra app registration constants
 	entraAppRegistrationFinalizer = "finalizer.entraAppRegistration.iam.entra.governance.com"
+
+	// Entra user constants
+	entraUserFinalizer = "finalizer.entraUser.iam.entra.governance.com"
+	// keys of the password Secret of cloud-only users
+	userPrincipalNameKey = "userPrincipalName"
+	userPasswordKey      = "password"
+
+	// Entra service principal constants
+	entraServicePrincipalFinalizer = "finalizer.entraServicePrincipal.iam.entra.governance.com"
+
+	// Entra app role assignment constants
+	entraAppRoleAssignmentFinalizer = "finalizer.entraAppRoleAssignment.iam.entra.governance.com"
+	// appRoleAssignmentRefField indexes assignments by the <type>/<name> of the resources they reference
+	appRoleAssignmentRefField = ".spec.refs"
+
+	// Entra permission grant constants
+	entraPermissionGrantFinalizer = "finalizer.entraPermissionGrant.iam.entra.governance.com"
+	// permissionGrantAppRegistrationRefField indexes grants by the EntraAppRegistration they consent for
+	permissionGrantAppRegistrationRefField = ".spec.appRegistrationRef"
+
+	// Entra directory role assignment constants
+	entraDirectoryRoleAssignmentFinalizer = "finalizer.entraDirectoryRoleAssignment.iam.entra.governance.com"
+	// directoryRoleAssignmentRefField indexes assignments by the <type>/<name> of the principal they reference
+	directoryRoleAssignmentRefField = ".spec.principal.ref"
+
+	// Entra eligible role assignment constants
+	entraEligibleRoleAssignmentFinalizer = "finalizer.entraEligibleRoleAssignment.iam.entra.governance.com"
+	// eligibleRoleAssignmentRefField indexes eligibilities by the <type>/<name> of the resources they reference
+	eligibleRoleAssignmentRefField = ".spec.refs"
+	// defaultEligibilityRenewBefore is how long before their end eligibilities with a duration are extended
+	defaultEligibilityRenewBefore = 7 * 24 * time.Hour
+
+	// Entra administrative unit constants
+	entraAdministrativeUnitFinalizer = "finalizer.entraAdministrativeUnit.iam.entra.governance.com"
+
+	// Entra conditional access policy constants
+	entraConditionalAccessPolicyFinalizer = "finalizer.entraConditionalAccessPolicy.iam.entra.governance.com"
+	// conditionalAccessPolicyRefField indexes policies by the <type>/<name> of the users and groups they reference
+	conditionalAccessPolicyRefField = ".spec.refs"
+
+	// Entra named location constants
+	entraNamedLocationFinalizer = "finalizer.entraNamedLocation.iam.entra.governance.com"
 )
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	entraclient "github.com/vimal-vijayan/entra-governance/internal/client"
+)
+
+// credentialSecretField indexes resources by the namespace/name of their credential secret
+// (forProvider.credentialSecretRef), so that secret changes enqueue the resources using them.
+const credentialSecretField = ".spec.forProvider.credentialSecretRef"
+
+// credentialSecretKey returns the index key of the credential secret name in namespace.
+func credentialSecretKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// indexCredentialSecret registers the credentialSecretField index of obj, one of the managed
+// resource kinds.
+func indexCredentialSecret(ctx context.Context, mgr ctrl.Manager, obj client.Object) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, obj, credentialSecretField, func(o client.Object) []string {
+		namespace, name := entraclient.CredentialSecret(o, providerOf(o))
+		if name == "" {
+			return nil
+		}
+		return []string{credentialSecretKey(namespace, name)}
+	})
+}
+
+// providerOf returns the provider spec of a managed resource, nil for other objects.
+func providerOf(obj client.Object) *entragov.ProviderSpec {
+	switch o := obj.(type) {
+	case *entragov.EntraSecurityGroup:
+		return o.Spec.ForProvider
+	case *entragov.EntraAppRegistration:
+		return entraclient.AppRegistrationProvider(o.Spec.ForProvider)
+	case *entragov.EntraUser:
+		return o.Spec.ForProvider
+	case *entragov.EntraServicePrincipal:
+		return o.Spec.ForProvider
+	case *entragov.EntraAppRoleAssignment:
+		return o.Spec.ForProvider
+	case *entragov.EntraPermissionGrant:
+		return o.Spec.ForProvider
+	case *entragov.EntraDirectoryRoleAssignment:
+		return o.Spec.ForProvider
+	case *entragov.EntraEligibleRoleAssignment:
+		return o.Spec.ForProvider
+	case *entragov.EntraAdministrativeUnit:
+		return o.Spec.ForProvider
+	case *entragov.EntraConditionalAccessPolicy:
+		return o.Spec.ForProvider
+	case *entragov.EntraNamedLocation:
+		return o.Spec.ForProvider
+	}
+	return nil
+}
+
+// enqueueForCredentialSecret enqueues the resources of list referencing a Secret, so that
+// created and rotated secrets take effect immediately instead of on the next periodic requeue.
+func enqueueForCredentialSecret(c client.Client, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(credentialSecretRequests(c, list))
+}
+
+// credentialSecretRequests maps a Secret to the resources of list referencing it through the
+// credentialSecretField index.
+func credentialSecretRequests(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, secret client.Object) []reconcile.Request {
+		logger := log.FromContext(ctx)
+
+		resources := list.DeepCopyObject().(client.ObjectList)
+		key := credentialSecretKey(secret.GetNamespace(), secret.GetName())
+		if err := c.List(ctx, resources, client.MatchingFields{credentialSecretField: key}); err != nil {
+			logger.Error(err, "failed to list resources referencing credential secret", "secret", key)
+			return nil
+		}
+
+		items, err := meta.ExtractList(resources)
+		if err != nil {
+			logger.Error(err, "failed to extract resources referencing credential secret", "secret", key)
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+			}
+		}
+		return requests
+	}
+}
+
+// SetCredentialsCondition records the outcome of the credential pre-flight check, the missing
+// permissions or the error of the check, in the CredentialsValid condition. It returns whether
+// the credential is valid.
+func SetCredentialsCondition(conditions *[]metav1.Condition, missing []string, checkErr error, generation int64) bool {
+	condition := metav1.Condition{
+		Type:               conditionTypeCredentialsValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "PermissionsGranted",
+		Message:            "the credential has the required Microsoft Graph permissions",
+	}
+	switch {
+	case checkErr != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CredentialUnavailable"
+		condition.Message = checkErr.Error()
+	case len(missing) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "MissingPermissions"
+		condition.Message = fmt.Sprintf("the credential is missing Microsoft Graph application permissions: %s", strings.Join(missing, ", "))
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+	return condition.Status == metav1.ConditionTrue
+}
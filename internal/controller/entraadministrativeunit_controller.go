@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphunits "github.com/vimal-vijayan/entra-governance/internal/graph/administrativeunits"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/administrativeunits"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraAdministrativeUnitReconciler reconciles a EntraAdministrativeUnit object
+type EntraAdministrativeUnitReconciler struct {
+	client.Client
+	Scheme                    *runtime.Scheme
+	AdministrativeUnitService administrativeunits.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraadministrativeunits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraadministrativeunits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraadministrativeunits/finalizers,verbs=update
+
+func (r *EntraAdministrativeUnitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraAdministrativeUnit.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraAdministrativeUnit --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	entraUnit := &entragov.EntraAdministrativeUnit{}
+	if err := r.Get(ctx, req.NamespacedName, entraUnit); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraAdministrativeUnit resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraAdministrativeUnit")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraUnit)
+	if err := PatchStatus(ctx, r.Client, entraUnit, func() {
+		SetPausedCondition(&entraUnit.Status.Conditions, paused, entraUnit.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAdministrativeUnit paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraAdministrativeUnit reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, entraUnit, entraAdministrativeUnitFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !entraUnit.DeletionTimestamp.IsZero() {
+		logger.Info("EntraAdministrativeUnit resource is being deleted. skipping reconciliation.")
+		return r.deleteUnit(ctx, entraUnit)
+	}
+
+	// Pre-flight: make sure the credential may manage administrative units before writing to Entra
+	missing, checkErr := r.AdministrativeUnitService.CheckCredentials(ctx, *entraUnit)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraUnit, func() {
+		valid = SetCredentialsCondition(&entraUnit.Status.Conditions, missing, checkErr, entraUnit.Generation)
+		if !valid {
+			entraUnit.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAdministrativeUnit credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if entraUnit.Status.ID == "" {
+		return r.createUnit(ctx, entraUnit)
+	}
+
+	return r.syncUnit(ctx, entraUnit)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraAdministrativeUnitReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraAdministrativeUnit{})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraAdministrativeUnit{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraAdministrativeUnitList{}), builder.OnlyMetadata).
+		Complete(r)
+}
+
+// createUnit creates the administrative unit in Entra and records it in status.
+func (r *EntraAdministrativeUnitReconciler) createUnit(ctx context.Context, entraUnit *entragov.EntraAdministrativeUnit) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	unit, err := r.AdministrativeUnitService.Create(ctx, *entraUnit)
+	if err != nil {
+		logger.Error(err, "failed to create Entra administrative unit", "displayName", entraUnit.Spec.DisplayName)
+		if patchErr := PatchStatus(ctx, r.Client, entraUnit, func() {
+			entraUnit.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraAdministrativeUnit status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraUnit, func() {
+		entraUnit.Status.ID = unit.ID
+		setUnitStatus(&entraUnit.Status, unit)
+		entraUnit.Status.ObservedGeneration = entraUnit.Generation
+		entraUnit.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAdministrativeUnit status with UnitID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra administrative unit", "UnitID", unit.ID, "displayName", unit.DisplayName)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncUnit applies the spec to the administrative unit in Entra and records its state in status.
+func (r *EntraAdministrativeUnitReconciler) syncUnit(ctx context.Context, entraUnit *entragov.EntraAdministrativeUnit) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	unit, statusCode, err := r.AdministrativeUnitService.Get(ctx, *entraUnit, entraUnit.Status.ID)
+	if err != nil {
+		// only an administrative unit confirmed missing is forgotten, it is created again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra administrative unit by ID from status", "UnitID", entraUnit.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra administrative unit from status no longer exists in Entra", "UnitID", entraUnit.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraAdministrativeUnit", "AdministrativeUnitMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, entraUnit, func() {
+			entraUnit.Status.ID = ""
+			entraUnit.Status.DisplayName = ""
+			entraUnit.Status.MembershipType = ""
+			entraUnit.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraAdministrativeUnit status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if !unitInSync(unit, entraUnit.Spec) {
+		logger.Info("Entra administrative unit is not in sync. updating administrative unit.", "UnitID", unit.ID)
+		if err := r.AdministrativeUnitService.Update(ctx, *entraUnit, unit.ID); err != nil {
+			logger.Error(err, "failed to update Entra administrative unit", "UnitID", unit.ID)
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		unit.DisplayName = entraUnit.Spec.DisplayName
+		unit.MembershipType = graphunits.MembershipType(entraUnit.Spec)
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraUnit, func() {
+		setUnitStatus(&entraUnit.Status, unit)
+		entraUnit.Status.ObservedGeneration = entraUnit.Generation
+		entraUnit.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAdministrativeUnit status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// deleteUnit deletes the administrative unit in Entra and removes the finalizer. Its members,
+// e.g. the groups created in it, are kept.
+func (r *EntraAdministrativeUnitReconciler) deleteUnit(ctx context.Context, entraUnit *entragov.EntraAdministrativeUnit) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraUnit.Status.ID != "" {
+		_, statusCode, err := r.AdministrativeUnitService.Get(ctx, *entraUnit, entraUnit.Status.ID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("Entra administrative unit not found in Entra. Removing finalizer.")
+		case err != nil:
+			logger.Error(err, "failed to get Entra administrative unit in Entra during deletion")
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		default:
+			if err := r.AdministrativeUnitService.Delete(ctx, *entraUnit, entraUnit.Status.ID); err != nil {
+				logger.Error(err, "failed to delete Entra administrative unit in Entra")
+				return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+			}
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, entraUnit, entraAdministrativeUnitFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraAdministrativeUnit")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraAdministrativeUnit. deletion complete.")
+	return ctrl.Result{}, nil
+}
+
+// unitInSync reports whether the administrative unit in Entra matches the updatable properties
+// of the spec. The membership rule is only compared for dynamic administrative units.
+func unitInSync(unit *graphunits.AdministrativeUnitResponse, spec entragov.EntraAdministrativeUnitSpec) bool {
+	membershipType := graphunits.MembershipType(spec)
+	if unit.DisplayName != spec.DisplayName || unit.Description != spec.Description || unit.MembershipType != membershipType {
+		return false
+	}
+	return membershipType != graphunits.MembershipTypeDynamic || unit.MembershipRule == spec.MembershipRule
+}
+
+func setUnitStatus(status *entragov.EntraAdministrativeUnitStatus, unit *graphunits.AdministrativeUnitResponse) {
+	status.DisplayName = unit.DisplayName
+	status.MembershipType = unit.MembershipType
+	status.IsMemberManagementRestricted = unit.IsMemberManagementRestricted
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	entraclient "github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/services/administrativeunits"
+)
+
+var _ = Describe("EntraAdministrativeUnit Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraAdministrativeUnitReconciler
+		var resources *testResources
+
+		createUnit := func(name string, spec iamv1alpha1.EntraAdministrativeUnitSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraAdministrativeUnit{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileUnit := func(key types.NamespacedName) *iamv1alpha1.EntraAdministrativeUnit {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraAdministrativeUnit{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraAdministrativeUnitReconciler{
+				Client:                    k8sClient,
+				Scheme:                    k8sClient.Scheme(),
+				AdministrativeUnitService: administrativeunits.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should create the administrative unit and apply changes of the spec", func() {
+			key := createUnit("finance", iamv1alpha1.EntraAdministrativeUnitSpec{
+				DisplayName:                  "Finance",
+				Description:                  "Groups administered by finance",
+				IsMemberManagementRestricted: true,
+			})
+
+			resource := reconcileUnit(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.MembershipType).To(Equal("Assigned"))
+			Expect(resource.Status.IsMemberManagementRestricted).To(BeTrue())
+			unit, ok := graphServer.Object("administrativeUnits", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(unit).To(HaveKeyWithValue("displayName", "Finance"))
+			Expect(unit).To(HaveKeyWithValue("isMemberManagementRestricted", true))
+
+			resource = reconcileUnit(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("making the membership dynamic")
+			resource.Spec.MembershipType = "Dynamic"
+			resource.Spec.MembershipRule = `(user.department -eq "Finance")`
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileUnit(key)
+			Expect(resource.Status.MembershipType).To(Equal("Dynamic"))
+			unit, _ = graphServer.Object("administrativeUnits", resource.Status.ID)
+			Expect(unit).To(HaveKeyWithValue("membershipRule", `(user.department -eq "Finance")`))
+			Expect(unit).To(HaveKeyWithValue("membershipRuleProcessingState", "On"))
+
+			By("creating the administrative unit again when it was deleted outside of the controller")
+			graphServer.DeleteObject("administrativeUnits", resource.Status.ID)
+			resource = reconcileUnit(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			resource = reconcileUnit(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+
+			By("deleting the administrative unit with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("administrativeUnits", resource.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should adopt the administrative unit created by an interrupted reconciliation", func() {
+			key := createUnit("procurement", iamv1alpha1.EntraAdministrativeUnitSpec{DisplayName: "Procurement"})
+			resource := &iamv1alpha1.EntraAdministrativeUnit{}
+			Expect(k8sClient.Get(ctx, key, resource)).To(Succeed())
+
+			By("creating the administrative unit in Entra without recording it in status")
+			unitID := graphServer.AddObject("administrativeUnits", map[string]any{
+				"displayName": "Procurement",
+				"uniqueName":  entraclient.UniqueName(resource),
+			})
+			creates := graphServer.CountRequests("POST", "/directory/administrativeUnits")
+
+			resource = reconcileUnit(key)
+			Expect(resource.Status.ID).To(Equal(unitID))
+			Expect(graphServer.CountRequests("POST", "/directory/administrativeUnits")).To(Equal(creates))
+		})
+
+		It("should tag created administrative units with the unique name of the resource", func() {
+			key := createUnit("legal", iamv1alpha1.EntraAdministrativeUnitSpec{DisplayName: "Legal"})
+
+			resource := reconcileUnit(key)
+			unit, ok := graphServer.Object("administrativeUnits", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(unit["uniqueName"]).To(Equal(entraclient.UniqueName(resource)))
+		})
+
+		It("should create groups in the referenced administrative unit once it exists", func() {
+			groupKey := createTestGroup(ctx, "payroll-admins", iamv1alpha1.EntraSecurityGroupSpec{
+				AdministrativeUnit: &iamv1alpha1.GroupAdministrativeUnit{Ref: "payroll"},
+			})
+
+			By("waiting for the referenced administrative unit")
+			group := reconcileTestGroup(ctx, groupKey)
+			Expect(group.Status.Phase).To(Equal("Pending"))
+			Expect(group.Status.ID).To(BeEmpty())
+
+			key := createUnit("payroll", iamv1alpha1.EntraAdministrativeUnitSpec{DisplayName: "Payroll"})
+			unit := reconcileUnit(key)
+			Expect(unit.Status.ID).NotTo(BeEmpty())
+
+			group = reconcileTestGroup(ctx, groupKey)
+			Expect(group.Status.ID).NotTo(BeEmpty())
+			Expect(group.Status.AdministrativeUnitID).To(Equal(unit.Status.ID))
+			Expect(graphServer.AdministrativeUnitMembers(unit.Status.ID)).To(ConsistOf(group.Status.ID))
+		})
+	})
+})
@@ -19,27 +19,34 @@ package controller
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 	appregistration "github.com/vimal-vijayan/entra-governance/internal/services/applications"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
 )
 
 // EntraAppRegistrationReconciler reconciles a EntraAppRegistration object
 type EntraAppRegistrationReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
-	AppService *appregistration.Service
+	AppService appregistration.API
 }
 
 // +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraappregistrations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraappregistrations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraappregistrations/finalizers,verbs=update
 
-func (r *EntraAppRegistrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *EntraAppRegistrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraAppRegistration.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("------------------ Reconciling EntraAppRegistration --------------------", "name", req.Name, "namespace", req.Namespace)
 
@@ -51,6 +58,19 @@ func (r *EntraAppRegistrationReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraAppReg)
+	if err := PatchStatus(ctx, r.Client, entraAppReg, func() {
+		SetPausedCondition(&entraAppReg.Status.Conditions, paused, entraAppReg.Generation)
+	}); err != nil {
+		logger.Error(err, "Failed to update EntraAppRegistration paused condition", "appName", entraAppReg.Name)
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraAppRegistration reconciliation is paused. skipping reconciliation.", "appName", entraAppReg.Name)
+		return ctrl.Result{}, nil
+	}
+
 	// Ensure finalizer is present
 	if err := EnsureFinalizer(ctx, r.Client, entraAppReg, entraAppRegistrationFinalizer); err != nil {
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
@@ -61,6 +81,27 @@ func (r *EntraAppRegistrationReconciler) Reconcile(ctx context.Context, req ctrl
 		return r.deleteAppRegistration(ctx, entraAppReg)
 	}
 
+	// Pre-flight: make sure the credential may manage applications before writing to Entra
+	missing, checkErr := r.AppService.CheckCredentials(ctx, *entraAppReg)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraAppReg, func() {
+		valid = SetCredentialsCondition(&entraAppReg.Status.Conditions, missing, checkErr, entraAppReg.Generation)
+		if !valid {
+			entraAppReg.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "Failed to update EntraAppRegistration credentials condition", "appName", entraAppReg.Name)
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "Credential pre-flight check failed", "appName", entraAppReg.Name)
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("Credential is missing Microsoft Graph permissions. skipping reconciliation.", "appName", entraAppReg.Name, "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
 	if entraAppReg.Status.AppRegistrationID == "" && entraAppReg.Status.AppRegistrationObjID == "" {
 		return r.createAppRegistration(ctx, entraAppReg)
 	} else {
@@ -76,8 +117,14 @@ func (r *EntraAppRegistrationReconciler) Reconcile(ctx context.Context, req ctrl
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *EntraAppRegistrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraAppRegistration{})
+	if err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&entragov.EntraAppRegistration{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraAppRegistrationList{}), builder.OnlyMetadata).
 		Complete(r)
 }
 
@@ -90,15 +137,15 @@ func (r *EntraAppRegistrationReconciler) createAppRegistration(ctx context.Conte
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
 
-	entraAppReg.Status.AppRegistrationID = clientId
-	entraAppReg.Status.AppRegistrationObjID = principalId
-	entraAppReg.Status.Phase = "Available"
-	entraAppReg.Status.AppRegistrationName = entraAppReg.Name
-	entraAppReg.Status.ObservedGeneration = entraAppReg.Generation
 	//TODO: Add conditions and messages in status
 	// entraAppReg.Status.Message = "App registration created successfully in Entra"
-
-	if err := r.Status().Update(ctx, entraAppReg); err != nil {
+	if err := PatchStatus(ctx, r.Client, entraAppReg, func() {
+		entraAppReg.Status.AppRegistrationID = clientId
+		entraAppReg.Status.AppRegistrationObjID = principalId
+		entraAppReg.Status.Phase = "Available"
+		entraAppReg.Status.AppRegistrationName = entraAppReg.Name
+		entraAppReg.Status.ObservedGeneration = entraAppReg.Generation
+	}); err != nil {
 		logger.Error(err, "Failed to update EntraAppRegistration status after creation", "appName", entraAppReg.Name)
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	appregistration "github.com/vimal-vijayan/entra-governance/internal/services/applications"
 )
 
 var _ = Describe("EntraAppRegistration Controller", func() {
@@ -38,47 +39,69 @@ var _ = Describe("EntraAppRegistration Controller", func() {
 
 		typeNamespacedName := types.NamespacedName{
 			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Namespace: "default",
 		}
-		entraappregistration := &iamv1alpha1.EntraAppRegistration{}
+		var controllerReconciler *EntraAppRegistrationReconciler
 
 		BeforeEach(func() {
+			controllerReconciler = &EntraAppRegistrationReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				AppService: appregistration.NewService(clientFactory),
+			}
+
 			By("creating the custom resource for the Kind EntraAppRegistration")
-			err := k8sClient.Get(ctx, typeNamespacedName, entraappregistration)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &iamv1alpha1.EntraAppRegistration{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource := &iamv1alpha1.EntraAppRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraAppRegistrationSpec{
+					ForProvider: &iamv1alpha1.AppRegCredConfig{CredentialSecretRef: credentialSecretName},
+					Name:        "test-app",
+				},
 			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &iamv1alpha1.EntraAppRegistration{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance EntraAppRegistration")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
-		It("should successfully reconcile the resource", func() {
-			By("Reconciling the created resource")
-			controllerReconciler := &EntraAppRegistrationReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
-			}
 
+		It("should create and delete the application in Entra", func() {
+			By("Reconciling the created resource")
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			resource := &iamv1alpha1.EntraAppRegistration{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(resource.Status.AppRegistrationID).NotTo(BeEmpty())
+
+			app, ok := graphServer.Object("applications", resource.Status.AppRegistrationID)
+			Expect(ok).To(BeTrue())
+			Expect(app["displayName"]).To(Equal("test-app"))
+
+			By("Deleting the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok = graphServer.Object("applications", resource.Status.AppRegistrationID)
+			Expect(ok).To(BeFalse())
 		})
 	})
 })
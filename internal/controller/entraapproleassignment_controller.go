@@ -0,0 +1,340 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/approleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraAppRoleAssignmentReconciler reconciles a EntraAppRoleAssignment object
+type EntraAppRoleAssignmentReconciler struct {
+	client.Client
+	Scheme                   *runtime.Scheme
+	AppRoleAssignmentService approleassignments.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraapproleassignments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraapproleassignments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraapproleassignments/finalizers,verbs=update
+
+func (r *EntraAppRoleAssignmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraAppRoleAssignment.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraAppRoleAssignment --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	assignment := &entragov.EntraAppRoleAssignment{}
+	if err := r.Get(ctx, req.NamespacedName, assignment); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraAppRoleAssignment resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraAppRoleAssignment")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(assignment)
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		SetPausedCondition(&assignment.Status.Conditions, paused, assignment.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAppRoleAssignment paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraAppRoleAssignment reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, assignment, entraAppRoleAssignmentFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !assignment.DeletionTimestamp.IsZero() {
+		logger.Info("EntraAppRoleAssignment resource is being deleted. skipping reconciliation.")
+		return r.deleteAssignment(ctx, assignment)
+	}
+
+	// Pre-flight: make sure the credential may manage app role assignments before writing to Entra
+	missing, checkErr := r.AppRoleAssignmentService.CheckCredentials(ctx, *assignment)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		valid = SetCredentialsCondition(&assignment.Status.Conditions, missing, checkErr, assignment.Generation)
+		if !valid {
+			assignment.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAppRoleAssignment credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	principalID, resourceID, err := r.resolveRefs(ctx, assignment)
+	if err != nil {
+		logger.Error(err, "failed to resolve the principal and resource of EntraAppRoleAssignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if principalID == "" || resourceID == "" {
+		// the watches on the referenced resources enqueue the assignment once they are created
+		logger.Info("referenced principal or resource is not created in Entra yet. waiting.", "principalID", principalID, "resourceID", resourceID)
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	appRoleID, err := r.AppRoleAssignmentService.ResolveAppRole(ctx, *assignment, resourceID)
+	if err != nil {
+		logger.Error(err, "failed to resolve app role of EntraAppRoleAssignment", "appRole", assignment.Spec.AppRole)
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraAppRoleAssignment status after failed app role resolution")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if assignment.Status.ID != "" && (assignment.Status.PrincipalID != principalID || assignment.Status.ResourceID != resourceID || assignment.Status.AppRoleID != appRoleID) {
+		// app role assignments cannot be updated, they are replaced
+		return r.replaceAssignment(ctx, assignment)
+	}
+
+	if assignment.Status.ID == "" {
+		return r.createAssignment(ctx, assignment, principalID, resourceID, appRoleID)
+	}
+
+	return r.syncAssignment(ctx, assignment)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraAppRoleAssignmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraAppRoleAssignment{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entragov.EntraAppRoleAssignment{}, appRoleAssignmentRefField, func(obj client.Object) []string {
+		assignment := obj.(*entragov.EntraAppRoleAssignment)
+		var refs []string
+		if assignment.Spec.Principal.Ref != "" {
+			refs = append(refs, assignment.Spec.Principal.Type+"/"+assignment.Spec.Principal.Ref)
+		}
+		if assignment.Spec.Resource.ServicePrincipalRef != "" {
+			refs = append(refs, "ServicePrincipal/"+assignment.Spec.Resource.ServicePrincipalRef)
+		}
+		return refs
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraAppRoleAssignment{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraAppRoleAssignmentList{}), builder.OnlyMetadata).
+		Watches(&entragov.EntraUser{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("User"))).
+		Watches(&entragov.EntraSecurityGroup{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("Group"))).
+		Watches(&entragov.EntraServicePrincipal{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("ServicePrincipal"))).
+		Complete(r)
+}
+
+// assignmentsReferencing maps a referenced resource of the given principal type to the
+// assignments of its namespace referencing it, so that they are created once it exists in Entra.
+func (r *EntraAppRoleAssignmentReconciler) assignmentsReferencing(principalType string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		assignments := &entragov.EntraAppRoleAssignmentList{}
+		ref := principalType + "/" + obj.GetName()
+		if err := r.List(ctx, assignments, client.InNamespace(obj.GetNamespace()), client.MatchingFields{appRoleAssignmentRefField: ref}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list EntraAppRoleAssignments referencing resource", "ref", ref)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(assignments.Items))
+		for _, assignment := range assignments.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&assignment)})
+		}
+		return requests
+	}
+}
+
+// resolveRefs returns the object IDs of the principal and the resource service principal of
+// assignment. IDs of referenced resources that are missing or not created in Entra yet are empty.
+func (r *EntraAppRoleAssignmentReconciler) resolveRefs(ctx context.Context, assignment *entragov.EntraAppRoleAssignment) (string, string, error) {
+	principalID := assignment.Spec.Principal.Id
+	if ref := assignment.Spec.Principal.Ref; ref != "" {
+		id, err := referencedID(ctx, r.Client, assignment.Namespace, ref, principalObject(assignment.Spec.Principal.Type))
+		if err != nil {
+			return "", "", err
+		}
+		principalID = id
+	}
+
+	resourceID := assignment.Spec.Resource.Id
+	if ref := assignment.Spec.Resource.ServicePrincipalRef; ref != "" {
+		id, err := referencedID(ctx, r.Client, assignment.Namespace, ref, &entragov.EntraServicePrincipal{})
+		if err != nil {
+			return "", "", err
+		}
+		resourceID = id
+	}
+	return principalID, resourceID, nil
+}
+
+// createAssignment grants the app role assignment in Entra and records it in status.
+func (r *EntraAppRoleAssignmentReconciler) createAssignment(ctx context.Context, assignment *entragov.EntraAppRoleAssignment, principalID, resourceID, appRoleID string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	resp, adopted, err := r.AppRoleAssignmentService.Create(ctx, *assignment, resourceID, principalID, appRoleID)
+	if err != nil {
+		logger.Error(err, "failed to create Entra app role assignment", "principalID", principalID, "resourceID", resourceID, "appRoleID", appRoleID)
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraAppRoleAssignment status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ID = resp.ID
+		assignment.Status.PrincipalID = principalID
+		assignment.Status.ResourceID = resourceID
+		assignment.Status.AppRoleID = appRoleID
+		assignment.Status.Adopted = adopted
+		assignment.Status.ObservedGeneration = assignment.Generation
+		assignment.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAppRoleAssignment status with AssignmentID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra app role assignment", "AssignmentID", resp.ID, "adopted", adopted)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// replaceAssignment revokes the assignment recorded in status after the principal, resource or
+// app role of the spec changed. The new assignment is granted on the next reconciliation.
+func (r *EntraAppRoleAssignmentReconciler) replaceAssignment(ctx context.Context, assignment *entragov.EntraAppRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Entra app role assignment changed. replacing assignment.", "AssignmentID", assignment.Status.ID)
+
+	if err := r.revokeAssignment(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ID = ""
+		assignment.Status.Adopted = false
+		assignment.Status.Phase = "Pending"
+	}); err != nil {
+		logger.Error(err, "failed to clear EntraAppRoleAssignment status after revoking assignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncAssignment checks that the assignment still exists in Entra.
+func (r *EntraAppRoleAssignmentReconciler) syncAssignment(ctx context.Context, assignment *entragov.EntraAppRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	_, statusCode, err := r.AppRoleAssignmentService.Get(ctx, *assignment, assignment.Status.ResourceID, assignment.Status.ID)
+	if err != nil {
+		// only an assignment confirmed missing is forgotten, it is granted again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra app role assignment by ID from status", "AssignmentID", assignment.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra app role assignment from status no longer exists in Entra", "AssignmentID", assignment.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraAppRoleAssignment", "AssignmentMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.ID = ""
+			assignment.Status.Adopted = false
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraAppRoleAssignment status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ObservedGeneration = assignment.Generation
+		assignment.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraAppRoleAssignment status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// revokeAssignment deletes the assignment recorded in status in Entra. Adopted assignments and
+// assignments that no longer exist are left alone.
+func (r *EntraAppRoleAssignmentReconciler) revokeAssignment(ctx context.Context, assignment *entragov.EntraAppRoleAssignment) error {
+	logger := log.FromContext(ctx)
+
+	if assignment.Status.ID == "" {
+		return nil
+	}
+	if assignment.Status.Adopted {
+		logger.Info("Entra app role assignment was adopted. leaving it in Entra.", "AssignmentID", assignment.Status.ID)
+		return nil
+	}
+
+	_, statusCode, err := r.AppRoleAssignmentService.Get(ctx, *assignment, assignment.Status.ResourceID, assignment.Status.ID)
+	switch {
+	case err != nil && statusCode == "404":
+		logger.Info("Entra app role assignment not found in Entra.", "AssignmentID", assignment.Status.ID)
+		return nil
+	case err != nil:
+		logger.Error(err, "failed to get Entra app role assignment in Entra")
+		return err
+	}
+
+	if err := r.AppRoleAssignmentService.Delete(ctx, *assignment, assignment.Status.ResourceID, assignment.Status.ID); err != nil {
+		logger.Error(err, "failed to delete Entra app role assignment in Entra")
+		return err
+	}
+	return nil
+}
+
+// deleteAssignment revokes the assignment in Entra and removes the finalizer.
+func (r *EntraAppRoleAssignmentReconciler) deleteAssignment(ctx context.Context, assignment *entragov.EntraAppRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.revokeAssignment(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, assignment, entraAppRoleAssignmentFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraAppRoleAssignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraAppRoleAssignment. deletion complete.")
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/approleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/services/serviceprincipals"
+)
+
+var _ = Describe("EntraAppRoleAssignment Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraAppRoleAssignmentReconciler
+		var resources *testResources
+
+		createAssignment := func(name string, spec iamv1alpha1.EntraAppRoleAssignmentSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraAppRoleAssignment{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileAssignment := func(key types.NamespacedName) *iamv1alpha1.EntraAppRoleAssignment {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraAppRoleAssignment{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraAppRoleAssignmentReconciler{
+				Client:                   k8sClient,
+				Scheme:                   k8sClient.Scheme(),
+				AppRoleAssignmentService: approleassignments.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should assign the app role by value and replace the assignment when it changes", func() {
+			resourceID := graphServer.AddServicePrincipal("Reporting API")
+			readerID := graphServer.AddAppRole(resourceID, "Reader")
+			writerID := graphServer.AddAppRole(resourceID, "Writer")
+			userID := graphServer.AddUser("Report reader")
+
+			key := createAssignment("report-reader", iamv1alpha1.EntraAppRoleAssignmentSpec{
+				Principal: iamv1alpha1.AppRoleAssignmentPrincipal{Type: "User", Id: userID},
+				Resource:  iamv1alpha1.AppRoleAssignmentResource{Id: resourceID},
+				AppRole:   "Reader",
+			})
+
+			resource := reconcileAssignment(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.AppRoleID).To(Equal(readerID))
+			assignments := graphServer.AppRoleAssignments(resourceID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["principalId"]).To(Equal(userID))
+			Expect(assignments[0]["appRoleId"]).To(Equal(readerID))
+
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("replacing the assignment with the new app role")
+			resource.Spec.AppRole = "Writer"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			Expect(graphServer.AppRoleAssignments(resourceID)).To(BeEmpty())
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.AppRoleID).To(Equal(writerID))
+			assignments = graphServer.AppRoleAssignments(resourceID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["appRoleId"]).To(Equal(writerID))
+
+			By("revoking the assignment with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graphServer.AppRoleAssignments(resourceID)).To(BeEmpty())
+		})
+
+		It("should fail for an app role the resource does not define", func() {
+			resourceID := graphServer.AddServicePrincipal("Roleless API")
+			userID := graphServer.AddUser("Roleless user")
+
+			key := createAssignment("unknown-role", iamv1alpha1.EntraAppRoleAssignmentSpec{
+				Principal: iamv1alpha1.AppRoleAssignmentPrincipal{Type: "User", Id: userID},
+				Resource:  iamv1alpha1.AppRoleAssignmentResource{Id: resourceID},
+				AppRole:   "Admin",
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraAppRoleAssignment{}
+			Expect(k8sClient.Get(ctx, key, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(graphServer.AppRoleAssignments(resourceID)).To(BeEmpty())
+		})
+
+		It("should wait for a referenced service principal to be created", func() {
+			appID := graphServer.AddExternalApplication("Referenced app")
+			spKey := types.NamespacedName{Name: "referenced-app", Namespace: "default"}
+			sp := &iamv1alpha1.EntraServicePrincipal{
+				ObjectMeta: metav1.ObjectMeta{Name: spKey.Name, Namespace: spKey.Namespace},
+				Spec: iamv1alpha1.EntraServicePrincipalSpec{
+					ForProvider: testProvider(),
+					AppID:       appID,
+				},
+			}
+			Expect(k8sClient.Create(ctx, sp)).To(Succeed())
+			spReconciler := &EntraServicePrincipalReconciler{
+				Client:                  k8sClient,
+				Scheme:                  k8sClient.Scheme(),
+				ServicePrincipalService: serviceprincipals.NewService(clientFactory),
+			}
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, sp)).To(Succeed())
+				_, err := spReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: spKey})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			userID := graphServer.AddUser("Default access user")
+
+			key := createAssignment("default-access", iamv1alpha1.EntraAppRoleAssignmentSpec{
+				Principal: iamv1alpha1.AppRoleAssignmentPrincipal{Type: "User", Id: userID},
+				Resource:  iamv1alpha1.AppRoleAssignmentResource{ServicePrincipalRef: spKey.Name},
+			})
+
+			resource := reconcileAssignment(key)
+			Expect(resource.Status.Phase).To(Equal("Pending"))
+			Expect(resource.Status.ID).To(BeEmpty())
+
+			By("assigning the default access role once the service principal exists")
+			sp = reconcileResource(ctx, spReconciler, spKey, sp)
+
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.ResourceID).To(Equal(sp.Status.ID))
+			Expect(resource.Status.AppRoleID).To(Equal("00000000-0000-0000-0000-000000000000"))
+			Expect(graphServer.AppRoleAssignments(sp.Status.ID)).To(HaveLen(1))
+		})
+	})
+})
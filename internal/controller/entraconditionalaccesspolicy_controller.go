@@ -0,0 +1,438 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/conditionalaccess"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/conditionalaccesspolicies"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraConditionalAccessPolicyReconciler reconciles a EntraConditionalAccessPolicy object
+type EntraConditionalAccessPolicyReconciler struct {
+	client.Client
+	Scheme                   *runtime.Scheme
+	ConditionalAccessService conditionalaccesspolicies.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraconditionalaccesspolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraconditionalaccesspolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraconditionalaccesspolicies/finalizers,verbs=update
+
+func (r *EntraConditionalAccessPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraConditionalAccessPolicy.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraConditionalAccessPolicy --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	entraPolicy := &entragov.EntraConditionalAccessPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, entraPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraConditionalAccessPolicy resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraConditionalAccessPolicy")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraPolicy)
+	if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+		SetPausedCondition(&entraPolicy.Status.Conditions, paused, entraPolicy.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraConditionalAccessPolicy paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraConditionalAccessPolicy reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, entraPolicy, entraConditionalAccessPolicyFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !entraPolicy.DeletionTimestamp.IsZero() {
+		logger.Info("EntraConditionalAccessPolicy resource is being deleted. skipping reconciliation.")
+		return r.deletePolicy(ctx, entraPolicy)
+	}
+
+	// Pre-flight: make sure the credential may manage conditional access before writing to Entra
+	missing, checkErr := r.ConditionalAccessService.CheckCredentials(ctx, *entraPolicy)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+		valid = SetCredentialsCondition(&entraPolicy.Status.Conditions, missing, checkErr, entraPolicy.Generation)
+		if !valid {
+			entraPolicy.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraConditionalAccessPolicy credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	policy, unresolved, err := r.desiredPolicy(ctx, entraPolicy)
+	if err != nil {
+		logger.Error(err, "failed to resolve the resources referenced by EntraConditionalAccessPolicy")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if len(unresolved) > 0 {
+		// a policy missing one of its users, groups or locations could apply to more or fewer
+		// sign-ins than intended, it is left unchanged until the watches on the references
+		// enqueue it again
+		logger.Info("referenced resources are not created in Entra yet. waiting.", "refs", unresolved)
+		if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+			entraPolicy.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if entraPolicy.Status.ID == "" {
+		return r.createPolicy(ctx, entraPolicy, policy)
+	}
+
+	return r.syncPolicy(ctx, entraPolicy, policy)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraConditionalAccessPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraConditionalAccessPolicy{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entragov.EntraConditionalAccessPolicy{}, conditionalAccessPolicyRefField, func(obj client.Object) []string {
+		conditions := obj.(*entragov.EntraConditionalAccessPolicy).Spec.Conditions
+		users := conditions.Users
+		var refs []string
+		for _, name := range slices.Concat(users.IncludeUserRefs, users.ExcludeUserRefs) {
+			refs = append(refs, "User/"+name)
+		}
+		for _, name := range slices.Concat(users.IncludeGroupRefs, users.ExcludeGroupRefs) {
+			refs = append(refs, "Group/"+name)
+		}
+		if conditions.Locations != nil {
+			for _, name := range slices.Concat(conditions.Locations.IncludeLocationRefs, conditions.Locations.ExcludeLocationRefs) {
+				refs = append(refs, "NamedLocation/"+name)
+			}
+		}
+		return refs
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraConditionalAccessPolicy{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraConditionalAccessPolicyList{}), builder.OnlyMetadata).
+		Watches(&entragov.EntraUser{}, handler.EnqueueRequestsFromMapFunc(r.policiesReferencing("User"))).
+		Watches(&entragov.EntraSecurityGroup{}, handler.EnqueueRequestsFromMapFunc(r.policiesReferencing("Group"))).
+		Watches(&entragov.EntraNamedLocation{}, handler.EnqueueRequestsFromMapFunc(r.policiesReferencing("NamedLocation"))).
+		Complete(r)
+}
+
+// policiesReferencing maps a referenced resource of the given type to the policies of its
+// namespace referencing it, so that they follow the object ID of the resource in Entra.
+func (r *EntraConditionalAccessPolicyReconciler) policiesReferencing(refType string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policies := &entragov.EntraConditionalAccessPolicyList{}
+		ref := refType + "/" + obj.GetName()
+		if err := r.List(ctx, policies, client.InNamespace(obj.GetNamespace()), client.MatchingFields{conditionalAccessPolicyRefField: ref}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list EntraConditionalAccessPolicies referencing resource", "ref", ref)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(policies.Items))
+		for _, policy := range policies.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+		}
+		return requests
+	}
+}
+
+// desiredPolicy returns the policy described by the spec with the IDs of the referenced
+// EntraUsers, EntraSecurityGroups and EntraNamedLocations, and the <type>/<name> of the
+// references that are not created in Entra yet.
+func (r *EntraConditionalAccessPolicyReconciler) desiredPolicy(ctx context.Context, entraPolicy *entragov.EntraConditionalAccessPolicy) (conditionalaccess.Policy, []string, error) {
+	spec := entraPolicy.Spec
+	conditions := spec.Conditions
+	var unresolved []string
+	resolve := func(ids []string, refType string, names []string) ([]string, error) {
+		resolved := slices.Clone(ids)
+		for _, name := range names {
+			id, err := referencedID(ctx, r.Client, entraPolicy.Namespace, name, policyRefObject(refType))
+			if err != nil {
+				return nil, err
+			}
+			if id == "" {
+				unresolved = append(unresolved, refType+"/"+name)
+				continue
+			}
+			resolved = append(resolved, id)
+		}
+		return resolved, nil
+	}
+
+	policy := conditionalaccess.Policy{
+		DisplayName:         spec.DisplayName,
+		State:               spec.State,
+		IncludeRoles:        conditions.Users.IncludeRoles,
+		ExcludeRoles:        conditions.Users.ExcludeRoles,
+		IncludeApplications: conditions.Applications.IncludeApplications,
+		ExcludeApplications: conditions.Applications.ExcludeApplications,
+		IncludeUserActions:  conditions.Applications.IncludeUserActions,
+		ClientAppTypes:      enumValues(conditions.ClientAppTypes),
+		SignInRiskLevels:    enumValues(conditions.SignInRiskLevels),
+		UserRiskLevels:      enumValues(conditions.UserRiskLevels),
+	}
+	if policy.State == "" {
+		policy.State = conditionalaccess.StateReportOnly
+	}
+	if len(policy.ClientAppTypes) == 0 {
+		policy.ClientAppTypes = []string{"all"}
+	}
+
+	var err error
+	if policy.IncludeUsers, err = resolve(conditions.Users.IncludeUsers, "User", conditions.Users.IncludeUserRefs); err != nil {
+		return policy, nil, err
+	}
+	if policy.ExcludeUsers, err = resolve(conditions.Users.ExcludeUsers, "User", conditions.Users.ExcludeUserRefs); err != nil {
+		return policy, nil, err
+	}
+	if policy.IncludeGroups, err = resolve(conditions.Users.IncludeGroups, "Group", conditions.Users.IncludeGroupRefs); err != nil {
+		return policy, nil, err
+	}
+	if policy.ExcludeGroups, err = resolve(conditions.Users.ExcludeGroups, "Group", conditions.Users.ExcludeGroupRefs); err != nil {
+		return policy, nil, err
+	}
+
+	if conditions.Platforms != nil {
+		policy.IncludePlatforms = enumValues(conditions.Platforms.IncludePlatforms)
+		policy.ExcludePlatforms = enumValues(conditions.Platforms.ExcludePlatforms)
+	}
+	if conditions.Locations != nil {
+		if policy.IncludeLocations, err = resolve(conditions.Locations.IncludeLocations, "NamedLocation", conditions.Locations.IncludeLocationRefs); err != nil {
+			return policy, nil, err
+		}
+		if policy.ExcludeLocations, err = resolve(conditions.Locations.ExcludeLocations, "NamedLocation", conditions.Locations.ExcludeLocationRefs); err != nil {
+			return policy, nil, err
+		}
+	}
+
+	if spec.GrantControls != nil {
+		policy.GrantOperator = spec.GrantControls.Operator
+		if policy.GrantOperator == "" {
+			policy.GrantOperator = "OR"
+		}
+		policy.BuiltInControls = enumValues(spec.GrantControls.BuiltInControls)
+	}
+	if session := spec.SessionControls; session != nil {
+		if session.SignInFrequency != nil {
+			policy.SignInFrequency = &conditionalaccess.SignInFrequency{Value: session.SignInFrequency.Value, Type: session.SignInFrequency.Type}
+		}
+		policy.PersistentBrowser = session.PersistentBrowser
+		policy.ApplicationEnforcedRestrictions = session.ApplicationEnforcedRestrictions
+		policy.DisableResilienceDefaults = session.DisableResilienceDefaults
+	}
+	return policy, unresolved, nil
+}
+
+// createPolicy creates the conditional access policy in Entra and records it in status.
+func (r *EntraConditionalAccessPolicyReconciler) createPolicy(ctx context.Context, entraPolicy *entragov.EntraConditionalAccessPolicy, policy conditionalaccess.Policy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	created, err := r.ConditionalAccessService.Create(ctx, *entraPolicy, policy)
+	if err != nil {
+		logger.Error(err, "failed to create Entra conditional access policy", "displayName", entraPolicy.Spec.DisplayName)
+		if patchErr := PatchStatus(ctx, r.Client, entraPolicy, func() {
+			entraPolicy.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraConditionalAccessPolicy status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+		entraPolicy.Status.ID = created.ID
+		entraPolicy.Status.DisplayName = created.DisplayName
+		entraPolicy.Status.State = created.State
+		entraPolicy.Status.ObservedGeneration = entraPolicy.Generation
+		entraPolicy.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraConditionalAccessPolicy status with PolicyID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra conditional access policy", "PolicyID", created.ID, "displayName", created.DisplayName, "state", created.State)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncPolicy applies the desired policy to the conditional access policy in Entra and records
+// its state in status.
+func (r *EntraConditionalAccessPolicyReconciler) syncPolicy(ctx context.Context, entraPolicy *entragov.EntraConditionalAccessPolicy, policy conditionalaccess.Policy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	existing, statusCode, err := r.ConditionalAccessService.Get(ctx, *entraPolicy, entraPolicy.Status.ID)
+	if err != nil {
+		// only a policy confirmed missing is forgotten, it is created again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra conditional access policy by ID from status", "PolicyID", entraPolicy.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra conditional access policy from status no longer exists in Entra", "PolicyID", entraPolicy.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraConditionalAccessPolicy", "PolicyMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+			entraPolicy.Status.ID = ""
+			entraPolicy.Status.DisplayName = ""
+			entraPolicy.Status.State = ""
+			entraPolicy.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraConditionalAccessPolicy status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if !policyInSync(existing, policy) {
+		logger.Info("Entra conditional access policy is not in sync. updating policy.", "PolicyID", existing.ID, "state", policy.State)
+		if err := r.ConditionalAccessService.Update(ctx, *entraPolicy, existing.ID, policy); err != nil {
+			logger.Error(err, "failed to update Entra conditional access policy", "PolicyID", existing.ID)
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		existing.DisplayName = policy.DisplayName
+		existing.State = policy.State
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraPolicy, func() {
+		entraPolicy.Status.DisplayName = existing.DisplayName
+		entraPolicy.Status.State = existing.State
+		entraPolicy.Status.ObservedGeneration = entraPolicy.Generation
+		entraPolicy.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraConditionalAccessPolicy status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// deletePolicy deletes the conditional access policy in Entra and removes the finalizer.
+func (r *EntraConditionalAccessPolicyReconciler) deletePolicy(ctx context.Context, entraPolicy *entragov.EntraConditionalAccessPolicy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraPolicy.Status.ID != "" {
+		_, statusCode, err := r.ConditionalAccessService.Get(ctx, *entraPolicy, entraPolicy.Status.ID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("Entra conditional access policy not found in Entra. Removing finalizer.")
+		case err != nil:
+			logger.Error(err, "failed to get Entra conditional access policy in Entra during deletion")
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		default:
+			if err := r.ConditionalAccessService.Delete(ctx, *entraPolicy, entraPolicy.Status.ID); err != nil {
+				logger.Error(err, "failed to delete Entra conditional access policy in Entra")
+				return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+			}
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, entraPolicy, entraConditionalAccessPolicyFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraConditionalAccessPolicy")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraConditionalAccessPolicy. deletion complete.")
+	return ctrl.Result{}, nil
+}
+
+// policyRefObject returns an empty resource of the kind referenced by policies with the given
+// type: EntraUser for User, EntraSecurityGroup for Group and EntraNamedLocation for NamedLocation.
+func policyRefObject(refType string) client.Object {
+	if refType == "NamedLocation" {
+		return &entragov.EntraNamedLocation{}
+	}
+	return principalObject(refType)
+}
+
+// policyInSync reports whether the policy in Entra matches the desired policy. Lists are
+// compared regardless of their order.
+func policyInSync(existing *conditionalaccess.Policy, desired conditionalaccess.Policy) bool {
+	if existing.DisplayName != desired.DisplayName || existing.State != desired.State ||
+		existing.GrantOperator != desired.GrantOperator || existing.PersistentBrowser != desired.PersistentBrowser ||
+		existing.ApplicationEnforcedRestrictions != desired.ApplicationEnforcedRestrictions ||
+		existing.DisableResilienceDefaults != desired.DisableResilienceDefaults {
+		return false
+	}
+	if (existing.SignInFrequency == nil) != (desired.SignInFrequency == nil) ||
+		(existing.SignInFrequency != nil && *existing.SignInFrequency != *desired.SignInFrequency) {
+		return false
+	}
+
+	lists := [][2][]string{
+		{existing.IncludeUsers, desired.IncludeUsers},
+		{existing.ExcludeUsers, desired.ExcludeUsers},
+		{existing.IncludeGroups, desired.IncludeGroups},
+		{existing.ExcludeGroups, desired.ExcludeGroups},
+		{existing.IncludeRoles, desired.IncludeRoles},
+		{existing.ExcludeRoles, desired.ExcludeRoles},
+		{existing.IncludeApplications, desired.IncludeApplications},
+		{existing.ExcludeApplications, desired.ExcludeApplications},
+		{existing.IncludeUserActions, desired.IncludeUserActions},
+		{existing.ClientAppTypes, desired.ClientAppTypes},
+		{existing.IncludePlatforms, desired.IncludePlatforms},
+		{existing.ExcludePlatforms, desired.ExcludePlatforms},
+		{existing.IncludeLocations, desired.IncludeLocations},
+		{existing.ExcludeLocations, desired.ExcludeLocations},
+		{existing.SignInRiskLevels, desired.SignInRiskLevels},
+		{existing.UserRiskLevels, desired.UserRiskLevels},
+		{existing.BuiltInControls, desired.BuiltInControls},
+	}
+	for _, list := range lists {
+		if !sameValues(list[0], list[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameValues reports whether two lists hold the same values.
+func sameValues(a, b []string) bool {
+	left, right := slices.Clone(a), slices.Clone(b)
+	slices.Sort(left)
+	slices.Sort(right)
+	return slices.Equal(slices.Compact(left), slices.Compact(right))
+}
+
+// enumValues returns the values of a list of enumerated spec values, nil for an empty list.
+func enumValues[T ~string](values []T) []string {
+	var result []string
+	for _, value := range values {
+		result = append(result, string(value))
+	}
+	return result
+}
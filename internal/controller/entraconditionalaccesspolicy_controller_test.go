@@ -0,0 +1,158 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/conditionalaccesspolicies"
+)
+
+var _ = Describe("EntraConditionalAccessPolicy Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraConditionalAccessPolicyReconciler
+		var resources *testResources
+
+		createPolicy := func(name string, spec iamv1alpha1.EntraConditionalAccessPolicySpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraConditionalAccessPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcilePolicy := func(key types.NamespacedName) *iamv1alpha1.EntraConditionalAccessPolicy {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraConditionalAccessPolicy{})
+		}
+
+		policyUsers := func(policyID string) map[string]any {
+			policy, ok := graphServer.Object("conditionalAccessPolicies", policyID)
+			Expect(ok).To(BeTrue())
+			conditions, _ := policy["conditions"].(map[string]any)
+			users, _ := conditions["users"].(map[string]any)
+			return users
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraConditionalAccessPolicyReconciler{
+				Client:                   k8sClient,
+				Scheme:                   k8sClient.Scheme(),
+				ConditionalAccessService: conditionalaccesspolicies.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should create the policy report-only and enable it when asked to", func() {
+			userID := graphServer.AddUser("Break glass")
+			key := createPolicy("require-mfa", iamv1alpha1.EntraConditionalAccessPolicySpec{
+				DisplayName: "Require MFA",
+				Conditions: iamv1alpha1.ConditionalAccessConditions{
+					Users:        iamv1alpha1.ConditionalAccessUsers{IncludeUsers: []string{"All"}, ExcludeUsers: []string{userID}},
+					Applications: iamv1alpha1.ConditionalAccessApplications{IncludeApplications: []string{"All"}},
+				},
+				GrantControls: &iamv1alpha1.ConditionalAccessGrantControls{
+					BuiltInControls: []iamv1alpha1.ConditionalAccessGrantControl{"mfa"},
+				},
+			})
+
+			resource := reconcilePolicy(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.State).To(Equal("enabledForReportingButNotEnforced"))
+			policy, ok := graphServer.Object("conditionalAccessPolicies", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(policy).To(HaveKeyWithValue("state", "enabledForReportingButNotEnforced"))
+			Expect(policyUsers(resource.Status.ID)).To(HaveKeyWithValue("excludeUsers", ConsistOf(userID)))
+
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("enabling the policy")
+			resource.Spec.State = "enabled"
+			resource.Spec.SessionControls = &iamv1alpha1.ConditionalAccessSessionControls{
+				SignInFrequency: &iamv1alpha1.ConditionalAccessSignInFrequency{Value: 12, Type: "hours"},
+			}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.State).To(Equal("enabled"))
+			policy, _ = graphServer.Object("conditionalAccessPolicies", resource.Status.ID)
+			Expect(policy).To(HaveKeyWithValue("state", "enabled"))
+			Expect(policy).To(HaveKeyWithValue("sessionControls", HaveKeyWithValue("signInFrequency", HaveKeyWithValue("value", BeNumerically("==", 12)))))
+
+			By("creating the policy again when it was deleted outside of the controller")
+			graphServer.DeleteObject("conditionalAccessPolicies", resource.Status.ID)
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+
+			By("deleting the policy with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("conditionalAccessPolicies", resource.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should follow the object ID of the referenced groups", func() {
+			key := createPolicy("block-contractors", iamv1alpha1.EntraConditionalAccessPolicySpec{
+				DisplayName: "Block contractors",
+				Conditions: iamv1alpha1.ConditionalAccessConditions{
+					Users:        iamv1alpha1.ConditionalAccessUsers{IncludeGroupRefs: []string{"contractors"}},
+					Applications: iamv1alpha1.ConditionalAccessApplications{IncludeApplications: []string{"All"}},
+				},
+				GrantControls: &iamv1alpha1.ConditionalAccessGrantControls{
+					BuiltInControls: []iamv1alpha1.ConditionalAccessGrantControl{"block"},
+				},
+			})
+
+			By("waiting for the referenced group")
+			resource := reconcilePolicy(key)
+			Expect(resource.Status.Phase).To(Equal("Pending"))
+			Expect(resource.Status.ID).To(BeEmpty())
+
+			groupKey := createTestGroup(ctx, "contractors", iamv1alpha1.EntraSecurityGroupSpec{})
+			groupID := reconcileTestGroup(ctx, groupKey).Status.ID
+			Expect(groupID).NotTo(BeEmpty())
+
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(policyUsers(resource.Status.ID)).To(HaveKeyWithValue("includeGroups", ConsistOf(groupID)))
+
+			By("updating the policy when the group is created again in Entra")
+			graphServer.DeleteObject("groups", groupID)
+			_, err := newGroupReconciler().Reconcile(ctx, reconcile.Request{NamespacedName: groupKey})
+			Expect(err).NotTo(HaveOccurred())
+			recreatedID := reconcileTestGroup(ctx, groupKey).Status.ID
+			Expect(recreatedID).NotTo(BeEmpty())
+			Expect(recreatedID).NotTo(Equal(groupID))
+
+			resource = reconcilePolicy(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(policyUsers(resource.Status.ID)).To(HaveKeyWithValue("includeGroups", ConsistOf(recreatedID)))
+		})
+	})
+})
@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/directoryroleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraDirectoryRoleAssignmentReconciler reconciles a EntraDirectoryRoleAssignment object
+type EntraDirectoryRoleAssignmentReconciler struct {
+	client.Client
+	Scheme                *runtime.Scheme
+	RoleAssignmentService directoryroleassignments.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entradirectoryroleassignments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entradirectoryroleassignments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entradirectoryroleassignments/finalizers,verbs=update
+
+func (r *EntraDirectoryRoleAssignmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraDirectoryRoleAssignment.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraDirectoryRoleAssignment --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	assignment := &entragov.EntraDirectoryRoleAssignment{}
+	if err := r.Get(ctx, req.NamespacedName, assignment); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraDirectoryRoleAssignment resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraDirectoryRoleAssignment")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(assignment)
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		SetPausedCondition(&assignment.Status.Conditions, paused, assignment.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraDirectoryRoleAssignment paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraDirectoryRoleAssignment reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, assignment, entraDirectoryRoleAssignmentFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !assignment.DeletionTimestamp.IsZero() {
+		logger.Info("EntraDirectoryRoleAssignment resource is being deleted. skipping reconciliation.")
+		return r.deleteAssignment(ctx, assignment)
+	}
+
+	// Pre-flight: make sure the credential may manage directory roles before writing to Entra
+	missing, checkErr := r.RoleAssignmentService.CheckCredentials(ctx, *assignment)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		valid = SetCredentialsCondition(&assignment.Status.Conditions, missing, checkErr, assignment.Generation)
+		if !valid {
+			assignment.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraDirectoryRoleAssignment credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	principalID, err := directoryRolePrincipalID(ctx, r.Client, assignment.Namespace, assignment.Spec.Principal, true)
+	if err != nil {
+		logger.Error(err, "failed to resolve the principal of EntraDirectoryRoleAssignment")
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraDirectoryRoleAssignment status after failed principal resolution")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if principalID == "" {
+		// the watches on the referenced resources enqueue the assignment once they are created
+		logger.Info("referenced principal is not created in Entra yet. waiting.", "ref", assignment.Spec.Principal.Ref)
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	role, err := r.RoleAssignmentService.ResolveRole(ctx, *assignment)
+	if err != nil {
+		logger.Error(err, "failed to resolve directory role of EntraDirectoryRoleAssignment", "role", assignment.Spec.Role)
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraDirectoryRoleAssignment status after failed role resolution")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	directoryScopeID := directoryroleassignments.DirectoryScopeID(assignment.Spec.AdministrativeUnitID)
+
+	if assignment.Status.ID != "" && (assignment.Status.PrincipalID != principalID || assignment.Status.RoleDefinitionID != role.ID || assignment.Status.DirectoryScopeID != directoryScopeID) {
+		// role assignments cannot be updated, they are replaced
+		return r.replaceAssignment(ctx, assignment)
+	}
+
+	if assignment.Status.ID == "" {
+		return r.createAssignment(ctx, assignment, principalID, role.ID, role.DisplayName, directoryScopeID)
+	}
+
+	return r.syncAssignment(ctx, assignment)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraDirectoryRoleAssignmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraDirectoryRoleAssignment{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entragov.EntraDirectoryRoleAssignment{}, directoryRoleAssignmentRefField, func(obj client.Object) []string {
+		principal := obj.(*entragov.EntraDirectoryRoleAssignment).Spec.Principal
+		if principal.Ref == "" {
+			return nil
+		}
+		return []string{principal.Type + "/" + principal.Ref}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraDirectoryRoleAssignment{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraDirectoryRoleAssignmentList{}), builder.OnlyMetadata).
+		Watches(&entragov.EntraUser{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("User"))).
+		Watches(&entragov.EntraSecurityGroup{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("Group"))).
+		Watches(&entragov.EntraServicePrincipal{}, handler.EnqueueRequestsFromMapFunc(r.assignmentsReferencing("ServicePrincipal"))).
+		Complete(r)
+}
+
+// assignmentsReferencing maps a referenced principal of the given type to the assignments of its
+// namespace referencing it, so that they are created once it exists in Entra.
+func (r *EntraDirectoryRoleAssignmentReconciler) assignmentsReferencing(principalType string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		assignments := &entragov.EntraDirectoryRoleAssignmentList{}
+		ref := principalType + "/" + obj.GetName()
+		if err := r.List(ctx, assignments, client.InNamespace(obj.GetNamespace()), client.MatchingFields{directoryRoleAssignmentRefField: ref}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list EntraDirectoryRoleAssignments referencing resource", "ref", ref)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(assignments.Items))
+		for _, assignment := range assignments.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&assignment)})
+		}
+		return requests
+	}
+}
+
+// createAssignment assigns the directory role in Entra and records it in status.
+func (r *EntraDirectoryRoleAssignmentReconciler) createAssignment(ctx context.Context, assignment *entragov.EntraDirectoryRoleAssignment, principalID, roleDefinitionID, roleDisplayName, directoryScopeID string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	resp, adopted, err := r.RoleAssignmentService.Create(ctx, *assignment, principalID, roleDefinitionID, directoryScopeID)
+	if err != nil {
+		logger.Error(err, "failed to create Entra directory role assignment", "principalID", principalID, "roleDefinitionID", roleDefinitionID, "directoryScopeID", directoryScopeID)
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraDirectoryRoleAssignment status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ID = resp.ID
+		assignment.Status.PrincipalID = principalID
+		assignment.Status.RoleDefinitionID = roleDefinitionID
+		assignment.Status.RoleDisplayName = roleDisplayName
+		assignment.Status.DirectoryScopeID = directoryScopeID
+		assignment.Status.Adopted = adopted
+		assignment.Status.ObservedGeneration = assignment.Generation
+		assignment.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraDirectoryRoleAssignment status with AssignmentID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra directory role assignment", "AssignmentID", resp.ID, "adopted", adopted)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// replaceAssignment revokes the assignment recorded in status after the principal, role or scope
+// of the spec changed. The new assignment is created on the next reconciliation.
+func (r *EntraDirectoryRoleAssignmentReconciler) replaceAssignment(ctx context.Context, assignment *entragov.EntraDirectoryRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Entra directory role assignment changed. replacing assignment.", "AssignmentID", assignment.Status.ID)
+
+	if err := r.revokeAssignment(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ID = ""
+		assignment.Status.Adopted = false
+		assignment.Status.Phase = "Pending"
+	}); err != nil {
+		logger.Error(err, "failed to clear EntraDirectoryRoleAssignment status after revoking assignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncAssignment checks that the assignment still exists in Entra.
+func (r *EntraDirectoryRoleAssignmentReconciler) syncAssignment(ctx context.Context, assignment *entragov.EntraDirectoryRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	_, statusCode, err := r.RoleAssignmentService.Get(ctx, *assignment, assignment.Status.ID)
+	if err != nil {
+		// only an assignment confirmed missing is forgotten, it is assigned again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra directory role assignment by ID from status", "AssignmentID", assignment.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra directory role assignment from status no longer exists in Entra", "AssignmentID", assignment.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraDirectoryRoleAssignment", "AssignmentMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.ID = ""
+			assignment.Status.Adopted = false
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraDirectoryRoleAssignment status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		assignment.Status.ObservedGeneration = assignment.Generation
+		assignment.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraDirectoryRoleAssignment status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// revokeAssignment deletes the assignment recorded in status in Entra. Adopted assignments and
+// assignments that no longer exist are left alone.
+func (r *EntraDirectoryRoleAssignmentReconciler) revokeAssignment(ctx context.Context, assignment *entragov.EntraDirectoryRoleAssignment) error {
+	logger := log.FromContext(ctx)
+
+	if assignment.Status.ID == "" {
+		return nil
+	}
+	if assignment.Status.Adopted {
+		logger.Info("Entra directory role assignment was adopted. leaving it in Entra.", "AssignmentID", assignment.Status.ID)
+		return nil
+	}
+
+	_, statusCode, err := r.RoleAssignmentService.Get(ctx, *assignment, assignment.Status.ID)
+	switch {
+	case err != nil && statusCode == "404":
+		logger.Info("Entra directory role assignment not found in Entra.", "AssignmentID", assignment.Status.ID)
+		return nil
+	case err != nil:
+		logger.Error(err, "failed to get Entra directory role assignment in Entra")
+		return err
+	}
+
+	if err := r.RoleAssignmentService.Delete(ctx, *assignment, assignment.Status.ID); err != nil {
+		logger.Error(err, "failed to delete Entra directory role assignment in Entra")
+		return err
+	}
+	return nil
+}
+
+// deleteAssignment revokes the assignment in Entra and removes the finalizer.
+func (r *EntraDirectoryRoleAssignmentReconciler) deleteAssignment(ctx context.Context, assignment *entragov.EntraDirectoryRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.revokeAssignment(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, assignment, entraDirectoryRoleAssignmentFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraDirectoryRoleAssignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraDirectoryRoleAssignment. deletion complete.")
+	return ctrl.Result{}, nil
+}
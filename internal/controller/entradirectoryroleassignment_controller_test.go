@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/directoryroleassignments"
+)
+
+var _ = Describe("EntraDirectoryRoleAssignment Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		const (
+			userAdministratorTemplateID   = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+			groupsAdministratorTemplateID = "fdd7a751-b60b-444a-984c-02652fe8fa1c"
+		)
+
+		var controllerReconciler *EntraDirectoryRoleAssignmentReconciler
+		var resources *testResources
+
+		createAssignment := func(name string, spec iamv1alpha1.EntraDirectoryRoleAssignmentSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraDirectoryRoleAssignment{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileAssignment := func(key types.NamespacedName) *iamv1alpha1.EntraDirectoryRoleAssignment {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraDirectoryRoleAssignment{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraDirectoryRoleAssignmentReconciler{
+				Client:                k8sClient,
+				Scheme:                k8sClient.Scheme(),
+				RoleAssignmentService: directoryroleassignments.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should assign the role by template ID and replace the assignment when it changes", func() {
+			userID := graphServer.AddUser("Helpdesk operator")
+
+			key := createAssignment("helpdesk-user-admin", iamv1alpha1.EntraDirectoryRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "User", Id: userID},
+				Role:      iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+			})
+
+			resource := reconcileAssignment(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.RoleDisplayName).To(Equal("User Administrator"))
+			Expect(resource.Status.DirectoryScopeID).To(Equal("/"))
+			assignments := graphServer.RoleAssignments(userID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["roleDefinitionId"]).To(Equal(userAdministratorTemplateID))
+			Expect(assignments[0]["directoryScopeId"]).To(Equal("/"))
+
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("replacing the assignment with the new role")
+			resource.Spec.Role = iamv1alpha1.DirectoryRoleDefinition{DisplayName: "Groups Administrator"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			Expect(graphServer.RoleAssignments(userID)).To(BeEmpty())
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.RoleDefinitionID).To(Equal(groupsAdministratorTemplateID))
+			assignments = graphServer.RoleAssignments(userID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["roleDefinitionId"]).To(Equal(groupsAdministratorTemplateID))
+
+			By("assigning the role again when it was removed outside of the controller")
+			graphServer.DeleteRoleAssignment(resource.Status.ID)
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			resource = reconcileAssignment(key)
+			Expect(graphServer.RoleAssignments(userID)).To(HaveLen(1))
+
+			By("revoking the assignment with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graphServer.RoleAssignments(userID)).To(BeEmpty())
+		})
+
+		It("should scope a custom role to an administrative unit", func() {
+			roleID := graphServer.AddRoleDefinition("Password Reset Operator")
+			userID := graphServer.AddUser("Regional helpdesk")
+			unitID := "4f6c2a1e-8b3d-4e7a-9c5f-1d2e3f4a5b6c"
+
+			key := createAssignment("regional-password-reset", iamv1alpha1.EntraDirectoryRoleAssignmentSpec{
+				Principal:            iamv1alpha1.DirectoryRolePrincipal{Type: "User", Id: userID},
+				Role:                 iamv1alpha1.DirectoryRoleDefinition{DisplayName: "Password Reset Operator"},
+				AdministrativeUnitID: unitID,
+			})
+
+			resource := reconcileAssignment(key)
+			Expect(resource.Status.RoleDefinitionID).To(Equal(roleID))
+			Expect(resource.Status.DirectoryScopeID).To(Equal("/administrativeUnits/" + unitID))
+			assignments := graphServer.RoleAssignments(userID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["directoryScopeId"]).To(Equal("/administrativeUnits/" + unitID))
+		})
+
+		It("should only assign roles to referenced groups assignable to roles", func() {
+			createGroup := func(name string, assignable bool) *iamv1alpha1.EntraSecurityGroup {
+				groupKey := createTestGroup(ctx, name, iamv1alpha1.EntraSecurityGroupSpec{IsAssignableToRole: assignable})
+				reconcileTestGroup(ctx, groupKey)
+				group := reconcileTestGroup(ctx, groupKey)
+				Expect(group.Status.ID).NotTo(BeEmpty())
+				return group
+			}
+
+			By("waiting for the referenced group to exist")
+			key := createAssignment("helpdesk-admins", iamv1alpha1.EntraDirectoryRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "Group", Ref: "helpdesk-admins"},
+				Role:      iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+			})
+			resource := reconcileAssignment(key)
+			Expect(resource.Status.Phase).To(Equal("Pending"))
+
+			group := createGroup("helpdesk-admins", true)
+			object, _ := graphServer.Object("groups", group.Status.ID)
+			Expect(object).To(HaveKeyWithValue("isAssignableToRole", true))
+			resource = reconcileAssignment(key)
+			Expect(resource.Status.PrincipalID).To(Equal(group.Status.ID))
+			Expect(graphServer.RoleAssignments(group.Status.ID)).To(HaveLen(1))
+
+			By("failing for a group that is not assignable to roles")
+			plain := createGroup("helpdesk-readers", false)
+			plainKey := createAssignment("helpdesk-readers", iamv1alpha1.EntraDirectoryRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "Group", Ref: "helpdesk-readers"},
+				Role:      iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+			})
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: plainKey})
+			Expect(err).To(HaveOccurred())
+			Expect(k8sClient.Get(ctx, plainKey, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(graphServer.RoleAssignments(plain.Status.ID)).To(BeEmpty())
+		})
+	})
+})
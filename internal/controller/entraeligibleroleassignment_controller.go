@@ -0,0 +1,618 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/pim"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/directoryroleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/services/eligibleroleassignments"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraEligibleRoleAssignmentReconciler reconciles a EntraEligibleRoleAssignment object
+type EntraEligibleRoleAssignmentReconciler struct {
+	client.Client
+	Scheme             *runtime.Scheme
+	EligibilityService eligibleroleassignments.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraeligibleroleassignments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraeligibleroleassignments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraeligibleroleassignments/finalizers,verbs=update
+
+func (r *EntraEligibleRoleAssignmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraEligibleRoleAssignment.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraEligibleRoleAssignment --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	assignment := &entragov.EntraEligibleRoleAssignment{}
+	if err := r.Get(ctx, req.NamespacedName, assignment); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraEligibleRoleAssignment resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraEligibleRoleAssignment")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(assignment)
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		SetPausedCondition(&assignment.Status.Conditions, paused, assignment.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraEligibleRoleAssignment paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraEligibleRoleAssignment reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, assignment, entraEligibleRoleAssignmentFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !assignment.DeletionTimestamp.IsZero() {
+		logger.Info("EntraEligibleRoleAssignment resource is being deleted. skipping reconciliation.")
+		return r.deleteEligibility(ctx, assignment)
+	}
+
+	// Pre-flight: make sure the credential may manage the eligibility before writing to Entra
+	missing, checkErr := r.EligibilityService.CheckCredentials(ctx, *assignment)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		valid = SetCredentialsCondition(&assignment.Status.Conditions, missing, checkErr, assignment.Generation)
+		if !valid {
+			assignment.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraEligibleRoleAssignment credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	target, roleDisplayName, err := r.resolveTarget(ctx, assignment)
+	if err != nil {
+		logger.Error(err, "failed to resolve the principal and target of EntraEligibleRoleAssignment")
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraEligibleRoleAssignment status after failed resolution")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if target.PrincipalID == "" || (assignment.Spec.Group != nil && target.GroupID == "") {
+		// the watches on the referenced resources enqueue the eligibility once they are created
+		logger.Info("referenced principal or group is not created in Entra yet. waiting.")
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	tracked := assignment.Status.ScheduleID != "" || assignment.Status.RequestID != ""
+	if tracked && !sameEligibilityTarget(assignment.Status, target) {
+		// eligibilities cannot be moved to another principal or target, they are replaced
+		return r.replaceEligibility(ctx, assignment)
+	}
+
+	if !tracked {
+		return r.requestEligibility(ctx, assignment, target, roleDisplayName)
+	}
+	if assignment.Status.ScheduleID == "" {
+		return r.awaitSchedule(ctx, assignment, target)
+	}
+	return r.syncEligibility(ctx, assignment, target)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraEligibleRoleAssignmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraEligibleRoleAssignment{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entragov.EntraEligibleRoleAssignment{}, eligibleRoleAssignmentRefField, func(obj client.Object) []string {
+		assignment := obj.(*entragov.EntraEligibleRoleAssignment)
+		var refs []string
+		if assignment.Spec.Principal.Ref != "" {
+			refs = append(refs, assignment.Spec.Principal.Type+"/"+assignment.Spec.Principal.Ref)
+		}
+		if assignment.Spec.Group != nil && assignment.Spec.Group.Ref != "" {
+			refs = append(refs, "Group/"+assignment.Spec.Group.Ref)
+		}
+		return refs
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraEligibleRoleAssignment{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraEligibleRoleAssignmentList{}), builder.OnlyMetadata).
+		Watches(&entragov.EntraUser{}, handler.EnqueueRequestsFromMapFunc(r.eligibilitiesReferencing("User"))).
+		Watches(&entragov.EntraSecurityGroup{}, handler.EnqueueRequestsFromMapFunc(r.eligibilitiesReferencing("Group"))).
+		Watches(&entragov.EntraServicePrincipal{}, handler.EnqueueRequestsFromMapFunc(r.eligibilitiesReferencing("ServicePrincipal"))).
+		Complete(r)
+}
+
+// eligibilitiesReferencing maps a referenced resource of the given type to the eligibilities of
+// its namespace referencing it, so that they are requested once it exists in Entra.
+func (r *EntraEligibleRoleAssignmentReconciler) eligibilitiesReferencing(refType string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		assignments := &entragov.EntraEligibleRoleAssignmentList{}
+		ref := refType + "/" + obj.GetName()
+		if err := r.List(ctx, assignments, client.InNamespace(obj.GetNamespace()), client.MatchingFields{eligibleRoleAssignmentRefField: ref}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list EntraEligibleRoleAssignments referencing resource", "ref", ref)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(assignments.Items))
+		for _, assignment := range assignments.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&assignment)})
+		}
+		return requests
+	}
+}
+
+// resolveTarget returns the eligibility selected by the spec as a request without action and
+// schedule, and the display name of its directory role. The principal and group IDs are empty
+// while referenced resources are not created in Entra.
+func (r *EntraEligibleRoleAssignmentReconciler) resolveTarget(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment) (pim.EligibilityRequest, string, error) {
+	target := pim.EligibilityRequest{}
+
+	principalID, err := directoryRolePrincipalID(ctx, r.Client, assignment.Namespace, assignment.Spec.Principal, assignment.Spec.DirectoryRole != nil)
+	if err != nil {
+		return target, "", err
+	}
+	target.PrincipalID = principalID
+
+	if group := assignment.Spec.Group; group != nil {
+		target.AccessID = group.AccessID
+		if target.AccessID == "" {
+			target.AccessID = pim.AccessMember
+		}
+		target.GroupID = group.Id
+		if group.Ref != "" {
+			target.GroupID, err = referencedID(ctx, r.Client, assignment.Namespace, group.Ref, &entragov.EntraSecurityGroup{})
+			if err != nil {
+				return target, "", err
+			}
+		}
+		return target, "", nil
+	}
+
+	if target.PrincipalID == "" {
+		return target, "", nil
+	}
+	role, err := r.EligibilityService.ResolveRole(ctx, *assignment)
+	if err != nil {
+		return target, "", err
+	}
+	target.RoleDefinitionID = role.ID
+	target.DirectoryScopeID = directoryroleassignments.DirectoryScopeID(assignment.Spec.DirectoryRole.AdministrativeUnitID)
+	return target, role.DisplayName, nil
+}
+
+// requestEligibility requests the eligibility in PIM and records the request in status. An
+// eligibility of the principal for the same target that already exists is adopted instead.
+func (r *EntraEligibleRoleAssignmentReconciler) requestEligibility(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment, target pim.EligibilityRequest, roleDisplayName string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	existing, err := r.EligibilityService.FindSchedule(ctx, *assignment, target)
+	if err != nil {
+		logger.Error(err, "failed to look up existing eligibility schedules")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if existing != nil {
+		logger.Info("adopting existing PIM eligibility", "ScheduleID", existing.ID)
+		// the observed generation is left behind so that the schedule of the spec is applied
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			setEligibilityTarget(&assignment.Status, target, roleDisplayName)
+			setEligibilitySchedule(&assignment.Status, existing)
+			assignment.Status.Adopted = true
+			assignment.Status.Phase = "Success"
+		}); err != nil {
+			logger.Error(err, "failed to update EntraEligibleRoleAssignment status with adopted schedule")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	request := target
+	request.Action = pim.ActionAdminAssign
+	request.StartDateTime, request.EndDateTime = eligibilityWindow(assignment.Spec.Schedule, time.Now())
+	resp, err := r.submitRequest(ctx, assignment, request)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		setEligibilityTarget(&assignment.Status, target, roleDisplayName)
+		assignment.Status.RequestID = resp.ID
+		assignment.Status.RequestStatus = resp.Status
+		assignment.Status.ScheduleID = resp.TargetScheduleID
+		assignment.Status.StartDateTime = &metav1.Time{Time: request.StartDateTime}
+		assignment.Status.EndDateTime = metaTime(request.EndDateTime)
+		assignment.Status.Adopted = false
+		assignment.Status.ObservedGeneration = assignment.Generation
+		assignment.Status.Phase = "Success"
+		if resp.TargetScheduleID == "" {
+			assignment.Status.Phase = "Pending"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraEligibleRoleAssignment status with RequestID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully requested PIM eligibility", "RequestID", resp.ID, "RequestStatus", resp.Status, "ScheduleID", resp.TargetScheduleID)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// awaitSchedule looks for the schedule of an eligibility whose request did not create one yet,
+// e.g. while it waits for approval.
+func (r *EntraEligibleRoleAssignmentReconciler) awaitSchedule(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment, target pim.EligibilityRequest) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	schedule, err := r.EligibilityService.FindSchedule(ctx, *assignment, target)
+	if err != nil {
+		logger.Error(err, "failed to look up the eligibility schedule of the request", "RequestID", assignment.Status.RequestID)
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if schedule == nil {
+		logger.Info("eligibility schedule request is not provisioned yet. waiting.", "RequestID", assignment.Status.RequestID, "RequestStatus", assignment.Status.RequestStatus)
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		setEligibilitySchedule(&assignment.Status, schedule)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraEligibleRoleAssignment status with ScheduleID")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncEligibility reports the schedule of the eligibility, applies changes of the spec and
+// extends eligibilities with a duration before they expire.
+func (r *EntraEligibleRoleAssignmentReconciler) syncEligibility(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment, target pim.EligibilityRequest) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	schedule, statusCode, err := r.EligibilityService.GetSchedule(ctx, *assignment, assignment.Status.ScheduleID)
+	if err != nil {
+		// only an eligibility confirmed missing, e.g. expired or removed in PIM, is requested again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get eligibility schedule by ID from status", "ScheduleID", assignment.Status.ScheduleID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("eligibility schedule from status no longer exists in Entra", "ScheduleID", assignment.Status.ScheduleID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraEligibleRoleAssignment", "EligibilityMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			clearEligibility(&assignment.Status)
+			assignment.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraEligibleRoleAssignment status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	now := time.Now()
+	spec := assignment.Spec.Schedule
+	pending := renewalPending(assignment.Status, schedule)
+	request := target
+	switch {
+	case assignment.Status.ObservedGeneration != assignment.Generation:
+		request.Action = pim.ActionAdminUpdate
+		request.StartDateTime, request.EndDateTime = eligibilityWindow(spec, now)
+	case renewalDue(spec, schedule.EndDateTime, now) && !pending:
+		request.Action = pim.ActionAdminExtend
+		request.StartDateTime = now
+		if schedule.StartDateTime != nil {
+			request.StartDateTime = *schedule.StartDateTime
+		}
+		end := now.Add(spec.Duration.Duration)
+		request.EndDateTime = &end
+	}
+
+	if request.Action != "" {
+		logger.Info("updating PIM eligibility schedule", "action", request.Action, "ScheduleID", assignment.Status.ScheduleID)
+		resp, err := r.submitRequest(ctx, assignment, request)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		if err := PatchStatus(ctx, r.Client, assignment, func() {
+			assignment.Status.RequestID = resp.ID
+			assignment.Status.RequestStatus = resp.Status
+			assignment.Status.StartDateTime = &metav1.Time{Time: request.StartDateTime}
+			assignment.Status.EndDateTime = metaTime(request.EndDateTime)
+			if request.Action == pim.ActionAdminExtend {
+				assignment.Status.LastRenewalTime = &metav1.Time{Time: now}
+			}
+			assignment.Status.ObservedGeneration = assignment.Generation
+		}); err != nil {
+			logger.Error(err, "failed to update EntraEligibleRoleAssignment status after schedule request")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if pending {
+		logger.Info("extension of the PIM eligibility is pending approval. waiting.", "RequestID", assignment.Status.RequestID, "RequestStatus", assignment.Status.RequestStatus)
+	}
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		end := assignment.Status.EndDateTime
+		setEligibilitySchedule(&assignment.Status, schedule)
+		switch {
+		case pending:
+			// the requested end is kept until the extension is approved, so it is not requested again
+			assignment.Status.EndDateTime = end
+		case pendingRequestStatus(assignment.Status.RequestStatus) && end != nil && schedule.EndDateTime != nil && schedule.EndDateTime.Equal(end.Time):
+			assignment.Status.RequestStatus = "Provisioned"
+		}
+		assignment.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraEligibleRoleAssignment status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	requeueAfter := defaultRequeueDuration
+	if spec.Duration != nil && schedule.EndDateTime != nil && !pending {
+		requeueAfter = min(requeueAfter, max(schedule.EndDateTime.Add(-renewBefore(spec)).Sub(now), time.Second))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// replaceEligibility removes the eligibility recorded in status after the principal or target
+// of the spec changed. The new eligibility is requested on the next reconciliation.
+func (r *EntraEligibleRoleAssignmentReconciler) replaceEligibility(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("PIM eligibility target changed. replacing eligibility.", "ScheduleID", assignment.Status.ScheduleID)
+
+	if err := r.removeEligibility(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, assignment, func() {
+		clearEligibility(&assignment.Status)
+		assignment.Status.Phase = "Pending"
+	}); err != nil {
+		logger.Error(err, "failed to clear EntraEligibleRoleAssignment status after removing eligibility")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// removeEligibility requests the removal of the eligibility recorded in status. Adopted
+// eligibilities and eligibilities that no longer exist are left alone.
+func (r *EntraEligibleRoleAssignmentReconciler) removeEligibility(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment) error {
+	logger := log.FromContext(ctx)
+
+	if assignment.Status.ScheduleID == "" && assignment.Status.RequestID == "" {
+		return nil
+	}
+	if assignment.Status.Adopted {
+		logger.Info("PIM eligibility was adopted. leaving it in Entra.", "ScheduleID", assignment.Status.ScheduleID)
+		return nil
+	}
+
+	request := eligibilityFromStatus(assignment.Status)
+	if assignment.Status.ScheduleID == "" {
+		schedule, err := r.EligibilityService.FindSchedule(ctx, *assignment, request)
+		if err != nil {
+			logger.Error(err, "failed to look up the eligibility schedule of the request", "RequestID", assignment.Status.RequestID)
+			return err
+		}
+		if schedule == nil {
+			logger.Info("PIM eligibility request was never provisioned. nothing to remove.", "RequestID", assignment.Status.RequestID)
+			return nil
+		}
+	} else {
+		_, statusCode, err := r.EligibilityService.GetSchedule(ctx, *assignment, assignment.Status.ScheduleID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("eligibility schedule not found in Entra.", "ScheduleID", assignment.Status.ScheduleID)
+			return nil
+		case err != nil:
+			logger.Error(err, "failed to get eligibility schedule in Entra")
+			return err
+		}
+	}
+
+	request.Action = pim.ActionAdminRemove
+	if _, err := r.EligibilityService.Request(ctx, *assignment, request); err != nil {
+		logger.Error(err, "failed to remove PIM eligibility in Entra")
+		return err
+	}
+	return nil
+}
+
+// deleteEligibility removes the eligibility in Entra and removes the finalizer.
+func (r *EntraEligibleRoleAssignmentReconciler) deleteEligibility(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.removeEligibility(ctx, assignment); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, assignment, entraEligibleRoleAssignmentFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraEligibleRoleAssignment")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraEligibleRoleAssignment. deletion complete.")
+	return ctrl.Result{}, nil
+}
+
+// submitRequest submits a schedule request and fails when PIM rejected it.
+func (r *EntraEligibleRoleAssignmentReconciler) submitRequest(ctx context.Context, assignment *entragov.EntraEligibleRoleAssignment, request pim.EligibilityRequest) (*pim.ScheduleRequestResponse, error) {
+	logger := log.FromContext(ctx)
+
+	resp, err := r.EligibilityService.Request(ctx, *assignment, request)
+	if err == nil && rejectedRequestStatus(resp.Status) {
+		err = fmt.Errorf("eligibility schedule request %s was %s", resp.ID, resp.Status)
+	}
+	if err != nil {
+		logger.Error(err, "failed to submit PIM eligibility schedule request", "action", request.Action)
+		if patchErr := PatchStatus(ctx, r.Client, assignment, func() {
+			if resp != nil {
+				assignment.Status.RequestStatus = resp.Status
+			}
+			assignment.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraEligibleRoleAssignment status after failed request")
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// eligibilityWindow returns the start and end of the eligibility of the schedule spec starting
+// now unless the spec sets its start. The end is nil for eligibilities that do not expire.
+func eligibilityWindow(schedule entragov.EligibilitySchedule, now time.Time) (time.Time, *time.Time) {
+	start := now
+	if schedule.StartDateTime != nil {
+		start = schedule.StartDateTime.Time
+	}
+
+	switch {
+	case schedule.EndDateTime != nil:
+		end := schedule.EndDateTime.Time
+		return start, &end
+	case schedule.Duration != nil:
+		end := start.Add(schedule.Duration.Duration)
+		return start, &end
+	}
+	return start, nil
+}
+
+// renewBefore returns how long before its end an eligibility with a duration is extended.
+func renewBefore(schedule entragov.EligibilitySchedule) time.Duration {
+	if schedule.RenewBefore != nil {
+		return schedule.RenewBefore.Duration
+	}
+	if schedule.Duration != nil {
+		return min(defaultEligibilityRenewBefore, schedule.Duration.Duration/2)
+	}
+	return defaultEligibilityRenewBefore
+}
+
+// renewalDue reports whether an eligibility ending at end must be extended.
+func renewalDue(schedule entragov.EligibilitySchedule, end *time.Time, now time.Time) bool {
+	if schedule.Duration == nil || end == nil {
+		return false
+	}
+	return !now.Before(end.Add(-renewBefore(schedule)))
+}
+
+// renewalPending reports whether the schedule request recorded in status extends the
+// eligibility and waits for approval: its end in status is later than the end of the schedule.
+func renewalPending(status entragov.EntraEligibleRoleAssignmentStatus, schedule *pim.EligibilitySchedule) bool {
+	if !pendingRequestStatus(status.RequestStatus) || status.EndDateTime == nil || schedule.EndDateTime == nil {
+		return false
+	}
+	return schedule.EndDateTime.Before(status.EndDateTime.Time)
+}
+
+// pendingRequestStatus reports whether a schedule request status waits for approval or
+// provisioning.
+func pendingRequestStatus(status string) bool {
+	return strings.HasPrefix(status, "Pending")
+}
+
+// rejectedRequestStatus reports whether a schedule request status is final without the
+// eligibility being provisioned.
+func rejectedRequestStatus(status string) bool {
+	switch status {
+	case "Canceled", "Denied", "Failed", "Revoked":
+		return true
+	}
+	return false
+}
+
+func sameEligibilityTarget(status entragov.EntraEligibleRoleAssignmentStatus, target pim.EligibilityRequest) bool {
+	return status.PrincipalID == target.PrincipalID &&
+		status.RoleDefinitionID == target.RoleDefinitionID &&
+		status.DirectoryScopeID == target.DirectoryScopeID &&
+		status.GroupID == target.GroupID &&
+		status.AccessID == target.AccessID
+}
+
+// eligibilityFromStatus returns the eligibility recorded in status as a request without action.
+func eligibilityFromStatus(status entragov.EntraEligibleRoleAssignmentStatus) pim.EligibilityRequest {
+	return pim.EligibilityRequest{
+		PrincipalID:      status.PrincipalID,
+		RoleDefinitionID: status.RoleDefinitionID,
+		DirectoryScopeID: status.DirectoryScopeID,
+		GroupID:          status.GroupID,
+		AccessID:         status.AccessID,
+	}
+}
+
+func setEligibilityTarget(status *entragov.EntraEligibleRoleAssignmentStatus, target pim.EligibilityRequest, roleDisplayName string) {
+	status.PrincipalID = target.PrincipalID
+	status.RoleDefinitionID = target.RoleDefinitionID
+	status.RoleDisplayName = roleDisplayName
+	status.DirectoryScopeID = target.DirectoryScopeID
+	status.GroupID = target.GroupID
+	status.AccessID = target.AccessID
+}
+
+func setEligibilitySchedule(status *entragov.EntraEligibleRoleAssignmentStatus, schedule *pim.EligibilitySchedule) {
+	status.ScheduleID = schedule.ID
+	status.ScheduleStatus = schedule.Status
+	status.StartDateTime = metaTime(schedule.StartDateTime)
+	status.EndDateTime = metaTime(schedule.EndDateTime)
+}
+
+// clearEligibility forgets the eligibility recorded in status, so that it is requested again.
+func clearEligibility(status *entragov.EntraEligibleRoleAssignmentStatus) {
+	status.ScheduleID = ""
+	status.ScheduleStatus = ""
+	status.RequestID = ""
+	status.RequestStatus = ""
+	status.StartDateTime = nil
+	status.EndDateTime = nil
+	status.Adopted = false
+}
+
+func metaTime(t *time.Time) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	return &metav1.Time{Time: *t}
+}
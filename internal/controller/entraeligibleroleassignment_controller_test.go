@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/eligibleroleassignments"
+)
+
+var _ = Describe("EntraEligibleRoleAssignment Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		const userAdministratorTemplateID = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+
+		var controllerReconciler *EntraEligibleRoleAssignmentReconciler
+		var resources *testResources
+
+		createEligibility := func(name string, spec iamv1alpha1.EntraEligibleRoleAssignmentSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			spec.Justification = "Helpdesk on-call rotation"
+			return resources.create(ctx, &iamv1alpha1.EntraEligibleRoleAssignment{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileEligibility := func(key types.NamespacedName) *iamv1alpha1.EntraEligibleRoleAssignment {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraEligibleRoleAssignment{})
+		}
+
+		lastRequest := func() map[string]any {
+			requests := graphServer.EligibilityRequests()
+			Expect(requests).NotTo(BeEmpty())
+			return requests[len(requests)-1]
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraEligibleRoleAssignmentReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				EligibilityService: eligibleroleassignments.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should make the user eligible for the role and renew the eligibility before it expires", func() {
+			userID := graphServer.AddUser("On-call operator")
+
+			key := createEligibility("on-call-user-admin", iamv1alpha1.EntraEligibleRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "User", Id: userID},
+				DirectoryRole: &iamv1alpha1.EligibleDirectoryRole{
+					Role: iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+				},
+				Schedule: iamv1alpha1.EligibilitySchedule{Duration: &metav1.Duration{Duration: 90 * 24 * time.Hour}},
+			})
+
+			resource := reconcileEligibility(key)
+			Expect(resource.Status.ScheduleID).NotTo(BeEmpty())
+			Expect(resource.Status.RoleDisplayName).To(Equal("User Administrator"))
+			Expect(resource.Status.DirectoryScopeID).To(Equal("/"))
+			request := lastRequest()
+			Expect(request).To(HaveKeyWithValue("action", "adminAssign"))
+			Expect(request).To(HaveKeyWithValue("justification", "Helpdesk on-call rotation"))
+			schedules := graphServer.EligibilitySchedules(userID)
+			Expect(schedules).To(HaveLen(1))
+			Expect(schedules[0]["roleDefinitionId"]).To(Equal(userAdministratorTemplateID))
+
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(resource.Status.EndDateTime).NotTo(BeNil())
+			Expect(resource.Status.EndDateTime.Time).To(BeTemporally("~", time.Now().Add(90*24*time.Hour), time.Hour))
+
+			By("extending the eligibility when it is about to expire")
+			graphServer.SetEligibilityEnd(resource.Status.ScheduleID, time.Now().Add(time.Hour))
+			resource = reconcileEligibility(key)
+			Expect(lastRequest()).To(HaveKeyWithValue("action", "adminExtend"))
+			Expect(resource.Status.LastRenewalTime).NotTo(BeNil())
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.EndDateTime.Time).To(BeTemporally("~", time.Now().Add(90*24*time.Hour), time.Hour))
+
+			By("requesting the eligibility again when it was removed outside of the controller")
+			graphServer.DeleteEligibilitySchedule(resource.Status.ScheduleID)
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.ScheduleID).To(BeEmpty())
+			resource = reconcileEligibility(key)
+			Expect(graphServer.EligibilitySchedules(userID)).To(HaveLen(1))
+
+			By("removing the eligibility with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastRequest()).To(HaveKeyWithValue("action", "adminRemove"))
+			Expect(graphServer.EligibilitySchedules(userID)).To(BeEmpty())
+		})
+
+		It("should not extend the eligibility again while the extension waits for approval", func() {
+			userID := graphServer.AddUser("Weekend operator")
+
+			key := createEligibility("weekend-user-admin", iamv1alpha1.EntraEligibleRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "User", Id: userID},
+				DirectoryRole: &iamv1alpha1.EligibleDirectoryRole{
+					Role: iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+				},
+				Schedule: iamv1alpha1.EligibilitySchedule{Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour}},
+			})
+			resource := reconcileEligibility(key)
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			graphServer.RequireApproval("adminExtend")
+			DeferCleanup(graphServer.RequireApproval)
+			end := time.Now().Add(time.Hour)
+			graphServer.SetEligibilityEnd(resource.Status.ScheduleID, end)
+			resource = reconcileEligibility(key)
+			Expect(lastRequest()).To(HaveKeyWithValue("action", "adminExtend"))
+			Expect(resource.Status.RequestStatus).To(Equal("PendingApproval"))
+			requests := len(graphServer.EligibilityRequests())
+
+			By("waiting for the approval of the extension")
+			resource = reconcileEligibility(key)
+			resource = reconcileEligibility(key)
+			Expect(graphServer.EligibilityRequests()).To(HaveLen(requests))
+			Expect(resource.Status.EndDateTime.Time).To(BeTemporally("~", time.Now().Add(30*24*time.Hour), time.Hour))
+
+			By("reporting the schedule once the extension is approved")
+			approvedEnd := resource.Status.EndDateTime.Time
+			graphServer.SetEligibilityEnd(resource.Status.ScheduleID, approvedEnd)
+			resource = reconcileEligibility(key)
+			Expect(graphServer.EligibilityRequests()).To(HaveLen(requests))
+			Expect(resource.Status.EndDateTime.Time).To(BeTemporally("==", approvedEnd))
+			Expect(resource.Status.RequestStatus).To(Equal("Provisioned"))
+
+			By("extending the eligibility again when it is about to expire")
+			graphServer.SetEligibilityEnd(resource.Status.ScheduleID, end)
+			resource = reconcileEligibility(key)
+			Expect(graphServer.EligibilityRequests()).To(HaveLen(requests + 1))
+			Expect(lastRequest()).To(HaveKeyWithValue("action", "adminExtend"))
+		})
+
+		It("should make the user an eligible group owner and update the schedule when it changes", func() {
+			userID := graphServer.AddUser("Group steward")
+			groupID := graphServer.AddObject("groups", map[string]any{
+				"displayName":     "Finance approvers",
+				"mailNickname":    "finance-approvers",
+				"securityEnabled": true,
+			})
+
+			key := createEligibility("finance-approvers-owner", iamv1alpha1.EntraEligibleRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "User", Id: userID},
+				Group:     &iamv1alpha1.EligibleGroupAccess{Id: groupID, AccessID: "owner"},
+				Schedule:  iamv1alpha1.EligibilitySchedule{EndDateTime: &metav1.Time{Time: time.Now().Add(48 * time.Hour)}},
+			})
+
+			resource := reconcileEligibility(key)
+			Expect(resource.Status.GroupID).To(Equal(groupID))
+			Expect(resource.Status.AccessID).To(Equal("owner"))
+			schedules := graphServer.EligibilitySchedules(userID)
+			Expect(schedules).To(HaveLen(1))
+			Expect(schedules[0]["groupId"]).To(Equal(groupID))
+			Expect(schedules[0]["accessId"]).To(Equal("owner"))
+
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("updating the schedule with the new end")
+			end := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+			resource.Spec.Schedule.EndDateTime = &metav1.Time{Time: end}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileEligibility(key)
+			Expect(lastRequest()).To(HaveKeyWithValue("action", "adminUpdate"))
+			resource = reconcileEligibility(key)
+			Expect(resource.Status.EndDateTime.Time).To(BeTemporally("==", end))
+		})
+
+		It("should wait for the referenced principal", func() {
+			key := createEligibility("missing-principal", iamv1alpha1.EntraEligibleRoleAssignmentSpec{
+				Principal: iamv1alpha1.DirectoryRolePrincipal{Type: "User", Ref: "missing-user"},
+				DirectoryRole: &iamv1alpha1.EligibleDirectoryRole{
+					Role: iamv1alpha1.DirectoryRoleDefinition{TemplateID: userAdministratorTemplateID},
+				},
+			})
+
+			resource := reconcileEligibility(key)
+			Expect(resource.Status.Phase).To(Equal("Pending"))
+			Expect(resource.Status.ScheduleID).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,334 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphlocations "github.com/vimal-vijayan/entra-governance/internal/graph/namedlocations"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/namedlocations"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// minIPRangeBits is the shortest prefix Entra accepts in IP named locations
+const minIPRangeBits = 8
+
+// EntraNamedLocationReconciler reconciles a EntraNamedLocation object
+type EntraNamedLocationReconciler struct {
+	client.Client
+	Scheme               *runtime.Scheme
+	NamedLocationService namedlocations.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entranamedlocations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entranamedlocations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entranamedlocations/finalizers,verbs=update
+
+func (r *EntraNamedLocationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraNamedLocation.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraNamedLocation --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	entraLocation := &entragov.EntraNamedLocation{}
+	if err := r.Get(ctx, req.NamespacedName, entraLocation); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraNamedLocation resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraNamedLocation")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraLocation)
+	if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+		SetPausedCondition(&entraLocation.Status.Conditions, paused, entraLocation.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraNamedLocation paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraNamedLocation reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, entraLocation, entraNamedLocationFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !entraLocation.DeletionTimestamp.IsZero() {
+		logger.Info("EntraNamedLocation resource is being deleted. skipping reconciliation.")
+		return r.deleteLocation(ctx, entraLocation)
+	}
+
+	// The IP ranges cannot be validated by the CRD schema, invalid ranges are reported in the
+	// Valid condition until the spec is fixed
+	location, invalid := desiredLocation(entraLocation.Spec)
+	if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+		if !setValidCondition(&entraLocation.Status.Conditions, invalid, entraLocation.Generation) {
+			entraLocation.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraNamedLocation valid condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if invalid != nil {
+		logger.Info("EntraNamedLocation spec is invalid. skipping reconciliation.", "reason", invalid.Error())
+		return ctrl.Result{}, nil
+	}
+
+	// Pre-flight: make sure the credential may manage named locations before writing to Entra
+	missing, checkErr := r.NamedLocationService.CheckCredentials(ctx, *entraLocation)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+		valid = SetCredentialsCondition(&entraLocation.Status.Conditions, missing, checkErr, entraLocation.Generation)
+		if !valid {
+			entraLocation.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraNamedLocation credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if entraLocation.Status.ID == "" {
+		return r.createLocation(ctx, entraLocation, location)
+	}
+
+	return r.syncLocation(ctx, entraLocation, location)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraNamedLocationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraNamedLocation{})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraNamedLocation{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraNamedLocationList{}), builder.OnlyMetadata).
+		Complete(r)
+}
+
+// createLocation creates the named location in Entra and records it in status.
+func (r *EntraNamedLocationReconciler) createLocation(ctx context.Context, entraLocation *entragov.EntraNamedLocation, location graphlocations.NamedLocation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	created, err := r.NamedLocationService.Create(ctx, *entraLocation, location)
+	if err != nil {
+		logger.Error(err, "failed to create Entra named location", "displayName", entraLocation.Spec.DisplayName)
+		if patchErr := PatchStatus(ctx, r.Client, entraLocation, func() {
+			entraLocation.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraNamedLocation status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+		entraLocation.Status.ID = created.ID
+		entraLocation.Status.DisplayName = created.DisplayName
+		entraLocation.Status.Type = created.Type
+		entraLocation.Status.ObservedGeneration = entraLocation.Generation
+		entraLocation.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraNamedLocation status with LocationID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra named location", "LocationID", created.ID, "displayName", created.DisplayName, "type", created.Type)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncLocation applies the spec to the named location in Entra and records its state in status.
+func (r *EntraNamedLocationReconciler) syncLocation(ctx context.Context, entraLocation *entragov.EntraNamedLocation, location graphlocations.NamedLocation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	existing, statusCode, err := r.NamedLocationService.Get(ctx, *entraLocation, entraLocation.Status.ID)
+	if err != nil {
+		// only a named location confirmed missing is forgotten, it is created again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra named location by ID from status", "LocationID", entraLocation.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra named location from status no longer exists in Entra", "LocationID", entraLocation.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraNamedLocation", "NamedLocationMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+			entraLocation.Status.ID = ""
+			entraLocation.Status.DisplayName = ""
+			entraLocation.Status.Type = ""
+			entraLocation.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraNamedLocation status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if !locationInSync(existing, location) {
+		logger.Info("Entra named location is not in sync. updating named location.", "LocationID", existing.ID)
+		if err := r.NamedLocationService.Update(ctx, *entraLocation, existing.ID, location); err != nil {
+			logger.Error(err, "failed to update Entra named location", "LocationID", existing.ID)
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		existing.DisplayName = location.DisplayName
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraLocation, func() {
+		entraLocation.Status.DisplayName = existing.DisplayName
+		entraLocation.Status.Type = existing.Type
+		entraLocation.Status.ObservedGeneration = entraLocation.Generation
+		entraLocation.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraNamedLocation status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// deleteLocation deletes the named location in Entra and removes the finalizer. Graph refuses
+// to delete named locations used by conditional access policies, deletion is retried until the
+// policies no longer use it.
+func (r *EntraNamedLocationReconciler) deleteLocation(ctx context.Context, entraLocation *entragov.EntraNamedLocation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraLocation.Status.ID != "" {
+		_, statusCode, err := r.NamedLocationService.Get(ctx, *entraLocation, entraLocation.Status.ID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("Entra named location not found in Entra. Removing finalizer.")
+		case err != nil:
+			logger.Error(err, "failed to get Entra named location in Entra during deletion")
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		default:
+			if err := r.NamedLocationService.Delete(ctx, *entraLocation, entraLocation.Status.ID); err != nil {
+				logger.Error(err, "failed to delete Entra named location in Entra")
+				return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+			}
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, entraLocation, entraNamedLocationFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraNamedLocation")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraNamedLocation. deletion complete.")
+	return ctrl.Result{}, nil
+}
+
+// desiredLocation returns the named location described by the spec, or why the spec is invalid.
+func desiredLocation(spec entragov.EntraNamedLocationSpec) (graphlocations.NamedLocation, error) {
+	location := graphlocations.NamedLocation{DisplayName: spec.DisplayName}
+	switch {
+	case spec.IP != nil:
+		prefixes, err := parseIPRanges(spec.IP.IPRanges)
+		if err != nil {
+			return location, err
+		}
+		location.Type = graphlocations.TypeIP
+		location.IPRanges = prefixes
+		location.IsTrusted = spec.IP.IsTrusted
+	case spec.Countries != nil:
+		location.Type = graphlocations.TypeCountry
+		location.CountriesAndRegions = enumValues(spec.Countries.CountriesAndRegions)
+		location.IncludeUnknownCountriesAndRegions = spec.Countries.IncludeUnknownCountriesAndRegions
+		location.CountryLookupMethod = spec.Countries.CountryLookupMethod
+		if location.CountryLookupMethod == "" {
+			location.CountryLookupMethod = "clientIpAddress"
+		}
+	default:
+		return location, fmt.Errorf("exactly one of ip or countries must be set")
+	}
+	return location, nil
+}
+
+// parseIPRanges parses CIDR ranges. Ranges with host bits set, e.g. 10.0.0.1/24, are rejected
+// rather than silently widened to their network.
+func parseIPRanges(ranges []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, ipRange := range ranges {
+		prefix, err := netip.ParsePrefix(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %q: %w", ipRange, err)
+		}
+		if prefix.Addr().Is4In6() {
+			return nil, fmt.Errorf("invalid IP range %q: IPv4-mapped IPv6 ranges are not supported, use the IPv4 range", ipRange)
+		}
+		if masked := prefix.Masked(); masked != prefix {
+			return nil, fmt.Errorf("invalid IP range %q: host bits are set, use %s", ipRange, masked)
+		}
+		if prefix.Bits() < minIPRangeBits {
+			return nil, fmt.Errorf("invalid IP range %q: the prefix must be at least /%d", ipRange, minIPRangeBits)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// setValidCondition records why the spec is invalid in the Valid condition. It returns whether
+// the spec is valid.
+func setValidCondition(conditions *[]metav1.Condition, invalid error, generation int64) bool {
+	condition := metav1.Condition{
+		Type:               conditionTypeValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "SpecValid",
+		Message:            "the spec is valid",
+	}
+	if invalid != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SpecInvalid"
+		condition.Message = invalid.Error()
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+	return invalid == nil
+}
+
+// locationInSync reports whether the named location in Entra matches the desired one. IP ranges
+// and countries are compared regardless of their order.
+func locationInSync(existing *graphlocations.NamedLocation, desired graphlocations.NamedLocation) bool {
+	if existing.DisplayName != desired.DisplayName || existing.Type != desired.Type {
+		return false
+	}
+	if desired.Type == graphlocations.TypeIP {
+		return existing.IsTrusted == desired.IsTrusted && sameValues(prefixStrings(existing.IPRanges), prefixStrings(desired.IPRanges))
+	}
+	return existing.IncludeUnknownCountriesAndRegions == desired.IncludeUnknownCountriesAndRegions &&
+		existing.CountryLookupMethod == desired.CountryLookupMethod &&
+		sameValues(existing.CountriesAndRegions, desired.CountriesAndRegions)
+}
+
+func prefixStrings(prefixes []netip.Prefix) []string {
+	values := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		values = append(values, prefix.String())
+	}
+	return values
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/conditionalaccesspolicies"
+	"github.com/vimal-vijayan/entra-governance/internal/services/namedlocations"
+)
+
+var _ = Describe("EntraNamedLocation Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraNamedLocationReconciler
+		var resources *testResources
+
+		createLocation := func(name string, spec iamv1alpha1.EntraNamedLocationSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraNamedLocation{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileLocation := func(key types.NamespacedName) *iamv1alpha1.EntraNamedLocation {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraNamedLocation{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraNamedLocationReconciler{
+				Client:               k8sClient,
+				Scheme:               k8sClient.Scheme(),
+				NamedLocationService: namedlocations.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should create the IP named location once its ranges are valid", func() {
+			key := createLocation("corporate-network", iamv1alpha1.EntraNamedLocationSpec{
+				DisplayName: "Corporate network",
+				IP: &iamv1alpha1.IPNamedLocation{
+					IPRanges:  []string{"10.0.0.1/24", "2001:db8::/48"},
+					IsTrusted: true,
+				},
+			})
+
+			By("reporting ranges with host bits set")
+			resource := reconcileLocation(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			condition := meta.FindStatusCondition(resource.Status.Conditions, conditionTypeValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("10.0.0.0/24"))
+
+			resource.Spec.IP.IPRanges[0] = "10.0.0.0/24"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileLocation(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.Type).To(Equal("ip"))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, conditionTypeValid)).To(BeTrue())
+			location, ok := graphServer.Object("namedLocations", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(location).To(HaveKeyWithValue("isTrusted", true))
+			Expect(location).To(HaveKeyWithValue("ipRanges", ConsistOf(
+				HaveKeyWithValue("cidrAddress", "10.0.0.0/24"),
+				HaveKeyWithValue("cidrAddress", "2001:db8::/48"),
+			)))
+
+			resource = reconcileLocation(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+
+			By("creating the named location again when it was deleted outside of the controller")
+			graphServer.DeleteObject("namedLocations", resource.Status.ID)
+			resource = reconcileLocation(key)
+			Expect(resource.Status.ID).To(BeEmpty())
+			resource = reconcileLocation(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+
+			By("deleting the named location with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("namedLocations", resource.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should apply changes of the countries of a country named location", func() {
+			key := createLocation("operating-countries", iamv1alpha1.EntraNamedLocationSpec{
+				DisplayName: "Operating countries",
+				Countries: &iamv1alpha1.CountryNamedLocation{
+					CountriesAndRegions: []iamv1alpha1.CountryCode{"NL"},
+				},
+			})
+
+			resource := reconcileLocation(key)
+			Expect(resource.Status.Type).To(Equal("country"))
+			location, ok := graphServer.Object("namedLocations", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(location).To(HaveKeyWithValue("countryLookupMethod", "clientIpAddress"))
+
+			resource.Spec.Countries.CountriesAndRegions = append(resource.Spec.Countries.CountriesAndRegions, "US")
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileLocation(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			location, _ = graphServer.Object("namedLocations", resource.Status.ID)
+			Expect(location).To(HaveKeyWithValue("countriesAndRegions", ConsistOf("NL", "US")))
+		})
+
+		It("should be referenced by conditional access policies once it exists", func() {
+			policyReconciler := &EntraConditionalAccessPolicyReconciler{
+				Client:                   k8sClient,
+				Scheme:                   k8sClient.Scheme(),
+				ConditionalAccessService: conditionalaccesspolicies.NewService(clientFactory),
+			}
+			policy := &iamv1alpha1.EntraConditionalAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "block-outside-office", Namespace: "default"},
+				Spec: iamv1alpha1.EntraConditionalAccessPolicySpec{
+					ForProvider: testProvider(),
+					DisplayName: "Block outside office",
+					Conditions: iamv1alpha1.ConditionalAccessConditions{
+						Users:        iamv1alpha1.ConditionalAccessUsers{IncludeUsers: []string{"All"}},
+						Applications: iamv1alpha1.ConditionalAccessApplications{IncludeApplications: []string{"All"}},
+						Locations: &iamv1alpha1.ConditionalAccessLocations{
+							IncludeLocations:    []string{"All"},
+							ExcludeLocationRefs: []string{"office"},
+						},
+					},
+					GrantControls: &iamv1alpha1.ConditionalAccessGrantControls{
+						BuiltInControls: []iamv1alpha1.ConditionalAccessGrantControl{"block"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			policyKey := client.ObjectKeyFromObject(policy)
+
+			By("waiting for the referenced named location")
+			Expect(reconcileResource(ctx, policyReconciler, policyKey, policy).Status.Phase).To(Equal("Pending"))
+			Expect(policy.Status.ID).To(BeEmpty())
+
+			key := createLocation("office", iamv1alpha1.EntraNamedLocationSpec{
+				DisplayName: "Office",
+				IP:          &iamv1alpha1.IPNamedLocation{IPRanges: []string{"192.0.2.0/24"}, IsTrusted: true},
+			})
+			location := reconcileLocation(key)
+			Expect(location.Status.ID).NotTo(BeEmpty())
+
+			policy = reconcileResource(ctx, policyReconciler, policyKey, policy)
+			Expect(policy.Status.ID).NotTo(BeEmpty())
+			graphPolicy, ok := graphServer.Object("conditionalAccessPolicies", policy.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(graphPolicy).To(HaveKeyWithValue("conditions", HaveKeyWithValue("locations",
+				HaveKeyWithValue("excludeLocations", ConsistOf(location.Status.ID)))))
+
+			By("keeping the named location while the policy uses it")
+			Expect(k8sClient.Delete(ctx, location)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+			_, ok = graphServer.Object("namedLocations", location.Status.ID)
+			Expect(ok).To(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+			_, err = policyReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: policyKey})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("namedLocations", location.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,496 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphgrants "github.com/vimal-vijayan/entra-governance/internal/graph/permissiongrants"
+	graphsp "github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/permissiongrants"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraPermissionGrantReconciler reconciles a EntraPermissionGrant object
+type EntraPermissionGrantReconciler struct {
+	client.Client
+	Scheme                 *runtime.Scheme
+	PermissionGrantService permissiongrants.API
+	// HighPrivilegePermissions are only granted once approved by the approved permissions
+	// annotation. DefaultHighPrivilegePermissions are used when nil.
+	HighPrivilegePermissions []string
+}
+
+// consentedResource is an API of the spec resolved against the permissions it publishes.
+type consentedResource struct {
+	resourceID string
+	// scope is the space separated list of the delegated scopes, empty when none are consented
+	scope string
+	// appRoles maps the consented application permissions to the IDs of their app roles
+	appRoles map[string]string
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrapermissiongrants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrapermissiongrants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrapermissiongrants/finalizers,verbs=update
+
+func (r *EntraPermissionGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraPermissionGrant.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraPermissionGrant --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	grant := &entragov.EntraPermissionGrant{}
+	if err := r.Get(ctx, req.NamespacedName, grant); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraPermissionGrant resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraPermissionGrant")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(grant)
+	if err := PatchStatus(ctx, r.Client, grant, func() {
+		SetPausedCondition(&grant.Status.Conditions, paused, grant.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraPermissionGrant paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraPermissionGrant reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, grant, entraPermissionGrantFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !grant.DeletionTimestamp.IsZero() {
+		logger.Info("EntraPermissionGrant resource is being deleted. skipping reconciliation.")
+		return r.deleteGrant(ctx, grant)
+	}
+
+	// Guardrail: high privilege permissions are only consented once a reviewer approved them
+	unapproved := UnapprovedPermissions(requestedPermissions(grant), r.highPrivilegePermissions(), ApprovedPermissions(grant))
+	approved := false
+	if err := PatchStatus(ctx, r.Client, grant, func() {
+		approved = SetApprovalCondition(&grant.Status.Conditions, unapproved, grant.Generation)
+		if !approved {
+			grant.Status.Phase = "Pending"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraPermissionGrant approved condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if !approved {
+		logger.Info("high privilege permissions are not approved. skipping reconciliation.", "unapprovedPermissions", unapproved)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	// Pre-flight: make sure the credential may grant consent before writing to Entra
+	missing, checkErr := r.PermissionGrantService.CheckCredentials(ctx, *grant)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, grant, func() {
+		valid = SetCredentialsCondition(&grant.Status.Conditions, missing, checkErr, grant.Generation)
+		if !valid {
+			grant.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraPermissionGrant credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	appID, err := r.appRegistrationID(ctx, grant)
+	if err != nil {
+		logger.Error(err, "failed to get EntraAppRegistration of EntraPermissionGrant", "appRegistrationRef", grant.Spec.AppRegistrationRef)
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if appID == "" {
+		// the watch on app registrations enqueues the grant once the application is created
+		logger.Info("referenced app registration is not created in Entra yet. waiting.", "appRegistrationRef", grant.Spec.AppRegistrationRef)
+		if err := PatchStatus(ctx, r.Client, grant, func() {
+			grant.Status.Phase = "Pending"
+		}); err != nil {
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	// managed collects the grants in Entra managed by the resource while they are changed
+	managed := grant.Status.DeepCopy()
+
+	clientSP, err := r.PermissionGrantService.ClientServicePrincipal(ctx, *grant, appID)
+	if err != nil {
+		logger.Error(err, "failed to get service principal of the application", "appId", appID)
+		return r.failGrant(ctx, grant, managed, err)
+	}
+	if managed.ClientServicePrincipalID != "" && managed.ClientServicePrincipalID != clientSP.ID {
+		// the grants of a replaced service principal were deleted with it
+		logger.Info("service principal of the application was replaced. granting consent again.", "previousID", managed.ClientServicePrincipalID, "ID", clientSP.ID)
+		managed.DelegatedGrants = nil
+		managed.ApplicationGrants = nil
+	}
+	managed.ClientServicePrincipalID = clientSP.ID
+
+	resources, err := r.resolveResources(ctx, grant)
+	if err != nil {
+		logger.Error(err, "failed to resolve the permissions of EntraPermissionGrant")
+		return r.failGrant(ctx, grant, managed, err)
+	}
+
+	if err := r.syncDelegatedGrants(ctx, grant, managed, resources); err != nil {
+		return r.failGrant(ctx, grant, managed, err)
+	}
+	if err := r.syncApplicationGrants(ctx, grant, managed, resources); err != nil {
+		return r.failGrant(ctx, grant, managed, err)
+	}
+
+	if err := PatchStatus(ctx, r.Client, grant, func() {
+		setManagedGrants(grant, managed)
+		grant.Status.ObservedGeneration = grant.Generation
+		grant.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraPermissionGrant status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	logger.Info("Successfully granted consent", "delegatedGrants", len(grant.Status.DelegatedGrants), "applicationGrants", len(grant.Status.ApplicationGrants))
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraPermissionGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraPermissionGrant{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entragov.EntraPermissionGrant{}, permissionGrantAppRegistrationRefField, func(obj client.Object) []string {
+		return []string{obj.(*entragov.EntraPermissionGrant).Spec.AppRegistrationRef}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraPermissionGrant{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraPermissionGrantList{}), builder.OnlyMetadata).
+		Watches(&entragov.EntraAppRegistration{}, handler.EnqueueRequestsFromMapFunc(r.grantsForAppRegistration)).
+		Complete(r)
+}
+
+// grantsForAppRegistration maps an EntraAppRegistration to the grants of its namespace
+// consenting for it, so that consent is granted once the application exists in Entra.
+func (r *EntraPermissionGrantReconciler) grantsForAppRegistration(ctx context.Context, obj client.Object) []reconcile.Request {
+	grants := &entragov.EntraPermissionGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(obj.GetNamespace()), client.MatchingFields{permissionGrantAppRegistrationRefField: obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list EntraPermissionGrants referencing EntraAppRegistration", "name", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(grants.Items))
+	for _, grant := range grants.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&grant)})
+	}
+	return requests
+}
+
+func (r *EntraPermissionGrantReconciler) highPrivilegePermissions() []string {
+	if r.HighPrivilegePermissions == nil {
+		return DefaultHighPrivilegePermissions
+	}
+	return r.HighPrivilegePermissions
+}
+
+// requestedPermissions returns the delegated scopes and application permissions of all APIs of
+// the spec of grant.
+func requestedPermissions(grant *entragov.EntraPermissionGrant) []string {
+	var requested []string
+	for _, resource := range grant.Spec.Resources {
+		requested = append(requested, resource.DelegatedScopes...)
+		requested = append(requested, resource.ApplicationPermissions...)
+	}
+	return requested
+}
+
+// appRegistrationID returns the application ID of the referenced EntraAppRegistration, empty
+// when it does not exist or was not created in Entra yet.
+func (r *EntraPermissionGrantReconciler) appRegistrationID(ctx context.Context, grant *entragov.EntraPermissionGrant) (string, error) {
+	app := &entragov.EntraAppRegistration{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: grant.Namespace, Name: grant.Spec.AppRegistrationRef}, app); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return app.Status.AppRegistrationID, nil
+}
+
+// resolveResources resolves the APIs of the spec to their service principals and checks that
+// they publish the requested scopes and application permissions.
+func (r *EntraPermissionGrantReconciler) resolveResources(ctx context.Context, grant *entragov.EntraPermissionGrant) ([]consentedResource, error) {
+	resources := make([]consentedResource, 0, len(grant.Spec.Resources))
+	for _, spec := range grant.Spec.Resources {
+		sp, err := r.PermissionGrantService.ResourceServicePrincipal(ctx, *grant, spec.ResourceAppID)
+		if err != nil {
+			return nil, err
+		}
+		published, err := r.PermissionGrantService.PublishedPermissions(ctx, *grant, sp.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var scopes []string
+		for _, scope := range spec.DelegatedScopes {
+			index := slices.IndexFunc(published.Scopes, func(candidate string) bool {
+				return strings.EqualFold(candidate, scope)
+			})
+			if index < 0 {
+				return nil, fmt.Errorf("delegated permission %q is not published by the API %s", scope, spec.ResourceAppID)
+			}
+			if !slices.Contains(scopes, published.Scopes[index]) {
+				scopes = append(scopes, published.Scopes[index])
+			}
+		}
+
+		appRoles := map[string]string{}
+		for _, permission := range spec.ApplicationPermissions {
+			index := slices.IndexFunc(published.AppRoles, func(role graphsp.AppRole) bool {
+				return role.IsEnabled && strings.EqualFold(role.Value, permission) && slices.Contains(role.AllowedMemberTypes, "Application")
+			})
+			if index < 0 {
+				return nil, fmt.Errorf("application permission %q is not published by the API %s", permission, spec.ResourceAppID)
+			}
+			role := published.AppRoles[index]
+			appRoles[role.Value] = role.ID
+		}
+
+		resources = append(resources, consentedResource{
+			resourceID: sp.ID,
+			scope:      strings.Join(scopes, " "),
+			appRoles:   appRoles,
+		})
+	}
+	return resources, nil
+}
+
+// syncDelegatedGrants sets the tenant-wide delegated grant of the application on each API to the
+// consented scopes and revokes the managed grants no longer consented. managed records the
+// managed grants, also when an operation fails.
+func (r *EntraPermissionGrantReconciler) syncDelegatedGrants(ctx context.Context, grant *entragov.EntraPermissionGrant, managed *entragov.EntraPermissionGrantStatus, resources []consentedResource) error {
+	logger := log.FromContext(ctx)
+	clientID := managed.ClientServicePrincipalID
+
+	existing, err := r.PermissionGrantService.ListDelegatedGrants(ctx, *grant, clientID)
+	if err != nil {
+		logger.Error(err, "failed to list delegated permission grants of the application")
+		return err
+	}
+
+	for _, resource := range resources {
+		if resource.scope == "" {
+			continue
+		}
+
+		current := slices.IndexFunc(existing, func(candidate graphgrants.OAuth2PermissionGrantResponse) bool {
+			return candidate.ResourceID == resource.resourceID
+		})
+		if current < 0 {
+			if slices.ContainsFunc(managed.DelegatedGrants, func(status entragov.DelegatedGrantStatus) bool {
+				return status.ResourceID == resource.resourceID
+			}) {
+				logger.Info("delegated permission grant from status no longer exists in Entra", "resourceID", resource.resourceID)
+				metrics.DriftDetectionsTotal.WithLabelValues("EntraPermissionGrant", "DelegatedGrantMissing").Inc()
+			}
+
+			created, err := r.PermissionGrantService.CreateDelegatedGrant(ctx, *grant, clientID, resource.resourceID, resource.scope)
+			if err != nil {
+				logger.Error(err, "failed to create delegated permission grant", "resourceID", resource.resourceID, "scope", resource.scope)
+				return err
+			}
+			setDelegatedGrant(managed, entragov.DelegatedGrantStatus{ID: created.ID, ResourceID: resource.resourceID, Scope: resource.scope})
+			continue
+		}
+
+		if !sameScope(existing[current].Scope, resource.scope) {
+			logger.Info("updating scopes of delegated permission grant", "grantID", existing[current].ID, "scope", resource.scope)
+			if err := r.PermissionGrantService.UpdateDelegatedGrant(ctx, *grant, existing[current].ID, resource.scope); err != nil {
+				logger.Error(err, "failed to update delegated permission grant", "grantID", existing[current].ID)
+				return err
+			}
+		}
+		setDelegatedGrant(managed, entragov.DelegatedGrantStatus{ID: existing[current].ID, ResourceID: resource.resourceID, Scope: resource.scope})
+	}
+
+	for _, stale := range slices.Clone(managed.DelegatedGrants) {
+		if slices.ContainsFunc(resources, func(resource consentedResource) bool {
+			return resource.resourceID == stale.ResourceID && resource.scope != ""
+		}) {
+			continue
+		}
+
+		if slices.ContainsFunc(existing, func(candidate graphgrants.OAuth2PermissionGrantResponse) bool { return candidate.ID == stale.ID }) {
+			logger.Info("revoking delegated permission grant no longer consented", "grantID", stale.ID)
+			if err := r.PermissionGrantService.DeleteDelegatedGrant(ctx, *grant, stale.ID); err != nil {
+				logger.Error(err, "failed to delete delegated permission grant", "grantID", stale.ID)
+				return err
+			}
+		}
+		managed.DelegatedGrants = slices.DeleteFunc(managed.DelegatedGrants, func(status entragov.DelegatedGrantStatus) bool {
+			return status.ID == stale.ID
+		})
+	}
+	return nil
+}
+
+// syncApplicationGrants assigns the consented app roles of each API to the service principal of
+// the application and revokes the managed assignments no longer consented. managed records the
+// managed assignments, also when an operation fails.
+func (r *EntraPermissionGrantReconciler) syncApplicationGrants(ctx context.Context, grant *entragov.EntraPermissionGrant, managed *entragov.EntraPermissionGrantStatus, resources []consentedResource) error {
+	logger := log.FromContext(ctx)
+	clientID := managed.ClientServicePrincipalID
+
+	existing, err := r.PermissionGrantService.ListApplicationGrants(ctx, *grant, clientID)
+	if err != nil {
+		logger.Error(err, "failed to list app role assignments of the application")
+		return err
+	}
+
+	for _, resource := range resources {
+		for permission, appRoleID := range resource.appRoles {
+			current := slices.IndexFunc(existing, func(candidate graphsp.AppRoleAssignmentResponse) bool {
+				return candidate.ResourceID == resource.resourceID && candidate.AppRoleID == appRoleID
+			})
+			if current >= 0 {
+				setApplicationGrant(managed, entragov.ApplicationGrantStatus{ID: existing[current].ID, ResourceID: resource.resourceID, Permission: permission, AppRoleID: appRoleID})
+				continue
+			}
+
+			created, err := r.PermissionGrantService.CreateApplicationGrant(ctx, *grant, clientID, resource.resourceID, appRoleID)
+			if err != nil {
+				logger.Error(err, "failed to assign application permission", "resourceID", resource.resourceID, "permission", permission)
+				return err
+			}
+			setApplicationGrant(managed, entragov.ApplicationGrantStatus{ID: created.ID, ResourceID: resource.resourceID, Permission: permission, AppRoleID: appRoleID})
+		}
+	}
+
+	for _, stale := range slices.Clone(managed.ApplicationGrants) {
+		if slices.ContainsFunc(resources, func(resource consentedResource) bool {
+			return resource.resourceID == stale.ResourceID && resource.appRoles[stale.Permission] == stale.AppRoleID
+		}) {
+			continue
+		}
+
+		if slices.ContainsFunc(existing, func(candidate graphsp.AppRoleAssignmentResponse) bool { return candidate.ID == stale.ID }) {
+			logger.Info("revoking application permission no longer consented", "assignmentID", stale.ID, "permission", stale.Permission)
+			if err := r.PermissionGrantService.DeleteApplicationGrant(ctx, *grant, stale.ResourceID, stale.ID); err != nil {
+				logger.Error(err, "failed to delete app role assignment", "assignmentID", stale.ID)
+				return err
+			}
+		}
+		managed.ApplicationGrants = slices.DeleteFunc(managed.ApplicationGrants, func(status entragov.ApplicationGrantStatus) bool {
+			return status.ID == stale.ID
+		})
+	}
+	return nil
+}
+
+// failGrant records the grants managed so far and the Failed phase in status.
+func (r *EntraPermissionGrantReconciler) failGrant(ctx context.Context, grant *entragov.EntraPermissionGrant, managed *entragov.EntraPermissionGrantStatus, err error) (ctrl.Result, error) {
+	if patchErr := PatchStatus(ctx, r.Client, grant, func() {
+		setManagedGrants(grant, managed)
+		grant.Status.Phase = "Failed"
+	}); patchErr != nil {
+		log.FromContext(ctx).Error(patchErr, "failed to update EntraPermissionGrant status after failed sync")
+	}
+	return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+}
+
+// deleteGrant revokes the grants managed by the resource in Entra and removes the finalizer. The
+// service principal of the application is left in place.
+func (r *EntraPermissionGrantReconciler) deleteGrant(ctx context.Context, grant *entragov.EntraPermissionGrant) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if grant.Status.ClientServicePrincipalID != "" {
+		// with no API consented, every managed grant is revoked
+		managed := grant.Status.DeepCopy()
+		if err := r.syncDelegatedGrants(ctx, grant, managed, nil); err != nil {
+			return r.failGrant(ctx, grant, managed, err)
+		}
+		if err := r.syncApplicationGrants(ctx, grant, managed, nil); err != nil {
+			return r.failGrant(ctx, grant, managed, err)
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, grant, entraPermissionGrantFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraPermissionGrant")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraPermissionGrant. deletion complete.")
+	return ctrl.Result{}, nil
+}
+
+// setManagedGrants copies the service principal and grants recorded in managed to the status of
+// grant, sorted so that status only changes with the grants.
+func setManagedGrants(grant *entragov.EntraPermissionGrant, managed *entragov.EntraPermissionGrantStatus) {
+	slices.SortFunc(managed.DelegatedGrants, func(a, b entragov.DelegatedGrantStatus) int {
+		return strings.Compare(a.ResourceID, b.ResourceID)
+	})
+	slices.SortFunc(managed.ApplicationGrants, func(a, b entragov.ApplicationGrantStatus) int {
+		return strings.Compare(a.ResourceID+"/"+a.Permission, b.ResourceID+"/"+b.Permission)
+	})
+	grant.Status.ClientServicePrincipalID = managed.ClientServicePrincipalID
+	grant.Status.DelegatedGrants = managed.DelegatedGrants
+	grant.Status.ApplicationGrants = managed.ApplicationGrants
+}
+
+// setDelegatedGrant records a managed delegated grant in managed.
+func setDelegatedGrant(managed *entragov.EntraPermissionGrantStatus, grant entragov.DelegatedGrantStatus) {
+	managed.DelegatedGrants = slices.DeleteFunc(managed.DelegatedGrants, func(candidate entragov.DelegatedGrantStatus) bool {
+		return candidate.ResourceID == grant.ResourceID
+	})
+	managed.DelegatedGrants = append(managed.DelegatedGrants, grant)
+}
+
+// setApplicationGrant records a managed app role assignment in managed.
+func setApplicationGrant(managed *entragov.EntraPermissionGrantStatus, grant entragov.ApplicationGrantStatus) {
+	managed.ApplicationGrants = slices.DeleteFunc(managed.ApplicationGrants, func(candidate entragov.ApplicationGrantStatus) bool {
+		return candidate.ResourceID == grant.ResourceID && candidate.AppRoleID == grant.AppRoleID
+	})
+	managed.ApplicationGrants = append(managed.ApplicationGrants, grant)
+}
+
+// sameScope reports whether two space separated scope lists hold the same scopes.
+func sameScope(a, b string) bool {
+	left, right := strings.Fields(a), strings.Fields(b)
+	slices.Sort(left)
+	slices.Sort(right)
+	return slices.Equal(slices.Compact(left), slices.Compact(right))
+}
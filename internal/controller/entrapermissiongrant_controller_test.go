@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/services/permissiongrants"
+)
+
+var _ = Describe("EntraPermissionGrant Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraPermissionGrantReconciler
+		var resources *testResources
+
+		// createAppRegistration creates an application in Entra and an EntraAppRegistration
+		// reporting it as created.
+		createAppRegistration := func(name string) {
+			appID := graphServer.AddExternalApplication(name)
+			app := &iamv1alpha1.EntraAppRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: iamv1alpha1.EntraAppRegistrationSpec{
+					ForProvider: &iamv1alpha1.AppRegCredConfig{CredentialSecretRef: credentialSecretName},
+					Name:        name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, app)).To(Succeed()) })
+			app.Status.AppRegistrationID = appID
+			Expect(k8sClient.Status().Update(ctx, app)).To(Succeed())
+		}
+
+		// addGraphAPI adds a service principal publishing the given delegated and application
+		// permissions and returns its object and application IDs.
+		addGraphAPI := func(scopes, appRoles []string) (string, string) {
+			id := graphServer.AddServicePrincipal("Microsoft Graph")
+			for _, scope := range scopes {
+				graphServer.AddPermissionScope(id, scope)
+			}
+			for _, appRole := range appRoles {
+				graphServer.AddAppRole(id, appRole)
+			}
+			sp, _ := graphServer.Object("servicePrincipals", id)
+			return id, sp["appId"].(string)
+		}
+
+		createGrant := func(name string, annotations map[string]string, spec iamv1alpha1.EntraPermissionGrantSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraPermissionGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+				Spec:       spec,
+			})
+		}
+
+		reconcileGrant := func(key types.NamespacedName) *iamv1alpha1.EntraPermissionGrant {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraPermissionGrant{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraPermissionGrantReconciler{
+				Client:                 k8sClient,
+				Scheme:                 k8sClient.Scheme(),
+				PermissionGrantService: permissiongrants.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should grant delegated and application permissions and revoke them on deletion", func() {
+			createAppRegistration("reporting-app")
+			graphID, graphAppID := addGraphAPI([]string{"User.Read", "offline_access"}, []string{"User.Read.All"})
+
+			key := createGrant("reporting-app-consent", nil, iamv1alpha1.EntraPermissionGrantSpec{
+				AppRegistrationRef: "reporting-app",
+				Resources: []iamv1alpha1.PermissionGrantResource{{
+					ResourceAppID:          graphAppID,
+					DelegatedScopes:        []string{"User.Read"},
+					ApplicationPermissions: []string{"User.Read.All"},
+				}},
+			})
+
+			resource := reconcileGrant(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			clientID := resource.Status.ClientServicePrincipalID
+			Expect(clientID).NotTo(BeEmpty())
+
+			grants := graphServer.PermissionGrants(clientID)
+			Expect(grants).To(HaveLen(1))
+			Expect(grants[0]["resourceId"]).To(Equal(graphID))
+			Expect(grants[0]["consentType"]).To(Equal("AllPrincipals"))
+			Expect(grants[0]["scope"]).To(Equal("User.Read"))
+			assignments := graphServer.AppRoleAssignments(graphID)
+			Expect(assignments).To(HaveLen(1))
+			Expect(assignments[0]["principalId"]).To(Equal(clientID))
+
+			By("updating the consented scopes")
+			resource.Spec.Resources[0].DelegatedScopes = []string{"User.Read", "offline_access"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileGrant(key)
+			Expect(graphServer.PermissionGrants(clientID)[0]["scope"]).To(Equal("User.Read offline_access"))
+			Expect(resource.Status.DelegatedGrants).To(HaveLen(1))
+
+			By("revoking the grants but keeping the service principal with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graphServer.PermissionGrants(clientID)).To(BeEmpty())
+			Expect(graphServer.AppRoleAssignments(graphID)).To(BeEmpty())
+			_, ok := graphServer.Object("servicePrincipals", clientID)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should wait for approval of high privilege permissions", func() {
+			createAppRegistration("provisioning-app")
+			graphID, graphAppID := addGraphAPI(nil, []string{"User.Read.All", "Group.ReadWrite.All"})
+
+			key := createGrant("provisioning-app-consent", nil, iamv1alpha1.EntraPermissionGrantSpec{
+				AppRegistrationRef: "provisioning-app",
+				Resources: []iamv1alpha1.PermissionGrantResource{{
+					ResourceAppID:          graphAppID,
+					ApplicationPermissions: []string{"User.Read.All", "Group.ReadWrite.All"},
+				}},
+			})
+
+			resource := reconcileGrant(key)
+			Expect(resource.Status.Phase).To(Equal("Pending"))
+			approved := meta.FindStatusCondition(resource.Status.Conditions, conditionTypeApproved)
+			Expect(approved).NotTo(BeNil())
+			Expect(approved.Status).To(Equal(metav1.ConditionFalse))
+			Expect(approved.Message).To(ContainSubstring("Group.ReadWrite.All"))
+			Expect(graphServer.AppRoleAssignments(graphID)).To(BeEmpty())
+
+			By("granting the permissions once approved")
+			resource.Annotations = map[string]string{approvedPermissionsAnnotation: "Group.ReadWrite.All"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileGrant(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, conditionTypeApproved)).To(BeTrue())
+			Expect(graphServer.AppRoleAssignments(graphID)).To(HaveLen(2))
+		})
+
+		It("should fail on permissions the API does not publish", func() {
+			createAppRegistration("typo-app")
+			_, graphAppID := addGraphAPI([]string{"User.Read"}, nil)
+
+			key := createGrant("typo-app-consent", nil, iamv1alpha1.EntraPermissionGrantSpec{
+				AppRegistrationRef: "typo-app",
+				Resources: []iamv1alpha1.PermissionGrantResource{{
+					ResourceAppID:   graphAppID,
+					DelegatedScopes: []string{"User.Raed"},
+				}},
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(MatchError(ContainSubstring("User.Raed")))
+
+			resource := &iamv1alpha1.EntraPermissionGrant{}
+			Expect(k8sClient.Get(ctx, key, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(graphServer.PermissionGrants(resource.Status.ClientServicePrincipalID)).To(BeEmpty())
+		})
+	})
+})
@@ -4,22 +4,33 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	entraGroup "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
 	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
 )
 
 // EntraSecurityGroupReconciler reconciles a EntraSecurityGroup object
 type EntraSecurityGroupReconciler struct {
 	client.Client
 	Scheme       *runtime.Scheme
-	GroupService *groups.Service
+	GroupService groups.API
+	// GroupEvents enqueues EntraSecurityGroups changed in Entra, see GroupDeltaWatcher.
+	// When set, periodic requeues are relaxed since changes are detected through delta queries.
+	GroupEvents <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrasecuritygroups,verbs=get;list;watch;create;update;patch;delete
@@ -28,7 +39,10 @@ type EntraSecurityGroupReconciler struct {
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *EntraSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *EntraSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraSecurityGroup.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 	logger.Info("------------------ Reconciling EntraSecurityGroup --------------------", "name", req.Name, "namespace", req.Namespace)
 
@@ -42,6 +56,19 @@ func (r *EntraSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
 
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraGroup)
+	if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+		SetPausedCondition(&entraGroup.Status.Conditions, paused, entraGroup.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraSecurityGroup paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraSecurityGroup reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
 	// Ensure finalizer is present
 	if err := EnsureFinalizer(ctx, r.Client, entraGroup, entraSecurityGroupFinalizer); err != nil {
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
@@ -52,6 +79,27 @@ func (r *EntraSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.deleteResource(ctx, entraGroup)
 	}
 
+	// Pre-flight: make sure the credential may manage groups before writing to Entra
+	missing, checkErr := r.GroupService.CheckCredentials(ctx, *entraGroup)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+		valid = SetCredentialsCondition(&entraGroup.Status.Conditions, missing, checkErr, entraGroup.Generation)
+		if !valid {
+			entraGroup.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraSecurityGroup credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
 	if entraGroup.Status.ID == "" {
 		// Group doesn't exist yet, create it
 		return r.createResource(ctx, entraGroup)
@@ -61,85 +109,316 @@ func (r *EntraSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if err := r.CheckAndUpdateGroupExists(ctx, entraGroup); err != nil {
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
+	if entraGroup.Status.ID == "" {
+		// the group no longer exists in Entra, it is created again
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	// Group exists, check members and owners
-	if entraGroup.Status.ManagedMemberGroups != nil {
-		// check if members are in sync, if not create
-		if err := r.CheckAndUpdateMembers(ctx, *entraGroup); err != nil {
-			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
-		}
+	if err := r.CheckAndUpdateMembers(ctx, entraGroup); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
 
 	if entraGroup.Status.Owners != nil {
 		// check if owners are in sync, if not create
 	}
 
+	if r.GroupEvents != nil {
+		return ctrl.Result{RequeueAfter: deltaWatchRequeueDuration}, nil
+	}
 	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 }
 
-func (r *EntraSecurityGroupReconciler) CheckAndUpdateMembers(ctx context.Context, entraGroup entraGroup.EntraSecurityGroup) error {
+func (r *EntraSecurityGroupReconciler) CheckAndUpdateMembers(ctx context.Context, entraGroup *entraGroup.EntraSecurityGroup) error {
 	// check if members are in sync with the spec
 	logger := log.FromContext(ctx)
 
-	unManagedMemberIds, err := r.GroupService.CheckMemberIds(ctx, entraGroup)
+	resolved, err := r.resolveMemberRefs(ctx, entraGroup)
 	if err != nil {
-		logger.Error(err, "failed to check member IDs for Entra Security Group", "GroupID", entraGroup.Status.ID)
+		logger.Error(err, "failed to resolve referenced members of Entra Security Group", "GroupID", entraGroup.Status.ID)
 		return err
 	}
 
-	if len(unManagedMemberIds) > 0 {
-		// add missing members
-		// missing members need to be added from the spec
-		logger.Info("groups members are not in sync. adding missing members.", "missingMemberIDs", unManagedMemberIds)
+	members, err := r.GroupService.ListMembers(ctx, *entraGroup, false)
+	if err != nil {
+		logger.Error(err, "failed to list members of Entra Security Group", "GroupID", entraGroup.Status.ID)
+		return err
+	}
+
+	current := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		current[member.ID] = struct{}{}
+	}
+
+	// the new status is computed on a copy and applied by PatchStatus
+	status := entraGroup.Status.DeepCopy()
+	status.MemberFailures = nil
+	changed := false
+
+	for _, memberType := range []string{groups.MemberTypeUser, groups.MemberTypeGroup, groups.MemberTypeServicePrincipal} {
+		managed := managedMembers(status, memberType)
+		desired := groups.GetMemberIDs(*resolved, memberType)
+
+		// managed members that were removed outside of the operator are added back
+		present := intersection(*managed, current)
+		if len(present) != len(*managed) {
+			logger.Info("managed members missing from Entra Security Group", "GroupID", entraGroup.Status.ID, "memberType", memberType, "missing", difference(*managed, present))
+			metrics.DriftDetectionsTotal.WithLabelValues("EntraSecurityGroup", "MemberRemoved").Inc()
+			*managed = present
+			changed = true
+		}
+
+		toAdd := difference(desired, *managed)
+		toRemove := difference(*managed, desired)
+		if len(toAdd) == 0 && len(toRemove) == 0 {
+			continue
+		}
+
+		logger.Info("group members are not in sync. updating members.", "GroupID", entraGroup.Status.ID, "memberType", memberType, "add", len(toAdd), "remove", len(toRemove))
+
+		added, addFailures, err := r.GroupService.AddMembers(ctx, *entraGroup, memberType, toAdd)
+		if err != nil {
+			logger.Error(err, "failed to add members to Entra Security Group", "GroupID", entraGroup.Status.ID)
+			return err
+		}
+
+		removed, removeFailures, err := r.GroupService.RemoveMembers(ctx, *entraGroup, memberType, toRemove)
+		if err != nil {
+			logger.Error(err, "failed to remove members from Entra Security Group", "GroupID", entraGroup.Status.ID)
+			return err
+		}
+
+		recordMemberChanges(groups.MemberOperationAdd, len(added), len(addFailures))
+		recordMemberChanges(groups.MemberOperationRemove, len(removed), len(removeFailures))
+
+		*managed = difference(append(*managed, added...), removed)
+		for _, id := range added {
+			current[id] = struct{}{}
+		}
+		for _, id := range removed {
+			delete(current, id)
+		}
+		status.MemberFailures = append(status.MemberFailures, addFailures...)
+		status.MemberFailures = append(status.MemberFailures, removeFailures...)
+		changed = true
+	}
+
+	status.MemberCount = int32(len(current))
+	if !changed && len(entraGroup.Status.MemberFailures) == 0 && entraGroup.Status.MemberCount == status.MemberCount {
+		return nil
+	}
+
+	if len(status.MemberFailures) > 0 {
+		logger.Info("some group members failed to sync", "GroupID", entraGroup.Status.ID, "failures", len(status.MemberFailures))
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+		entraGroup.Status.ManagedMemberUsers = status.ManagedMemberUsers
+		entraGroup.Status.ManagedMemberGroups = status.ManagedMemberGroups
+		entraGroup.Status.ManagedMemberServicePrincipals = status.ManagedMemberServicePrincipals
+		entraGroup.Status.MemberFailures = status.MemberFailures
+		entraGroup.Status.MemberCount = status.MemberCount
+	}); err != nil {
+		logger.Error(err, "failed to update EntraSecurityGroup status after member sync")
+		return err
 	}
 
 	return nil
 }
 
+// resolveMemberRefs returns a copy of group whose members referencing an EntraUser or an
+// EntraServicePrincipal carry its ID in Entra. Members that are missing or not created in Entra
+// yet are left out until they are, their changes enqueue the group again.
+func (r *EntraSecurityGroupReconciler) resolveMemberRefs(ctx context.Context, group *entraGroup.EntraSecurityGroup) (*entraGroup.EntraSecurityGroup, error) {
+	logger := log.FromContext(ctx)
+	if group.Spec.Members == nil {
+		return group, nil
+	}
+
+	resolved := group.DeepCopy()
+	members := make([]entraGroup.Members, 0, len(*resolved.Spec.Members))
+	for _, member := range *resolved.Spec.Members {
+		var ref client.Object
+		var refName string
+		switch {
+		case member.UserRef != "":
+			ref, refName = &entraGroup.EntraUser{}, member.UserRef
+		case member.ServicePrincipalRef != "":
+			ref, refName = &entraGroup.EntraServicePrincipal{}, member.ServicePrincipalRef
+		default:
+			members = append(members, member)
+			continue
+		}
+
+		if err := r.Get(ctx, client.ObjectKey{Namespace: group.Namespace, Name: refName}, ref); err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Info("referenced member not found. skipping member.", "type", member.Type, "ref", refName)
+				continue
+			}
+			return nil, err
+		}
+
+		switch ref := ref.(type) {
+		case *entraGroup.EntraUser:
+			member.Id = ref.Status.ID
+		case *entraGroup.EntraServicePrincipal:
+			member.Id = ref.Status.ID
+		}
+		if member.Id == "" {
+			logger.Info("referenced member is not created in Entra yet. skipping member.", "type", member.Type, "ref", refName)
+			continue
+		}
+		members = append(members, member)
+	}
+	resolved.Spec.Members = &members
+	return resolved, nil
+}
+
+// CheckAndUpdateGroupExists clears the group ID in status when the group no longer exists in
+// Entra, so that it is created again.
 func (r *EntraSecurityGroupReconciler) CheckAndUpdateGroupExists(ctx context.Context, entraGroup *entraGroup.EntraSecurityGroup) error {
 	logger := log.FromContext(ctx)
 
-	_, _, err := r.GroupService.Get(ctx, *entraGroup, entraGroup.Status.ID)
-	if err != nil {
-		logger.Error(err, "failed to get Entra Security Group by ID from status", "GroupID", entraGroup.Status.ID)
+	_, statusCode, err := r.GroupService.Get(ctx, *entraGroup, entraGroup.Status.ID)
+	if err == nil {
+		return nil
+	}
+	// only a group confirmed missing is forgotten, clearing the ID on transient errors
+	// would create a duplicate group
+	if statusCode != "404" {
+		logger.Error(err, "failed to get Entra Security Group by ID from status", "GroupID", entraGroup.Status.ID, "statusCode", statusCode)
+		return err
+	}
+
+	logger.Info("Entra Security Group from status no longer exists in Entra", "GroupID", entraGroup.Status.ID)
+	metrics.DriftDetectionsTotal.WithLabelValues("EntraSecurityGroup", "GroupMissing").Inc()
+	if err := PatchStatus(ctx, r.Client, entraGroup, func() {
 		entraGroup.Status.ID = ""
 		entraGroup.Status.DisplayName = ""
 		entraGroup.Status.Phase = "Pending"
-		if err := r.Status().Update(ctx, entraGroup); err != nil {
-			logger.Error(err, "failed to clear EntraSecurityGroup status after failed get")
-		}
+	}); err != nil {
+		logger.Error(err, "failed to clear EntraSecurityGroup status after failed get")
 		return err
 	}
-
 	return nil
 }
 
 // setupWithManager sets up the controller with the Manager.
 func (r *EntraSecurityGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	err := indexCredentialSecret(context.Background(), mgr, &entraGroup.EntraSecurityGroup{})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entraGroup.EntraSecurityGroup{}, memberUserRefField, func(obj client.Object) []string {
+		return memberRefs(obj.(*entraGroup.EntraSecurityGroup), func(member entraGroup.Members) string { return member.UserRef })
+	})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entraGroup.EntraSecurityGroup{}, memberServicePrincipalRefField, func(obj client.Object) []string {
+		return memberRefs(obj.(*entraGroup.EntraSecurityGroup), func(member entraGroup.Members) string { return member.ServicePrincipalRef })
+	})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &entraGroup.EntraSecurityGroup{}, groupAdministrativeUnitRefField, func(obj client.Object) []string {
+		group := obj.(*entraGroup.EntraSecurityGroup)
+		if group.Spec.AdministrativeUnit == nil || group.Spec.AdministrativeUnit.Ref == "" {
+			return nil
+		}
+		return []string{group.Spec.AdministrativeUnit.Ref}
+	})
+	if err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&entraGroup.EntraSecurityGroup{}).
-		Complete(r)
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entraGroup.EntraSecurityGroupList{}), builder.OnlyMetadata).
+		Watches(&entraGroup.EntraUser{}, handler.EnqueueRequestsFromMapFunc(r.groupsReferencing(memberUserRefField))).
+		Watches(&entraGroup.EntraServicePrincipal{}, handler.EnqueueRequestsFromMapFunc(r.groupsReferencing(memberServicePrincipalRefField))).
+		Watches(&entraGroup.EntraAdministrativeUnit{}, handler.EnqueueRequestsFromMapFunc(r.groupsReferencing(groupAdministrativeUnitRefField)))
+	if r.GroupEvents != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.GroupEvents, &handler.EnqueueRequestForObject{}))
+	}
+	return builder.Complete(r)
+}
+
+// memberRefs returns the names of the resources referenced by the members of group.
+func memberRefs(group *entraGroup.EntraSecurityGroup, ref func(entraGroup.Members) string) []string {
+	if group.Spec.Members == nil {
+		return nil
+	}
+	var refs []string
+	for _, member := range *group.Spec.Members {
+		if name := ref(member); name != "" {
+			refs = append(refs, name)
+		}
+	}
+	return refs
+}
+
+// groupsReferencing maps an EntraUser, EntraServicePrincipal or EntraAdministrativeUnit to the
+// groups of its namespace referencing it in the indexed field, so that members are added and
+// groups are created in the administrative unit once it was created in Entra.
+func (r *EntraSecurityGroupReconciler) groupsReferencing(field string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		groups := &entraGroup.EntraSecurityGroupList{}
+		if err := r.List(ctx, groups, client.InNamespace(obj.GetNamespace()), client.MatchingFields{field: obj.GetName()}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list EntraSecurityGroups referencing member", "field", field, "ref", obj.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(groups.Items))
+		for _, group := range groups.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&group)})
+		}
+		return requests
+	}
 }
 
 // create security group in Entra and update status
 func (r *EntraSecurityGroupReconciler) createResource(ctx context.Context, entraGroup *entraGroup.EntraSecurityGroup) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
-	groupId, groupName, err := r.GroupService.Create(ctx, *entraGroup)
+
+	unitID, err := r.resolveAdministrativeUnit(ctx, entraGroup)
+	if err != nil {
+		logger.Error(err, "failed to resolve administrative unit of Entra Security Group")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if entraGroup.Spec.AdministrativeUnit != nil && unitID == "" {
+		logger.Info("referenced EntraAdministrativeUnit is not created in Entra yet. waiting.", "ref", entraGroup.Spec.AdministrativeUnit.Ref)
+		if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+			entraGroup.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to update EntraSecurityGroup status while waiting for the administrative unit")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	groupId, groupName, err := r.GroupService.Create(ctx, *entraGroup, unitID)
 	if err != nil {
 		logger.Error(err, "failed to create Entra Security Group")
-		entraGroup.Status.Phase = "Failed"
-		if err := r.Status().Update(ctx, entraGroup); err != nil {
+		if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+			entraGroup.Status.Phase = "Failed"
+		}); err != nil {
 			logger.Error(err, "failed to update EntraSecurityGroup status after creation failure")
 		}
 		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
 	}
 
 	// Update status with the created group ID
-	entraGroup.Status.ID = groupId
-	entraGroup.Status.DisplayName = groupName
-	entraGroup.Status.ObservedGeneration = entraGroup.Generation
-	entraGroup.Status.Phase = "Success"
-	if err := r.Status().Update(ctx, entraGroup); err != nil {
+	if err := PatchStatus(ctx, r.Client, entraGroup, func() {
+		entraGroup.Status.ID = groupId
+		entraGroup.Status.DisplayName = groupName
+		entraGroup.Status.AdministrativeUnitID = unitID
+		entraGroup.Status.ObservedGeneration = entraGroup.Generation
+		entraGroup.Status.Phase = "Success"
+	}); err != nil {
 		logger.Error(err, "failed to update EntraSecurityGroup status with GroupID")
 		return ctrl.Result{Requeue: true}, err
 	}
@@ -148,10 +427,28 @@ func (r *EntraSecurityGroupReconciler) createResource(ctx context.Context, entra
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// resolveAdministrativeUnit returns the object ID of the administrative unit the group is created
+// in, empty when it has none or the referenced EntraAdministrativeUnit was not created in Entra yet.
+func (r *EntraSecurityGroupReconciler) resolveAdministrativeUnit(ctx context.Context, group *entraGroup.EntraSecurityGroup) (string, error) {
+	unit := group.Spec.AdministrativeUnit
+	if unit == nil {
+		return "", nil
+	}
+	if unit.Ref == "" {
+		return unit.Id, nil
+	}
+	return referencedID(ctx, r.Client, group.Namespace, unit.Ref, &entraGroup.EntraAdministrativeUnit{})
+}
+
 // Delete resource and remove finalizer
 func (r *EntraSecurityGroupReconciler) deleteResource(ctx context.Context, entraGroup *entraGroup.EntraSecurityGroup) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	if entraGroup.Status.ID == "" {
+		logger.Info("entra security group id is empty in status. skipping deletion in Entra.")
+		return r.removeFinalizer(ctx, entraGroup)
+	}
+
 	_, statusCode, err := r.GroupService.Get(ctx, *entraGroup, entraGroup.Status.ID)
 	if err != nil {
 		if statusCode == "404" {
@@ -162,11 +459,6 @@ func (r *EntraSecurityGroupReconciler) deleteResource(ctx context.Context, entra
 		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
 	}
 
-	if entraGroup.Status.ID == "" {
-		logger.Info("entra security group id is empty in status. skipping deletion in Entra.")
-		return r.removeFinalizer(ctx, entraGroup)
-	}
-
 	err = r.GroupService.Delete(ctx, *entraGroup, entraGroup.Status.ID)
 	if err != nil {
 		logger.Error(err, "failed to delete Entra Security Group in Entra")
@@ -179,11 +471,56 @@ func (r *EntraSecurityGroupReconciler) deleteResource(ctx context.Context, entra
 // Remove finalizer
 func (r *EntraSecurityGroupReconciler) removeFinalizer(ctx context.Context, entraGroup *entraGroup.EntraSecurityGroup) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
-	controllerutil.RemoveFinalizer(entraGroup, entraSecurityGroupFinalizer)
-	if err := r.Update(ctx, entraGroup); err != nil {
+	if err := RemoveFinalizer(ctx, r.Client, entraGroup, entraSecurityGroupFinalizer); err != nil {
 		logger.Error(err, "failed to remove finalizer from EntraSecurityGroup")
 		return ctrl.Result{RequeueAfter: 60 * time.Second}, err
 	}
 	logger.Info("finalizer removed from EntraSecurityGroup. deletion complete.")
 	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
 }
+
+func recordMemberChanges(operation string, succeeded int, failed int) {
+	metrics.GroupMemberChangesTotal.WithLabelValues(operation, "success").Add(float64(succeeded))
+	metrics.GroupMemberChangesTotal.WithLabelValues(operation, "failure").Add(float64(failed))
+}
+
+// managedMembers returns the status field tracking the managed members of the given type.
+func managedMembers(status *entraGroup.EntraSecurityGroupStatus, memberType string) *[]string {
+	switch memberType {
+	case groups.MemberTypeGroup:
+		return &status.ManagedMemberGroups
+	case groups.MemberTypeServicePrincipal:
+		return &status.ManagedMemberServicePrincipals
+	default:
+		return &status.ManagedMemberUsers
+	}
+}
+
+// intersection returns the elements of ids that are present in set.
+func intersection(ids []string, set map[string]struct{}) []string {
+	var result []string
+	for _, id := range ids {
+		if _, ok := set[id]; ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// difference returns the elements of a that are not present in b.
+func difference(a, b []string) []string {
+	exclude := make(map[string]struct{}, len(b))
+	for _, id := range b {
+		exclude[id] = struct{}{}
+	}
+
+	var result []string
+	for _, id := range a {
+		if _, ok := exclude[id]; ok {
+			continue
+		}
+		exclude[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -18,18 +18,63 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	entraclient "github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/fakegraph"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
 )
 
+// failingDeleteGroupService fails group deletion and delegates everything else.
+type failingDeleteGroupService struct {
+	groups.API
+}
+
+func (s *failingDeleteGroupService) Delete(context.Context, iamv1alpha1.EntraSecurityGroup, string) error {
+	return fmt.Errorf("deletion failed")
+}
+
+// selfSignedCertificate returns a PEM encoded self-signed client certificate and its key.
+func selfSignedCertificate() ([]byte, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "entra-governance"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+}
+
 var _ = Describe("EntraSecurityGroup Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
@@ -38,47 +83,649 @@ var _ = Describe("EntraSecurityGroup Controller", func() {
 
 		typeNamespacedName := types.NamespacedName{
 			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Namespace: "default",
+		}
+		var memberIDs []string
+		var controllerReconciler *EntraSecurityGroupReconciler
+
+		reconcileGroup := func() *iamv1alpha1.EntraSecurityGroup {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
 		}
-		entrasecuritygroup := &iamv1alpha1.EntraSecurityGroup{}
 
 		BeforeEach(func() {
+			controllerReconciler = &EntraSecurityGroupReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(clientFactory),
+			}
+
+			memberIDs = []string{graphServer.AddUser("alice"), graphServer.AddUser("bob")}
+			members := []iamv1alpha1.Members{}
+			for _, id := range memberIDs {
+				members = append(members, iamv1alpha1.Members{Type: groups.MemberTypeUser, Id: id})
+			}
+
 			By("creating the custom resource for the Kind EntraSecurityGroup")
-			err := k8sClient.Get(ctx, typeNamespacedName, entrasecuritygroup)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &iamv1alpha1.EntraSecurityGroup{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider:     &iamv1alpha1.ProviderSpec{CredentialSecretRef: credentialSecretName},
+					Name:            "test-group",
+					Description:     "managed by the controller tests",
+					MailNickname:    "test-group",
+					SecurityEnabled: true,
+					Members:         &members,
+				},
 			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			graphServer.ClearFaults()
+
 			resource := &iamv1alpha1.EntraSecurityGroup{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance EntraSecurityGroup")
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
 		})
-		It("should successfully reconcile the resource", func() {
+
+		It("should create the group and sync its members", func() {
 			By("Reconciling the created resource")
+			resource := reconcileGroup()
+			Expect(resource.Finalizers).To(ContainElement(entraSecurityGroupFinalizer))
+			Expect(resource.Status.Phase).To(Equal("Success"))
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+
+			group, ok := graphServer.Object("groups", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(group["displayName"]).To(Equal("test-group"))
+			Expect(group["securityEnabled"]).To(BeTrue())
+
+			By("Reconciling the members")
+			resource = reconcileGroup()
+			Expect(graphServer.Members(resource.Status.ID)).To(ConsistOf(memberIDs))
+			Expect(resource.Status.ManagedMemberUsers).To(ConsistOf(memberIDs))
+			Expect(resource.Status.MemberCount).To(BeEquivalentTo(2))
+			Expect(resource.Status.MemberFailures).To(BeEmpty())
+		})
+
+		It("should add back members removed in Entra", func() {
+			reconcileGroup()
+			resource := reconcileGroup()
+			graphServer.RemoveMember(resource.Status.ID, memberIDs[0])
+
+			By("Reconciling the drifted resource")
+			graphServer.PageSize = 1
+			defer func() { graphServer.PageSize = 100 }()
+			resource = reconcileGroup()
+			Expect(graphServer.Members(resource.Status.ID)).To(ConsistOf(memberIDs))
+			Expect(resource.Status.MemberCount).To(BeEquivalentTo(2))
+		})
+
+		It("should report members that cannot be added", func() {
+			reconcileGroup()
+
+			By("Deleting a member in Entra before it is added")
+			graphServer.DeleteObject("users", memberIDs[1])
+			resource := reconcileGroup()
+			Expect(graphServer.Members(resource.Status.ID)).To(ConsistOf(memberIDs[0]))
+			Expect(resource.Status.ManagedMemberUsers).To(ConsistOf(memberIDs[0]))
+			Expect(resource.Status.MemberFailures).To(HaveLen(1))
+			Expect(resource.Status.MemberFailures[0].ID).To(Equal(memberIDs[1]))
+			Expect(resource.Status.MemberFailures[0].StatusCode).To(BeEquivalentTo(404))
+		})
+
+		It("should report missing permissions before writing to Entra", func() {
+			graphServer.SetRoles("Group.Read.All")
+			DeferCleanup(func() { graphServer.SetRoles(fakegraph.DefaultRoles...) })
+			creates := graphServer.CountRequests("POST", "/groups")
+
+			resource := reconcileGroup()
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(resource.Status.ID).To(BeEmpty())
+			Expect(graphServer.CountRequests("POST", "/groups")).To(Equal(creates))
+
+			condition := meta.FindStatusCondition(resource.Status.Conditions, conditionTypeCredentialsValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("MissingPermissions"))
+			Expect(condition.Message).To(ContainSubstring("Group.ReadWrite.All"))
+
+			By("Granting the permission")
+			graphServer.SetRoles(fakegraph.DefaultRoles...)
+			resource = reconcileGroup()
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, conditionTypeCredentialsValid)).To(BeTrue())
+		})
+
+		It("should adopt the group created by an interrupted reconciliation", func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Creating the group in Entra without recording it in status")
+			groupID := graphServer.AddObject("groups", map[string]any{
+				"displayName":     "test-group",
+				"mailNickname":    "test-group",
+				"securityEnabled": true,
+				"uniqueName":      entraclient.UniqueName(resource),
+			})
+			creates := graphServer.CountRequests("POST", "/groups")
+
+			resource = reconcileGroup()
+			Expect(resource.Status.ID).To(Equal(groupID))
+			Expect(graphServer.CountRequests("POST", "/groups")).To(Equal(creates))
+		})
+
+		It("should tag created groups with the unique name of the resource", func() {
+			resource := reconcileGroup()
+
+			group, ok := graphServer.Object("groups", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(group["uniqueName"]).To(Equal(entraclient.UniqueName(resource)))
+		})
+
+		It("should retry throttled Graph requests", func() {
+			graphServer.Throttle("POST", "/groups", 2)
+
+			resource := reconcileGroup()
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(graphServer.CountRequests("POST", "/groups")).To(BeNumerically(">=", 3))
+		})
+
+		It("should mark the resource failed when Graph rejects the group", func() {
+			graphServer.Inject(fakegraph.Fault{
+				Method:     "POST",
+				Path:       "/groups",
+				StatusCode: 403,
+				Code:       "Authorization_RequestDenied",
+				Message:    "Insufficient privileges to complete the operation.",
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(resource.Status.ID).To(BeEmpty())
+		})
+
+		It("should keep the group ID when Entra fails to return the group", func() {
+			resource := reconcileGroup()
+			groupID := resource.Status.ID
+			groupCount := len(graphServer.Objects("groups"))
+
+			graphServer.Inject(fakegraph.Fault{
+				Method:     "GET",
+				Path:       "/groups/" + groupID,
+				StatusCode: 500,
+				Code:       "InternalServerError",
+				Message:    "Internal server error.",
+			})
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ID).To(Equal(groupID))
+			Expect(graphServer.Objects("groups")).To(HaveLen(groupCount))
+		})
+
+		It("should recreate the group when it was deleted in Entra", func() {
+			resource := reconcileGroup()
+			groupID := resource.Status.ID
+			graphServer.DeleteObject("groups", groupID)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ID).To(BeEmpty())
+
+			resource = reconcileGroup()
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.ID).NotTo(Equal(groupID))
+		})
+
+		It("should add the finalizer to a stale copy of the resource", func() {
+			stale := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, stale)).To(Succeed())
+
+			current := stale.DeepCopy()
+			current.Labels = map[string]string{"team": "marketing"}
+			Expect(k8sClient.Update(ctx, current)).To(Succeed())
+
+			Expect(EnsureFinalizer(ctx, k8sClient, stale, entraSecurityGroupFinalizer)).To(Succeed())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Finalizers).To(ContainElement(entraSecurityGroupFinalizer))
+			Expect(resource.Labels).To(HaveKeyWithValue("team", "marketing"))
+		})
+
+		It("should delete the group in Entra with the resource", func() {
+			resource := reconcileGroup()
+			groupID := resource.Status.ID
+
+			By("Deleting the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok := graphServer.Object("groups", groupID)
+			Expect(ok).To(BeFalse())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+		})
+
+		It("should keep the finalizer when the group cannot be deleted in Entra", func() {
+			resource := reconcileGroup()
+			groupService := controllerReconciler.GroupService
+			controllerReconciler.GroupService = &failingDeleteGroupService{API: groupService}
+
+			By("Deleting the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Finalizers).To(ContainElement(entraSecurityGroupFinalizer))
+			_, ok := graphServer.Object("groups", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+
+			controllerReconciler.GroupService = groupService
+		})
+	})
+
+	Context("When the credential secret is in another namespace", func() {
+		const resourceName = "cross-namespace-resource"
+		const credentialNamespace = "platform-identity"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		var controllerReconciler *EntraSecurityGroupReconciler
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraSecurityGroupReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(clientFactory),
+			}
+
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: credentialNamespace}}
+			if err := k8sClient.Create(ctx, namespace); err != nil {
+				Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: credentialSecretName, Namespace: credentialNamespace},
+				StringData: map[string]string{"tenantId": "tenant", "clientId": "client", "clientSecret": "fake"},
+			}
+			if err := k8sClient.Create(ctx, secret); err != nil {
+				Expect(errors.IsAlreadyExists(err)).To(BeTrue())
+			}
+
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider: &iamv1alpha1.ProviderSpec{
+						CredentialSecretRef:       credentialSecretName,
+						CredentialSecretNamespace: credentialNamespace,
+					},
+					Name:            "shared-credential-group",
+					MailNickname:    "shared-credential-group",
+					SecurityEnabled: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &iamv1alpha1.CredentialGrant{}, client.InNamespace(credentialNamespace))).To(Succeed())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should refuse the secret without a CredentialGrant", func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(entraclient.ErrCredentialNotGranted))
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+		})
+
+		It("should refuse the secret when the grant is for another kind", func() {
+			grant := &iamv1alpha1.CredentialGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-registrations", Namespace: credentialNamespace},
+				Spec: iamv1alpha1.CredentialGrantSpec{
+					From: []iamv1alpha1.CredentialGrantFrom{{Namespace: "default", Kind: "EntraAppRegistration"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(entraclient.ErrCredentialNotGranted))
+		})
+
+		It("should use the secret when a CredentialGrant allows the namespace", func() {
+			grant := &iamv1alpha1.CredentialGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-namespace", Namespace: credentialNamespace},
+				Spec: iamv1alpha1.CredentialGrantSpec{
+					From: []iamv1alpha1.CredentialGrantFrom{{Namespace: "default"}},
+					To:   []iamv1alpha1.CredentialGrantTo{{Name: credentialSecretName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, grant)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+		})
+	})
+
+	Context("When the credential secret holds a client certificate", func() {
+		const resourceName = "certificate-resource"
+		const certificateSecretName = "entra-certificate"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		var controllerReconciler *EntraSecurityGroupReconciler
+
+		createResource := func(authMethod string) {
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider: &iamv1alpha1.ProviderSpec{
+						CredentialSecretRef: certificateSecretName,
+						AuthMethod:          authMethod,
+					},
+					Name:            "certificate-group",
+					MailNickname:    "certificate-group",
+					SecurityEnabled: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraSecurityGroupReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(clientFactory),
+			}
+		})
+
+		AfterEach(func() {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certificateSecretName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should authenticate with the certificate of a TLS secret", func() {
+			certPEM, keyPEM := selfSignedCertificate()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: certificateSecretName, Namespace: "default"},
+				Type:       corev1.SecretTypeTLS,
+				Data: map[string][]byte{
+					"tenantId":              []byte("tenant"),
+					"clientId":              []byte("client"),
+					corev1.TLSCertKey:       certPEM,
+					corev1.TLSPrivateKeyKey: keyPEM,
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			createResource("")
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+		})
+
+		It("should fail when the certificate cannot be parsed", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: certificateSecretName, Namespace: "default"},
+				StringData: map[string]string{
+					"tenantId":          "tenant",
+					"clientId":          "client",
+					"clientSecret":      "fake",
+					"clientCertificate": "not a certificate",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			createResource(entraclient.AuthMethodClientCertificate)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+		})
+	})
+
+	Context("When the provider targets a custom cloud", func() {
+		const resourceName = "custom-cloud-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		AfterEach(func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should fail when the custom cloud has no endpoints", func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider: &iamv1alpha1.ProviderSpec{
+						CredentialSecretRef: credentialSecretName,
+						Cloud:               entraclient.CloudCustom,
+					},
+					Name:            "custom-cloud-group",
+					MailNickname:    "custom-cloud-group",
+					SecurityEnabled: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
 			controllerReconciler := &EntraSecurityGroupReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(clientFactory),
 			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(resource.Status.ID).To(BeEmpty())
+		})
+	})
+
+	Context("When the provider uses the managed identity of the controller", func() {
+		const resourceName = "managed-identity-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider: &iamv1alpha1.ProviderSpec{
+						ManagedIdentity: &iamv1alpha1.ManagedIdentitySpec{ClientID: "00000000-0000-0000-0000-000000000001"},
+					},
+					Name:            "managed-identity-group",
+					MailNickname:    "managed-identity-group",
+					SecurityEnabled: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should refuse the managed identity unless the controller enables it", func() {
+			controllerReconciler := &EntraSecurityGroupReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(clientFactory),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(MatchError(entraclient.ErrManagedIdentityDisabled))
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+		})
+
+		It("should create the group with the managed identity when enabled", func() {
+			factory := entraclient.NewClientFactory(k8sClient,
+				entraclient.WithBaseURL(graphServer.BaseURL()),
+				entraclient.WithCredential(graphServer.Credential()),
+				entraclient.WithManagedIdentity(true),
+			)
+			controllerReconciler := &EntraSecurityGroupReconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				GroupService: groups.NewService(factory),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraSecurityGroup{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+		})
+	})
+
+	Context("When the credential secret of a resource changes", func() {
+		const resourceName = "secret-watch-resource"
+		const lateSecretName = "late-credentials"
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		It("should map the secret to the resources referencing it", func() {
+			resource := &iamv1alpha1.EntraSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: iamv1alpha1.EntraSecurityGroupSpec{
+					ForProvider: &iamv1alpha1.ProviderSpec{
+						CredentialSecretRef: lateSecretName,
+					},
+					Name:            "secret-watch-group",
+					MailNickname:    "secret-watch-group",
+					SecurityEnabled: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:     k8sClient.Scheme(),
+				Metrics:    metricsserver.Options{BindAddress: "0"},
+				Controller: config.Controller{SkipNameValidation: ptr.To(true)},
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+			Expect((&EntraSecurityGroupReconciler{
+				Client:       mgr.GetClient(),
+				Scheme:       mgr.GetScheme(),
+				GroupService: groups.NewService(clientFactory),
+			}).SetupWithManager(mgr)).To(Succeed())
+
+			mgrCtx, stopManager := context.WithCancel(ctx)
+			go func() {
+				defer GinkgoRecover()
+				Expect(mgr.Start(mgrCtx)).To(Succeed())
+			}()
+
+			mapSecret := credentialSecretRequests(mgr.GetClient(), &iamv1alpha1.EntraSecurityGroupList{})
+			lateSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: lateSecretName, Namespace: "default"}}
+			Eventually(func() []reconcile.Request {
+				return mapSecret(ctx, lateSecret)
+			}).Should(ConsistOf(reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			otherSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: lateSecretName, Namespace: "kube-system"}}
+			Expect(mapSecret(ctx, otherSecret)).To(BeEmpty())
+
+			stopManager()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 		})
 	})
 })
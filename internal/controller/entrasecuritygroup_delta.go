@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entraGroup "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphgroups "github.com/vimal-vijayan/entra-governance/internal/graph/groups"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+)
+
+// GroupDeltaWatcher polls the Microsoft Graph groups delta query for every credential
+// used by EntraSecurityGroups and enqueues the EntraSecurityGroups whose group changed
+// in Entra. Delta links are persisted in a ConfigMap so that a restarted controller
+// resumes change tracking instead of missing the changes made while it was down.
+type GroupDeltaWatcher struct {
+	// Client is used to list EntraSecurityGroups and to write the delta link ConfigMap.
+	Client client.Client
+	// Reader reads the delta link ConfigMap without starting a ConfigMap informer.
+	Reader       client.Reader
+	GroupService groups.API
+	// Interval is the time between two delta queries.
+	Interval time.Duration
+	// TokenStore is the ConfigMap the delta links are persisted in.
+	TokenStore types.NamespacedName
+	// Events receives an event for every EntraSecurityGroup that changed in Entra.
+	Events chan<- event.GenericEvent
+}
+
+// +kubebuilder:rbac:groups="",namespace=system,resources=configmaps,verbs=get;create;update
+
+// Start runs the delta query loop until the context is cancelled.
+func (w *GroupDeltaWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("group-delta-watcher")
+	ctx = log.IntoContext(ctx, logger)
+	logger.Info("starting group delta watcher", "interval", w.Interval, "tokenStore", w.TokenStore)
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			logger.Error(err, "failed to poll group delta")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes sure only the leader queries Graph and writes delta links.
+func (w *GroupDeltaWatcher) NeedLeaderElection() bool {
+	return true
+}
+
+func (w *GroupDeltaWatcher) poll(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	entraGroups := &entraGroup.EntraSecurityGroupList{}
+	if err := w.Client.List(ctx, entraGroups); err != nil {
+		return err
+	}
+
+	// groups sharing a credential share a tenant and therefore a delta link
+	byCredential := make(map[string][]entraGroup.EntraSecurityGroup)
+	for _, group := range entraGroups.Items {
+		key := credentialKey(group)
+		if key == "" {
+			continue
+		}
+		byCredential[key] = append(byCredential[key], group)
+	}
+
+	tokenStore, err := w.loadTokenStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	for key, managed := range byCredential {
+		deltaLink := tokenStore.Data[key]
+
+		changedIDs, nextLink, err := w.GroupService.Delta(ctx, managed[0], deltaLink)
+		if err != nil {
+			if errors.Is(err, graphgroups.ErrDeltaTokenExpired) {
+				logger.Info("group delta link expired, restarting change tracking", "credential", key)
+				delete(tokenStore.Data, key)
+				continue
+			}
+			logger.Error(err, "failed to query group delta", "credential", key)
+			continue
+		}
+		tokenStore.Data[key] = nextLink
+
+		// the initial round only starts change tracking; managed groups are already
+		// reconciled on startup so there is nothing to enqueue
+		if deltaLink == "" {
+			continue
+		}
+
+		changed := make(map[string]struct{}, len(changedIDs))
+		for _, id := range changedIDs {
+			changed[id] = struct{}{}
+		}
+
+		for i := range managed {
+			if _, ok := changed[managed[i].Status.ID]; !ok {
+				continue
+			}
+			logger.Info("EntraSecurityGroup changed in Entra, enqueueing", "name", managed[i].Name, "namespace", managed[i].Namespace, "GroupID", managed[i].Status.ID)
+			select {
+			case w.Events <- event.GenericEvent{Object: managed[i].DeepCopy()}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+
+	// drop delta links of credentials that are no longer referenced
+	for key := range tokenStore.Data {
+		if _, ok := byCredential[key]; !ok {
+			delete(tokenStore.Data, key)
+		}
+	}
+
+	return w.saveTokenStore(ctx, tokenStore)
+}
+
+func (w *GroupDeltaWatcher) loadTokenStore(ctx context.Context) (*corev1.ConfigMap, error) {
+	tokenStore := &corev1.ConfigMap{}
+	if err := w.Reader.Get(ctx, w.TokenStore, tokenStore); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		tokenStore.Name = w.TokenStore.Name
+		tokenStore.Namespace = w.TokenStore.Namespace
+	}
+	if tokenStore.Data == nil {
+		tokenStore.Data = map[string]string{}
+	}
+	return tokenStore, nil
+}
+
+func (w *GroupDeltaWatcher) saveTokenStore(ctx context.Context, tokenStore *corev1.ConfigMap) error {
+	if tokenStore.ResourceVersion == "" {
+		return w.Client.Create(ctx, tokenStore)
+	}
+	return w.Client.Update(ctx, tokenStore)
+}
+
+// credentialKey identifies the credential of group, empty when it has none. Keys are valid
+// ConfigMap keys: the secret namespace and name, or the managed identity client ID.
+func credentialKey(group entraGroup.EntraSecurityGroup) string {
+	provider := group.Spec.ForProvider
+	switch {
+	case provider == nil:
+		return ""
+	case provider.ManagedIdentity != nil:
+		if provider.ManagedIdentity.ClientID == "" {
+			return "managed-identity.system"
+		}
+		return "managed-identity." + provider.ManagedIdentity.ClientID
+	case provider.CredentialSecretRef == "":
+		return ""
+	case provider.CredentialSecretNamespace != "":
+		return provider.CredentialSecretNamespace + "." + provider.CredentialSecretRef
+	default:
+		return group.Namespace + "." + provider.CredentialSecretRef
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/fakegraph"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+)
+
+var _ = Describe("GroupDeltaWatcher", func() {
+	ctx := context.Background()
+	tokenStoreKey := types.NamespacedName{Namespace: "default", Name: "group-delta-test"}
+	credential := "default." + credentialSecretName
+
+	var watcher *GroupDeltaWatcher
+	var events chan event.GenericEvent
+	var groupID string
+
+	deltaLink := func() string {
+		tokenStore := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(ctx, tokenStoreKey, tokenStore)).To(Succeed())
+		return tokenStore.Data[credential]
+	}
+
+	BeforeEach(func() {
+		events = make(chan event.GenericEvent, 10)
+		watcher = &GroupDeltaWatcher{
+			Client:       k8sClient,
+			Reader:       k8sClient,
+			GroupService: groups.NewService(clientFactory),
+			Interval:     time.Minute,
+			TokenStore:   tokenStoreKey,
+			Events:       events,
+		}
+
+		groupID = graphServer.AddObject("groups", map[string]any{"displayName": "delta-watched"})
+		group := &iamv1alpha1.EntraSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "delta-watched", Namespace: "default"},
+			Spec: iamv1alpha1.EntraSecurityGroupSpec{
+				ForProvider:  &iamv1alpha1.ProviderSpec{CredentialSecretRef: credentialSecretName},
+				Name:         "delta-watched",
+				MailNickname: "delta-watched",
+			},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+		group.Status.ID = groupID
+		Expect(k8sClient.Status().Update(ctx, group)).To(Succeed())
+
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, group)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: tokenStoreKey.Name, Namespace: tokenStoreKey.Namespace}})).To(Succeed())
+			graphServer.ClearFaults()
+		})
+	})
+
+	It("should start change tracking without enqueueing groups", func() {
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(deltaLink()).NotTo(BeEmpty())
+		Expect(events).To(BeEmpty())
+
+		// the first round does not page through the groups of the tenant
+		Expect(graphServer.Requests()).To(ContainElement(And(
+			HaveField("Path", HavePrefix("/groups/delta")),
+			HaveField("Query", ContainSubstring("deltatoken=latest")),
+		)))
+	})
+
+	It("should enqueue the groups that changed in Entra", func() {
+		Expect(watcher.poll(ctx)).To(Succeed())
+		firstLink := deltaLink()
+
+		By("changing the membership of the managed group and of an unmanaged group")
+		graphServer.AddMember(groupID, graphServer.AddUser("Delta member"))
+		unmanagedID := graphServer.AddObject("groups", map[string]any{"displayName": "unmanaged"})
+		graphServer.AddMember(unmanagedID, graphServer.AddUser("Other member"))
+
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(events).To(HaveLen(1))
+		changed := <-events
+		Expect(changed.Object.GetName()).To(Equal("delta-watched"))
+		Expect(deltaLink()).NotTo(Equal(firstLink))
+
+		By("not enqueueing groups again when nothing changed")
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(events).To(BeEmpty())
+	})
+
+	It("should restart change tracking when the delta link expired", func() {
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(deltaLink()).NotTo(BeEmpty())
+
+		graphServer.Inject(fakegraph.Fault{Method: http.MethodGet, Path: "/groups/delta*", StatusCode: http.StatusGone, Code: "syncStateNotFound", Message: "The delta token has expired.", Times: 1})
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(deltaLink()).To(BeEmpty())
+		Expect(events).To(BeEmpty())
+
+		Expect(watcher.poll(ctx)).To(Succeed())
+		Expect(deltaLink()).To(ContainSubstring("deltatoken"))
+		Expect(deltaLink()).NotTo(ContainSubstring("latest"))
+		Expect(events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphsp "github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraServicePrincipalReconciler reconciles a EntraServicePrincipal object
+type EntraServicePrincipalReconciler struct {
+	client.Client
+	Scheme                  *runtime.Scheme
+	ServicePrincipalService serviceprincipals.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraserviceprincipals,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraserviceprincipals/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entraserviceprincipals/finalizers,verbs=update
+
+func (r *EntraServicePrincipalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraServicePrincipal.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraServicePrincipal --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	entraSP := &entragov.EntraServicePrincipal{}
+	if err := r.Get(ctx, req.NamespacedName, entraSP); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraServicePrincipal resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraServicePrincipal")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraSP)
+	if err := PatchStatus(ctx, r.Client, entraSP, func() {
+		SetPausedCondition(&entraSP.Status.Conditions, paused, entraSP.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraServicePrincipal paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraServicePrincipal reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, entraSP, entraServicePrincipalFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !entraSP.DeletionTimestamp.IsZero() {
+		logger.Info("EntraServicePrincipal resource is being deleted. skipping reconciliation.")
+		return r.deleteServicePrincipal(ctx, entraSP)
+	}
+
+	// Pre-flight: make sure the credential may manage service principals before writing to Entra
+	missing, checkErr := r.ServicePrincipalService.CheckCredentials(ctx, *entraSP)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraSP, func() {
+		valid = SetCredentialsCondition(&entraSP.Status.Conditions, missing, checkErr, entraSP.Generation)
+		if !valid {
+			entraSP.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraServicePrincipal credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if entraSP.Status.ID == "" {
+		return r.createServicePrincipal(ctx, entraSP)
+	}
+
+	return r.syncServicePrincipal(ctx, entraSP)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraServicePrincipalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraServicePrincipal{})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraServicePrincipal{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraServicePrincipalList{}), builder.OnlyMetadata).
+		Complete(r)
+}
+
+// createServicePrincipal instantiates or adopts the service principal of the application in Entra
+// and records it in status.
+func (r *EntraServicePrincipalReconciler) createServicePrincipal(ctx context.Context, entraSP *entragov.EntraServicePrincipal) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	sp, adopted, err := r.ServicePrincipalService.Create(ctx, *entraSP)
+	if err != nil {
+		logger.Error(err, "failed to create Entra service principal", "appId", entraSP.Spec.AppID)
+		if patchErr := PatchStatus(ctx, r.Client, entraSP, func() {
+			entraSP.Status.Phase = "Failed"
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraServicePrincipal status after creation failure")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraSP, func() {
+		entraSP.Status.ID = sp.ID
+		entraSP.Status.AppID = sp.AppID
+		entraSP.Status.DisplayName = sp.DisplayName
+		entraSP.Status.Adopted = adopted
+		entraSP.Status.ObservedGeneration = entraSP.Generation
+		entraSP.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraServicePrincipal status with ServicePrincipalID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra service principal", "ServicePrincipalID", sp.ID, "appId", sp.AppID, "adopted", adopted)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// syncServicePrincipal applies the spec to the service principal in Entra and records its state
+// in status.
+func (r *EntraServicePrincipalReconciler) syncServicePrincipal(ctx context.Context, entraSP *entragov.EntraServicePrincipal) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	sp, statusCode, err := r.ServicePrincipalService.Get(ctx, *entraSP, entraSP.Status.ID)
+	if err != nil {
+		// only a service principal confirmed missing is forgotten, it is instantiated again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra service principal by ID from status", "ServicePrincipalID", entraSP.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra service principal from status no longer exists in Entra", "ServicePrincipalID", entraSP.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraServicePrincipal", "ServicePrincipalMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, entraSP, func() {
+			entraSP.Status.ID = ""
+			entraSP.Status.Adopted = false
+			entraSP.Status.Owners = nil
+			entraSP.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraServicePrincipal status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if !servicePrincipalInSync(sp, entraSP.Spec) {
+		logger.Info("Entra service principal is not in sync. updating service principal.", "ServicePrincipalID", sp.ID)
+		if err := r.ServicePrincipalService.Update(ctx, *entraSP, sp.ID); err != nil {
+			logger.Error(err, "failed to update Entra service principal", "ServicePrincipalID", sp.ID)
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+	}
+
+	owners, err := r.syncOwners(ctx, entraSP)
+	if err != nil {
+		logger.Error(err, "failed to sync owners of Entra service principal", "ServicePrincipalID", sp.ID)
+		if patchErr := PatchStatus(ctx, r.Client, entraSP, func() {
+			entraSP.Status.Owners = owners
+		}); patchErr != nil {
+			logger.Error(patchErr, "failed to update EntraServicePrincipal owners after failed sync")
+		}
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraSP, func() {
+		entraSP.Status.AppID = sp.AppID
+		entraSP.Status.DisplayName = sp.DisplayName
+		entraSP.Status.Owners = owners
+		entraSP.Status.ObservedGeneration = entraSP.Generation
+		entraSP.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraServicePrincipal status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// servicePrincipalInSync reports whether the properties managed by spec match the service
+// principal in Entra. Tags are only compared when the spec sets them.
+func servicePrincipalInSync(sp *graphsp.ServicePrincipalResponse, spec entragov.EntraServicePrincipalSpec) bool {
+	if sp.AppRoleAssignmentRequired != spec.AppRoleAssignmentRequired || sp.Notes != spec.Notes {
+		return false
+	}
+	if len(spec.Tags) == 0 {
+		return true
+	}
+	current := slices.Clone(sp.Tags)
+	desired := slices.Clone(spec.Tags)
+	slices.Sort(current)
+	slices.Sort(desired)
+	return slices.Equal(current, desired)
+}
+
+// syncOwners adds the owners of the spec to the service principal and removes owners the resource
+// added before that are no longer in the spec. Owners added outside of the resource are kept. It
+// returns the owners managed by the resource.
+func (r *EntraServicePrincipalReconciler) syncOwners(ctx context.Context, entraSP *entragov.EntraServicePrincipal) ([]string, error) {
+	desired := serviceprincipals.OwnerIDs(*entraSP)
+	managed := entraSP.Status.Owners
+
+	current, err := r.ServicePrincipalService.ListOwners(ctx, *entraSP, entraSP.Status.ID)
+	if err != nil {
+		return managed, err
+	}
+
+	var toAdd, toRemove, owners []string
+	for _, id := range desired {
+		if slices.Contains(current, id) {
+			owners = append(owners, id)
+		} else {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for _, id := range managed {
+		if !slices.Contains(desired, id) && slices.Contains(current, id) {
+			toRemove = append(toRemove, id)
+		}
+	}
+
+	added, err := r.ServicePrincipalService.AddOwners(ctx, *entraSP, entraSP.Status.ID, toAdd)
+	owners = append(owners, added...)
+	if err != nil {
+		return append(owners, toRemove...), err
+	}
+
+	removed, err := r.ServicePrincipalService.RemoveOwners(ctx, *entraSP, entraSP.Status.ID, toRemove)
+	if err != nil {
+		for _, id := range toRemove {
+			if !slices.Contains(removed, id) {
+				owners = append(owners, id)
+			}
+		}
+		return owners, err
+	}
+	return owners, nil
+}
+
+// deleteServicePrincipal deletes the service principal in Entra and removes the finalizer. Adopted
+// service principals are left in Entra.
+func (r *EntraServicePrincipalReconciler) deleteServicePrincipal(ctx context.Context, entraSP *entragov.EntraServicePrincipal) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraSP.Status.ID != "" && entraSP.Status.Adopted {
+		logger.Info("Entra service principal was adopted. leaving it in Entra.", "ServicePrincipalID", entraSP.Status.ID)
+	} else if entraSP.Status.ID != "" {
+		_, statusCode, err := r.ServicePrincipalService.Get(ctx, *entraSP, entraSP.Status.ID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("Entra service principal not found in Entra. Removing finalizer.")
+		case err != nil:
+			logger.Error(err, "failed to get Entra service principal in Entra during deletion")
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		default:
+			if err := r.ServicePrincipalService.Delete(ctx, *entraSP, entraSP.Status.ID); err != nil {
+				logger.Error(err, "failed to delete Entra service principal in Entra")
+				return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+			}
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, entraSP, entraServicePrincipalFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraServicePrincipal")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraServicePrincipal. deletion complete.")
+	return ctrl.Result{}, nil
+}
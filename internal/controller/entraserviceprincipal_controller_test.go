@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/services/serviceprincipals"
+)
+
+var _ = Describe("EntraServicePrincipal Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraServicePrincipalReconciler
+		var resources *testResources
+
+		createServicePrincipal := func(name string, spec iamv1alpha1.EntraServicePrincipalSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraServicePrincipal{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileServicePrincipal := func(key types.NamespacedName) *iamv1alpha1.EntraServicePrincipal {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraServicePrincipal{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraServicePrincipalReconciler{
+				Client:                  k8sClient,
+				Scheme:                  k8sClient.Scheme(),
+				ServicePrincipalService: serviceprincipals.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should instantiate the service principal of an application of another tenant", func() {
+			appID := graphServer.AddExternalApplication("Fabrikam CRM")
+			ownerID := graphServer.AddUser("App owner")
+
+			key := createServicePrincipal("fabrikam-crm", iamv1alpha1.EntraServicePrincipalSpec{
+				AppID:                     appID,
+				Tags:                      []string{"WindowsAzureActiveDirectoryIntegratedApp"},
+				AppRoleAssignmentRequired: true,
+				Notes:                     "Owned by the sales team",
+				Owners:                    []iamv1alpha1.ServicePrincipalOwner{{Type: "User", Id: ownerID}},
+			})
+
+			resource := reconcileServicePrincipal(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.AppID).To(Equal(appID))
+			Expect(resource.Status.DisplayName).To(Equal("Fabrikam CRM"))
+			Expect(resource.Status.Adopted).To(BeFalse())
+
+			sp, ok := graphServer.Object("servicePrincipals", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(sp["appRoleAssignmentRequired"]).To(BeTrue())
+			Expect(sp["notes"]).To(Equal("Owned by the sales team"))
+			Expect(sp["tags"]).To(ConsistOf("WindowsAzureActiveDirectoryIntegratedApp"))
+
+			By("adding the owners")
+			resource = reconcileServicePrincipal(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(resource.Status.Owners).To(ConsistOf(ownerID))
+			Expect(graphServer.Owners(resource.Status.ID)).To(ConsistOf(ownerID))
+
+			By("applying changes of the spec")
+			resource.Spec.AppRoleAssignmentRequired = false
+			resource.Spec.Owners = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileServicePrincipal(key)
+			sp, _ = graphServer.Object("servicePrincipals", resource.Status.ID)
+			Expect(sp["appRoleAssignmentRequired"]).To(BeFalse())
+			Expect(graphServer.Owners(resource.Status.ID)).To(BeEmpty())
+			Expect(resource.Status.Owners).To(BeEmpty())
+
+			By("deleting the service principal in Entra with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("servicePrincipals", resource.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should adopt an existing service principal and leave it in Entra on delete", func() {
+			existingID := graphServer.AddServicePrincipal("Consented app")
+			existing, _ := graphServer.Object("servicePrincipals", existingID)
+
+			key := createServicePrincipal("consented-app", iamv1alpha1.EntraServicePrincipalSpec{
+				AppID: existing["appId"].(string),
+				Notes: "Adopted",
+			})
+
+			resource := reconcileServicePrincipal(key)
+			Expect(resource.Status.ID).To(Equal(existingID))
+			Expect(resource.Status.Adopted).To(BeTrue())
+
+			resource = reconcileServicePrincipal(key)
+			sp, _ := graphServer.Object("servicePrincipals", existingID)
+			Expect(sp["notes"]).To(Equal("Adopted"))
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := graphServer.Object("servicePrincipals", existingID)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should add service principals referenced by groups once they are created", func() {
+			appID := graphServer.AddExternalApplication("Member app")
+			key := createServicePrincipal("member-app", iamv1alpha1.EntraServicePrincipalSpec{AppID: appID})
+
+			groupKey := createTestGroup(ctx, "sp-ref-group", iamv1alpha1.EntraSecurityGroupSpec{
+				Members: &[]iamv1alpha1.Members{{Type: groups.MemberTypeServicePrincipal, ServicePrincipalRef: key.Name}},
+			})
+
+			By("skipping the service principal until it exists in Entra")
+			reconcileTestGroup(ctx, groupKey)
+			group := reconcileTestGroup(ctx, groupKey)
+			Expect(group.Status.ID).NotTo(BeEmpty())
+			Expect(graphServer.Members(group.Status.ID)).To(BeEmpty())
+
+			By("adding the service principal once it was created")
+			sp := reconcileServicePrincipal(key)
+			group = reconcileTestGroup(ctx, groupKey)
+			Expect(graphServer.Members(group.Status.ID)).To(ConsistOf(sp.Status.ID))
+			Expect(group.Status.ManagedMemberServicePrincipals).To(ConsistOf(sp.Status.ID))
+		})
+	})
+})
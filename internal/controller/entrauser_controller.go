@@ -0,0 +1,342 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	graphusers "github.com/vimal-vijayan/entra-governance/internal/graph/users"
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+	"github.com/vimal-vijayan/entra-governance/internal/services/users"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// EntraUserReconciler reconciles a EntraUser object
+type EntraUserReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	UserService users.API
+}
+
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrausers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrausers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=iam.entra.governance.com,resources=entrausers/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+func (r *EntraUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "EntraUser.Reconcile", attribute.String("k8s.namespace.name", req.Namespace), attribute.String("k8s.resource.name", req.Name))
+	defer func() { tracing.End(span, err) }()
+
+	logger := log.FromContext(ctx)
+	logger.Info("------------------ Reconciling EntraUser --------------------", "name", req.Name, "namespace", req.Namespace)
+
+	entraUser := &entragov.EntraUser{}
+	if err := r.Get(ctx, req.NamespacedName, entraUser); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("EntraUser resource not found. skipping reconciliation.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get EntraUser")
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	// Paused resources are left untouched in Entra, including deletion
+	paused := IsPaused(entraUser)
+	if err := PatchStatus(ctx, r.Client, entraUser, func() {
+		SetPausedCondition(&entraUser.Status.Conditions, paused, entraUser.Generation)
+	}); err != nil {
+		logger.Error(err, "failed to update EntraUser paused condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if paused {
+		logger.Info("EntraUser reconciliation is paused. skipping reconciliation.")
+		return ctrl.Result{}, nil
+	}
+
+	// Ensure finalizer is present
+	if err := EnsureFinalizer(ctx, r.Client, entraUser, entraUserFinalizer); err != nil {
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+	}
+
+	if !entraUser.DeletionTimestamp.IsZero() {
+		logger.Info("EntraUser resource is being deleted. skipping reconciliation.")
+		return r.deleteUser(ctx, entraUser)
+	}
+
+	// Pre-flight: make sure the credential may manage users before writing to Entra
+	missing, checkErr := r.UserService.CheckCredentials(ctx, *entraUser)
+	valid := false
+	if err := PatchStatus(ctx, r.Client, entraUser, func() {
+		valid = SetCredentialsCondition(&entraUser.Status.Conditions, missing, checkErr, entraUser.Generation)
+		if !valid {
+			entraUser.Status.Phase = "Failed"
+		}
+	}); err != nil {
+		logger.Error(err, "failed to update EntraUser credentials condition")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	if checkErr != nil {
+		logger.Error(checkErr, "credential pre-flight check failed")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, checkErr
+	}
+	if !valid {
+		logger.Info("credential is missing Microsoft Graph permissions. skipping reconciliation.", "missingPermissions", missing)
+		return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+	}
+
+	if entraUser.Status.ID == "" {
+		return r.createUser(ctx, entraUser)
+	}
+
+	return r.syncUser(ctx, entraUser)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EntraUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := indexCredentialSecret(context.Background(), mgr, &entragov.EntraUser{})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&entragov.EntraUser{}).
+		Watches(&corev1.Secret{}, enqueueForCredentialSecret(mgr.GetClient(), &entragov.EntraUserList{}), builder.OnlyMetadata).
+		Complete(r)
+}
+
+// createUser invites the guest or creates the cloud-only user in Entra and records it in status.
+func (r *EntraUserReconciler) createUser(ctx context.Context, entraUser *entragov.EntraUser) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraUser.Spec.Invitation != nil {
+		invitation, err := r.UserService.Invite(ctx, *entraUser)
+		if err != nil {
+			logger.Error(err, "failed to invite Entra guest user", "email", entraUser.Spec.Invitation.Email)
+			return r.failCreation(ctx, entraUser, err)
+		}
+
+		if err := PatchStatus(ctx, r.Client, entraUser, func() {
+			entraUser.Status.ID = invitation.UserID
+			entraUser.Status.InviteRedeemURL = invitation.RedeemURL
+			entraUser.Status.InvitationState = invitation.Status
+			entraUser.Status.ObservedGeneration = entraUser.Generation
+			entraUser.Status.Phase = "Success"
+		}); err != nil {
+			logger.Error(err, "failed to update EntraUser status with UserID")
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		logger.Info("Successfully invited Entra guest user", "UserID", invitation.UserID, "status", invitation.Status)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// a user with the user principal name that already exists is adopted and left as it is, it
+	// may be a person or service account that is not managed by the resource
+	existing, err := r.UserService.FindByUserPrincipalName(ctx, *entraUser)
+	if err != nil {
+		logger.Error(err, "failed to look up Entra user by user principal name", "userPrincipalName", entraUser.Spec.UserPrincipalName)
+		return r.failCreation(ctx, entraUser, err)
+	}
+	if existing != nil {
+		logger.Info("adopting existing Entra user with the user principal name", "UserID", existing.ID, "userPrincipalName", existing.UserPrincipalName)
+		if err := PatchStatus(ctx, r.Client, entraUser, func() {
+			entraUser.Status.ID = existing.ID
+			entraUser.Status.UserPrincipalName = existing.UserPrincipalName
+			entraUser.Status.AccountEnabled = existing.AccountEnabled
+			entraUser.Status.Adopted = true
+			entraUser.Status.ObservedGeneration = entraUser.Generation
+			entraUser.Status.Phase = "Success"
+		}); err != nil {
+			logger.Error(err, "failed to update EntraUser status with adopted UserID")
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// the password is stored before the user is created so that it is never lost
+	secretName, password, err := r.ensurePasswordSecret(ctx, entraUser)
+	if err != nil {
+		logger.Error(err, "failed to store the password of the Entra user")
+		return r.failCreation(ctx, entraUser, err)
+	}
+
+	user, err := r.UserService.Create(ctx, *entraUser, password)
+	if err != nil {
+		logger.Error(err, "failed to create Entra user", "userPrincipalName", entraUser.Spec.UserPrincipalName)
+		return r.failCreation(ctx, entraUser, err)
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraUser, func() {
+		entraUser.Status.ID = user.ID
+		entraUser.Status.UserPrincipalName = user.UserPrincipalName
+		entraUser.Status.AccountEnabled = user.AccountEnabled
+		entraUser.Status.PasswordSecretName = secretName
+		entraUser.Status.ObservedGeneration = entraUser.Generation
+		entraUser.Status.Phase = "Success"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraUser status with UserID")
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	logger.Info("Successfully created Entra user", "UserID", user.ID, "userPrincipalName", user.UserPrincipalName)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+func (r *EntraUserReconciler) failCreation(ctx context.Context, entraUser *entragov.EntraUser, err error) (ctrl.Result, error) {
+	if patchErr := PatchStatus(ctx, r.Client, entraUser, func() {
+		entraUser.Status.Phase = "Failed"
+	}); patchErr != nil {
+		log.FromContext(ctx).Error(patchErr, "failed to update EntraUser status after creation failure")
+	}
+	return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+}
+
+// ensurePasswordSecret returns the name of the password Secret of a cloud-only user and the
+// password it holds, creating the Secret with a generated password first. Secrets that are not
+// controlled by entraUser are never overwritten.
+func (r *EntraUserReconciler) ensurePasswordSecret(ctx context.Context, entraUser *entragov.EntraUser) (string, string, error) {
+	name := entraUser.Spec.PasswordSecretName
+	if name == "" {
+		name = entraUser.Name + "-password"
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: entraUser.Namespace, Name: name}, secret)
+	found := err == nil
+	if found {
+		if !metav1.IsControlledBy(secret, entraUser) {
+			return "", "", fmt.Errorf("password secret %s already exists and is not controlled by EntraUser %s", name, entraUser.Name)
+		}
+		if password := string(secret.Data[userPasswordKey]); password != "" {
+			return name, password, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	password, err := users.GeneratePassword()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	if !found {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: entraUser.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+		if err := controllerutil.SetControllerReference(entraUser, secret, r.Scheme); err != nil {
+			return "", "", err
+		}
+	}
+	secret.Data = map[string][]byte{
+		userPrincipalNameKey: []byte(entraUser.Spec.UserPrincipalName),
+		userPasswordKey:      []byte(password),
+	}
+
+	if found {
+		err = r.Update(ctx, secret)
+	} else {
+		err = r.Create(ctx, secret)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return name, password, nil
+}
+
+// syncUser applies the spec to the user in Entra and records its state in status.
+func (r *EntraUserReconciler) syncUser(ctx context.Context, entraUser *entragov.EntraUser) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	user, statusCode, err := r.UserService.Get(ctx, *entraUser, entraUser.Status.ID)
+	if err != nil {
+		// only a user confirmed missing is forgotten, it is invited or created again
+		if statusCode != "404" {
+			logger.Error(err, "failed to get Entra user by ID from status", "UserID", entraUser.Status.ID, "statusCode", statusCode)
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		}
+
+		logger.Info("Entra user from status no longer exists in Entra", "UserID", entraUser.Status.ID)
+		metrics.DriftDetectionsTotal.WithLabelValues("EntraUser", "UserMissing").Inc()
+		if err := PatchStatus(ctx, r.Client, entraUser, func() {
+			entraUser.Status.ID = ""
+			entraUser.Status.InviteRedeemURL = ""
+			entraUser.Status.InvitationState = ""
+			entraUser.Status.Adopted = false
+			entraUser.Status.Phase = "Pending"
+		}); err != nil {
+			logger.Error(err, "failed to clear EntraUser status after failed get")
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	accountEnabled := graphusers.AccountEnabled(entraUser.Spec)
+	// adopted users are left as they are in Entra
+	if !entraUser.Status.Adopted && (user.DisplayName != entraUser.Spec.DisplayName || user.AccountEnabled != accountEnabled) {
+		logger.Info("Entra user is not in sync. updating user.", "UserID", user.ID, "accountEnabled", accountEnabled)
+		if err := r.UserService.Update(ctx, *entraUser, user.ID); err != nil {
+			logger.Error(err, "failed to update Entra user", "UserID", user.ID)
+			return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+		}
+		user.DisplayName = entraUser.Spec.DisplayName
+		user.AccountEnabled = accountEnabled
+	}
+
+	if err := PatchStatus(ctx, r.Client, entraUser, func() {
+		entraUser.Status.UserPrincipalName = user.UserPrincipalName
+		entraUser.Status.AccountEnabled = user.AccountEnabled
+		if user.ExternalUserState != "" {
+			entraUser.Status.InvitationState = user.ExternalUserState
+		}
+		entraUser.Status.ObservedGeneration = entraUser.Generation
+		entraUser.Status.Phase = "Available"
+	}); err != nil {
+		logger.Error(err, "failed to update EntraUser status after sync")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+
+	return ctrl.Result{RequeueAfter: defaultRequeueDuration}, nil
+}
+
+// deleteUser deletes the user in Entra and removes the finalizer. Adopted users are left in
+// Entra.
+func (r *EntraUserReconciler) deleteUser(ctx context.Context, entraUser *entragov.EntraUser) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if entraUser.Status.ID != "" && entraUser.Status.Adopted {
+		logger.Info("Entra user was adopted. leaving it in Entra.", "UserID", entraUser.Status.ID)
+	} else if entraUser.Status.ID != "" {
+		_, statusCode, err := r.UserService.Get(ctx, *entraUser, entraUser.Status.ID)
+		switch {
+		case err != nil && statusCode == "404":
+			logger.Info("Entra user not found in Entra. Removing finalizer.")
+		case err != nil:
+			logger.Error(err, "failed to get Entra user in Entra during deletion")
+			return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+		default:
+			if err := r.UserService.Delete(ctx, *entraUser, entraUser.Status.ID); err != nil {
+				logger.Error(err, "failed to delete Entra user in Entra")
+				return ctrl.Result{RequeueAfter: defaultRequeueDuration}, err
+			}
+		}
+	}
+
+	if err := RemoveFinalizer(ctx, r.Client, entraUser, entraUserFinalizer); err != nil {
+		logger.Error(err, "failed to remove finalizer from EntraUser")
+		return ctrl.Result{RequeueAfter: faildStatusUpdateRequeueDuration}, err
+	}
+	logger.Info("finalizer removed from EntraUser. deletion complete.")
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/services/users"
+)
+
+var _ = Describe("EntraUser Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		var controllerReconciler *EntraUserReconciler
+		var resources *testResources
+
+		createUser := func(name string, spec iamv1alpha1.EntraUserSpec) types.NamespacedName {
+			spec.ForProvider = testProvider()
+			return resources.create(ctx, &iamv1alpha1.EntraUser{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec})
+		}
+
+		reconcileUser := func(key types.NamespacedName) *iamv1alpha1.EntraUser {
+			return reconcileResource(ctx, controllerReconciler, key, &iamv1alpha1.EntraUser{})
+		}
+
+		BeforeEach(func() {
+			controllerReconciler = &EntraUserReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				UserService: users.NewService(clientFactory),
+			}
+			resources = &testResources{reconciler: controllerReconciler}
+		})
+
+		AfterEach(func() {
+			resources.cleanup(ctx)
+		})
+
+		It("should create a cloud-only user with its generated password in a Secret", func() {
+			key := createUser("svc-backup", iamv1alpha1.EntraUserSpec{
+				DisplayName:       "Backup service account",
+				UserPrincipalName: "svc-backup@contoso.onmicrosoft.com",
+			})
+
+			resource := reconcileUser(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.PasswordSecretName).To(Equal("svc-backup-password"))
+
+			user, ok := graphServer.Object("users", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(user["userPrincipalName"]).To(Equal("svc-backup@contoso.onmicrosoft.com"))
+			Expect(user["mailNickname"]).To(Equal("svc-backup"))
+			Expect(user["accountEnabled"]).To(BeTrue())
+
+			By("storing the password the user was created with")
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc-backup-password"}, secret)).To(Succeed())
+			Expect(metav1.IsControlledBy(secret, resource)).To(BeTrue())
+			password, ok := graphServer.Password(resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(string(secret.Data["password"])).To(Equal(password))
+			Expect(string(secret.Data["userPrincipalName"])).To(Equal("svc-backup@contoso.onmicrosoft.com"))
+
+			resource = reconcileUser(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			Expect(resource.Status.AccountEnabled).To(BeTrue())
+
+			By("deleting the user in Entra with the resource")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok = graphServer.Object("users", resource.Status.ID)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should adopt an existing user with the user principal name and leave it in Entra", func() {
+			By("Creating the user in Entra outside of the controller")
+			userID := graphServer.AddObject("users", map[string]any{
+				"displayName":       "Reports service account",
+				"userPrincipalName": "svc-reports@contoso.onmicrosoft.com",
+				"mailNickname":      "svc-reports",
+				"accountEnabled":    true,
+			})
+			creates := graphServer.CountRequests("POST", "/users")
+
+			key := createUser("svc-reports", iamv1alpha1.EntraUserSpec{
+				DisplayName:       "Reporting",
+				UserPrincipalName: "svc-reports@contoso.onmicrosoft.com",
+			})
+			resource := reconcileUser(key)
+			Expect(resource.Status.ID).To(Equal(userID))
+			Expect(resource.Status.Adopted).To(BeTrue())
+			Expect(resource.Status.PasswordSecretName).To(BeEmpty())
+			Expect(graphServer.CountRequests("POST", "/users")).To(Equal(creates))
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "svc-reports-password"}, &corev1.Secret{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			By("leaving the adopted user as it is")
+			resource = reconcileUser(key)
+			Expect(resource.Status.Phase).To(Equal("Available"))
+			user, _ := graphServer.Object("users", userID)
+			Expect(user).To(HaveKeyWithValue("displayName", "Reports service account"))
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := graphServer.Object("users", userID)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should not overwrite a password Secret it does not control", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-password", Namespace: "default"},
+				StringData: map[string]string{"password": "keep-me"},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+
+			key := createUser("svc-conflict", iamv1alpha1.EntraUserSpec{
+				DisplayName:        "Conflicting service account",
+				UserPrincipalName:  "svc-conflict@contoso.onmicrosoft.com",
+				PasswordSecretName: "existing-password",
+			})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+
+			resource := &iamv1alpha1.EntraUser{}
+			Expect(k8sClient.Get(ctx, key, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal("Failed"))
+			Expect(resource.Status.ID).To(BeEmpty())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			Expect(string(secret.Data["password"])).To(Equal("keep-me"))
+		})
+
+		It("should invite a guest user and report the redeem URL", func() {
+			key := createUser("partner-guest", iamv1alpha1.EntraUserSpec{
+				DisplayName: "Jane Partner",
+				Invitation: &iamv1alpha1.InvitationSpec{
+					Email:       "jane@fabrikam.com",
+					RedirectURL: "https://myapps.microsoft.com",
+				},
+			})
+
+			resource := reconcileUser(key)
+			Expect(resource.Status.ID).NotTo(BeEmpty())
+			Expect(resource.Status.InviteRedeemURL).NotTo(BeEmpty())
+			Expect(resource.Status.InvitationState).To(Equal("PendingAcceptance"))
+
+			user, ok := graphServer.Object("users", resource.Status.ID)
+			Expect(ok).To(BeTrue())
+			Expect(user["userType"]).To(Equal("Guest"))
+			Expect(user["mail"]).To(Equal("jane@fabrikam.com"))
+
+			By("reporting the accepted invitation")
+			graphServer.RedeemInvitation(resource.Status.ID)
+			resource = reconcileUser(key)
+			Expect(resource.Status.InvitationState).To(Equal("Accepted"))
+			Expect(resource.Status.Phase).To(Equal("Available"))
+		})
+
+		It("should disable and enable the account of the user", func() {
+			key := createUser("svc-disabled", iamv1alpha1.EntraUserSpec{
+				DisplayName:       "Disabled service account",
+				UserPrincipalName: "svc-disabled@contoso.onmicrosoft.com",
+			})
+			resource := reconcileUser(key)
+			id := resource.Status.ID
+
+			By("disabling the account")
+			resource.Spec.AccountEnabled = ptr.To(false)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileUser(key)
+			Expect(resource.Status.AccountEnabled).To(BeFalse())
+			user, _ := graphServer.Object("users", id)
+			Expect(user["accountEnabled"]).To(BeFalse())
+
+			By("enabling the account again")
+			resource.Spec.AccountEnabled = ptr.To(true)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			resource = reconcileUser(key)
+			Expect(resource.Status.AccountEnabled).To(BeTrue())
+			user, _ = graphServer.Object("users", id)
+			Expect(user["accountEnabled"]).To(BeTrue())
+		})
+
+		It("should add users referenced by groups once they are created", func() {
+			key := createUser("group-member", iamv1alpha1.EntraUserSpec{
+				DisplayName:       "Group member",
+				UserPrincipalName: "group-member@contoso.onmicrosoft.com",
+			})
+
+			groupKey := createTestGroup(ctx, "user-ref-group", iamv1alpha1.EntraSecurityGroupSpec{
+				Members: &[]iamv1alpha1.Members{{Type: groups.MemberTypeUser, UserRef: key.Name}},
+			})
+
+			By("skipping the user until it exists in Entra")
+			reconcileTestGroup(ctx, groupKey)
+			group := reconcileTestGroup(ctx, groupKey)
+			Expect(group.Status.ID).NotTo(BeEmpty())
+			Expect(graphServer.Members(group.Status.ID)).To(BeEmpty())
+
+			By("adding the user once it was created")
+			user := reconcileUser(key)
+			group = reconcileTestGroup(ctx, groupKey)
+			Expect(graphServer.Members(group.Status.ID)).To(ConsistOf(user.Status.ID))
+			Expect(group.Status.ManagedMemberUsers).To(ConsistOf(user.Status.ID))
+		})
+	})
+})
@@ -3,28 +3,41 @@ package controller
 import (
 	"context"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // EnsureFinalizer ensures that the finalizer is set on the resource.
 func EnsureFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
-	if !controllerutil.ContainsFinalizer(obj, finalizer) {
-		controllerutil.AddFinalizer(obj, finalizer)
-		if err := c.Update(ctx, obj); err != nil {
-			return err
-		}
-	}
-	return nil
+	return patchFinalizers(ctx, c, obj, func() bool {
+		return controllerutil.AddFinalizer(obj, finalizer)
+	})
 }
 
 // RemoveFinalizer removes the finalizer from the resource.
 func RemoveFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
-	if controllerutil.ContainsFinalizer(obj, finalizer) {
-		controllerutil.RemoveFinalizer(obj, finalizer)
-		if err := c.Update(ctx, obj); err != nil {
-			return err
+	return client.IgnoreNotFound(patchFinalizers(ctx, c, obj, func() bool {
+		return controllerutil.RemoveFinalizer(obj, finalizer)
+	}))
+}
+
+// patchFinalizers applies mutate to the finalizers of obj and writes them with a merge patch.
+// The patch replaces the whole list, so it is guarded by the resource version and retried on
+// the latest version of the resource when another writer changed it in the meantime.
+func patchFinalizers(ctx context.Context, c client.Client, obj client.Object, mutate func() bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		base := obj.DeepCopyObject().(client.Object)
+		if !mutate() {
+			return nil
+		}
+		err := c.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if apierrors.IsConflict(err) {
+			if getErr := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); getErr != nil {
+				return getErr
+			}
 		}
-	}
-	return nil
+		return err
+	})
 }
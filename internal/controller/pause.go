@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsPaused reports whether reconciliation of the resource is paused through the paused annotation.
+func IsPaused(obj client.Object) bool {
+	return obj.GetAnnotations()[pausedAnnotation] == "true"
+}
+
+// SetPausedCondition adds the Paused condition when the resource is paused and removes it once
+// reconciliation resumes. It returns true when the conditions changed and status needs to be written.
+func SetPausedCondition(conditions *[]metav1.Condition, paused bool, generation int64) bool {
+	if !paused {
+		return meta.RemoveStatusCondition(conditions, conditionTypePaused)
+	}
+
+	return meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "ReconciliationPaused",
+		Message:            "reconciliation is paused by the " + pausedAnnotation + " annotation",
+	})
+}
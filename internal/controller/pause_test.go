@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	groups "github.com/vimal-vijayan/entra-governance/internal/services/groups"
+)
+
+var _ = Describe("Pause", func() {
+	It("should only pause resources annotated with true", func() {
+		group := &iamv1alpha1.EntraSecurityGroup{}
+		Expect(IsPaused(group)).To(BeFalse())
+
+		group.Annotations = map[string]string{pausedAnnotation: "false"}
+		Expect(IsPaused(group)).To(BeFalse())
+
+		group.Annotations[pausedAnnotation] = "yes"
+		Expect(IsPaused(group)).To(BeFalse())
+
+		group.Annotations[pausedAnnotation] = "true"
+		Expect(IsPaused(group)).To(BeTrue())
+	})
+
+	It("should add and remove the Paused condition as reconciliation is paused and resumed", func() {
+		var conditions []metav1.Condition
+
+		By("leaving the conditions of resources that were never paused untouched")
+		Expect(SetPausedCondition(&conditions, false, 1)).To(BeFalse())
+		Expect(conditions).To(BeEmpty())
+
+		By("adding the condition once")
+		Expect(SetPausedCondition(&conditions, true, 1)).To(BeTrue())
+		condition := meta.FindStatusCondition(conditions, conditionTypePaused)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("ReconciliationPaused"))
+		Expect(condition.ObservedGeneration).To(BeEquivalentTo(1))
+		Expect(SetPausedCondition(&conditions, true, 1)).To(BeFalse())
+
+		By("recording the generation observed while paused")
+		Expect(SetPausedCondition(&conditions, true, 2)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, conditionTypePaused).ObservedGeneration).To(BeEquivalentTo(2))
+
+		By("removing the condition when resumed and keeping the other conditions")
+		meta.SetStatusCondition(&conditions, metav1.Condition{Type: conditionTypeCredentialsValid, Status: metav1.ConditionTrue, Reason: "Valid"})
+		Expect(SetPausedCondition(&conditions, false, 2)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, conditionTypePaused)).To(BeNil())
+		Expect(conditions).To(HaveLen(1))
+		Expect(SetPausedCondition(&conditions, false, 2)).To(BeFalse())
+	})
+
+	It("should leave paused resources untouched in Entra until resumed", func() {
+		ctx := context.Background()
+		reconciler := &EntraSecurityGroupReconciler{
+			Client:       k8sClient,
+			Scheme:       k8sClient.Scheme(),
+			GroupService: groups.NewService(clientFactory),
+		}
+		group := &iamv1alpha1.EntraSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "paused-group",
+				Namespace:   "default",
+				Annotations: map[string]string{pausedAnnotation: "true"},
+			},
+			Spec: iamv1alpha1.EntraSecurityGroupSpec{
+				ForProvider:     &iamv1alpha1.ProviderSpec{CredentialSecretRef: credentialSecretName},
+				Name:            "paused-group",
+				MailNickname:    "paused-group",
+				SecurityEnabled: true,
+			},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+		key := client.ObjectKeyFromObject(group)
+		reconcileGroup := func() *iamv1alpha1.EntraSecurityGroup {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, group)).To(Succeed())
+			return group
+		}
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, key, group)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, group)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		group = reconcileGroup()
+		Expect(group.Status.ID).To(BeEmpty())
+		Expect(group.Finalizers).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(group.Status.Conditions, conditionTypePaused)).To(BeTrue())
+
+		By("resuming reconciliation")
+		group.Annotations = nil
+		Expect(k8sClient.Update(ctx, group)).To(Succeed())
+		group = reconcileGroup()
+		Expect(meta.FindStatusCondition(group.Status.Conditions, conditionTypePaused)).To(BeNil())
+		Expect(group.Status.ID).NotTo(BeEmpty())
+		_, ok := graphServer.Object("groups", group.Status.ID)
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	entragov "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// principalObject returns an empty resource of the kind managing principals of the given type:
+// EntraUser for User, EntraSecurityGroup for Group and EntraServicePrincipal otherwise.
+func principalObject(principalType string) client.Object {
+	switch principalType {
+	case "User":
+		return &entragov.EntraUser{}
+	case "Group":
+		return &entragov.EntraSecurityGroup{}
+	default:
+		return &entragov.EntraServicePrincipal{}
+	}
+}
+
+// referencedID returns the Entra object ID recorded in the status of the named resource, empty
+// when the resource does not exist or was not created in Entra yet.
+func referencedID(ctx context.Context, c client.Reader, namespace, name string, obj client.Object) (string, error) {
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	switch obj := obj.(type) {
+	case *entragov.EntraUser:
+		return obj.Status.ID, nil
+	case *entragov.EntraSecurityGroup:
+		return obj.Status.ID, nil
+	case *entragov.EntraServicePrincipal:
+		return obj.Status.ID, nil
+	case *entragov.EntraAdministrativeUnit:
+		return obj.Status.ID, nil
+	case *entragov.EntraNamedLocation:
+		return obj.Status.ID, nil
+	}
+	return "", nil
+}
+
+// directoryRolePrincipalID returns the object ID of a principal of a directory role or PIM
+// eligibility, empty when the referenced resource is missing or not created in Entra yet.
+// Referenced groups must be assignable to roles when roleAssignable is set.
+func directoryRolePrincipalID(ctx context.Context, c client.Reader, namespace string, principal entragov.DirectoryRolePrincipal, roleAssignable bool) (string, error) {
+	if principal.Ref == "" {
+		return principal.Id, nil
+	}
+
+	obj := principalObject(principal.Type)
+	id, err := referencedID(ctx, c, namespace, principal.Ref, obj)
+	if err != nil {
+		return "", err
+	}
+	if group, ok := obj.(*entragov.EntraSecurityGroup); ok && roleAssignable && id != "" && !group.Spec.IsAssignableToRole {
+		return "", fmt.Errorf("EntraSecurityGroup %s is not assignable to roles, it must be created with isAssignableToRole", principal.Ref)
+	}
+	return id, nil
+}
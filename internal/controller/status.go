@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PatchStatus applies mutate to the status of obj and writes the changes with a merge patch.
+// A merge patch replaces lists such as the conditions and the member failures as a whole, so it
+// is guarded by the resource version and, like patchFinalizers, mutate is applied again to the
+// latest version of the resource when another writer changed it in the meantime. Nothing is
+// written when mutate leaves the status unchanged.
+func PatchStatus(ctx context.Context, c client.Client, obj client.Object, mutate func()) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		base := obj.DeepCopyObject().(client.Object)
+		mutate()
+
+		data, err := client.MergeFrom(base).Data(obj)
+		if err != nil {
+			return err
+		}
+		if string(data) == "{}" {
+			return nil
+		}
+		err = c.Status().Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if apierrors.IsConflict(err) {
+			if getErr := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+}
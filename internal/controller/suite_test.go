@@ -26,14 +26,22 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	iamv1alpha1 "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	entraclient "github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/fakegraph"
+	"github.com/vimal-vijayan/entra-governance/internal/services/groups"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -46,6 +54,13 @@ var testEnv *envtest.Environment
 var ctx context.Context
 var cancel context.CancelFunc
 
+// graphServer fakes Microsoft Graph for the reconcilers, which authenticate with the
+// credential secret named credentialSecretName in the default namespace.
+var graphServer *fakegraph.Server
+var clientFactory *entraclient.ClientFactory
+
+const credentialSecretName = "entra-credentials"
+
 func TestControllers(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -86,11 +101,116 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
+	By("starting the fake Microsoft Graph server")
+	graphServer = fakegraph.NewServer()
+	clientFactory = entraclient.NewClientFactory(k8sClient,
+		entraclient.WithBaseURL(graphServer.BaseURL()),
+		entraclient.WithCredential(graphServer.Credential()),
+	)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialSecretName,
+			Namespace: "default",
+		},
+		StringData: map[string]string{
+			"tenantId":     "00000000-0000-0000-0000-000000000000",
+			"clientId":     "00000000-0000-0000-0000-000000000000",
+			"clientSecret": "fake",
+		},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
 })
 
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
+	graphServer.Close()
 	cancel()
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// testProvider returns the provider spec authenticating with the credential secret of the suite.
+func testProvider() *iamv1alpha1.ProviderSpec {
+	return &iamv1alpha1.ProviderSpec{CredentialSecretRef: credentialSecretName}
+}
+
+// testResources tracks the resources created by a spec, so that they are deleted, and their
+// deletion reconciled, after it.
+type testResources struct {
+	reconciler reconcile.Reconciler
+	objects    []client.Object
+}
+
+// create creates obj in the default namespace and tracks it for cleanup.
+func (t *testResources) create(ctx context.Context, obj client.Object) types.NamespacedName {
+	obj.SetNamespace("default")
+	Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+	t.objects = append(t.objects, obj.DeepCopyObject().(client.Object))
+	return client.ObjectKeyFromObject(obj)
+}
+
+// cleanup deletes the tracked resources that still exist and reconciles their deletion until
+// they are gone.
+func (t *testResources) cleanup(ctx context.Context) {
+	for _, obj := range t.objects {
+		key := client.ObjectKeyFromObject(obj)
+		err := k8sClient.Get(ctx, key, obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		By(fmt.Sprintf("Cleanup the resource instance %s", key))
+		Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+		_, err = t.reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, key, obj))).To(BeTrue())
+	}
+	t.objects = nil
+}
+
+// reconcileResource reconciles the resource key with r and returns it, read into obj, as left
+// by the reconciliation.
+func reconcileResource[T client.Object](ctx context.Context, r reconcile.Reconciler, key types.NamespacedName, obj T) T {
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient.Get(ctx, key, obj)).To(Succeed())
+	return obj
+}
+
+// newGroupReconciler returns an EntraSecurityGroup reconciler using the fake Graph server, for
+// specs of other kinds that involve groups.
+func newGroupReconciler() *EntraSecurityGroupReconciler {
+	return &EntraSecurityGroupReconciler{
+		Client:       k8sClient,
+		Scheme:       k8sClient.Scheme(),
+		GroupService: groups.NewService(clientFactory),
+	}
+}
+
+// createTestGroup creates the security group name in the default namespace, named name in
+// Entra unless spec says otherwise, and deletes it at the end of the current spec.
+func createTestGroup(ctx context.Context, name string, spec iamv1alpha1.EntraSecurityGroupSpec) types.NamespacedName {
+	spec.ForProvider = testProvider()
+	spec.SecurityEnabled = true
+	if spec.Name == "" {
+		spec.Name = name
+	}
+	if spec.MailNickname == "" {
+		spec.MailNickname = name
+	}
+
+	resources := &testResources{reconciler: newGroupReconciler()}
+	key := resources.create(ctx, &iamv1alpha1.EntraSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	})
+	DeferCleanup(resources.cleanup, ctx)
+	return key
+}
+
+// reconcileTestGroup reconciles the security group key and returns it.
+func reconcileTestGroup(ctx context.Context, key types.NamespacedName) *iamv1alpha1.EntraSecurityGroup {
+	return reconcileResource(ctx, newGroupReconciler(), key, &iamv1alpha1.EntraSecurityGroup{})
+}
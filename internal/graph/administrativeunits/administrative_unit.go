@@ -0,0 +1,152 @@
+package administrativeunits
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	graphdirectory "github.com/microsoftgraph/msgraph-sdk-go/directory"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	entraUnit "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// unitProperties are the properties read from administrative units, the membership and the
+// restricted management flag are only returned when selected.
+var unitProperties = []string{"id", "displayName", "description", "membershipType", "membershipRule", "isMemberManagementRestricted"}
+
+// Create creates the administrative unit, tagged with uniqueName so that it can be found again
+// with GetByUniqueName when the creation is retried. Restricted management can only be set here.
+// api doc: https://learn.microsoft.com/en-us/graph/api/directory-post-administrativeunits?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, unitSpec entraUnit.EntraAdministrativeUnitSpec, uniqueName string) (*AdministrativeUnitResponse, error) {
+	unit := administrativeUnit(unitSpec)
+	if unitSpec.IsMemberManagementRestricted {
+		unit.SetIsMemberManagementRestricted(&unitSpec.IsMemberManagementRestricted)
+	}
+	if uniqueName != "" {
+		// the SDK model of administrative units has no uniqueName property
+		unit.SetAdditionalData(map[string]any{"uniqueName": uniqueName})
+	}
+
+	resp, err := s.sdk.Directory().AdministrativeUnits().Post(ctx, unit, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create administrative unit: %w", err)
+	}
+
+	return unitResponse(resp), nil
+}
+
+func (s *Service) Get(ctx context.Context, unitID string) (*AdministrativeUnitGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if unitID == "" {
+		return nil, fmt.Errorf("administrative unit id is empty")
+	}
+
+	resp, err := s.sdk.Directory().AdministrativeUnits().ByAdministrativeUnitId(unitID).Get(ctx, &graphdirectory.AdministrativeUnitsAdministrativeUnitItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphdirectory.AdministrativeUnitsAdministrativeUnitItemRequestBuilderGetQueryParameters{
+			Select: unitProperties,
+		},
+	})
+	if err != nil {
+		response := &AdministrativeUnitGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get administrative unit", "unitID", unitID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get administrative unit %w", err)
+	}
+
+	return &AdministrativeUnitGetResponse{AdministrativeUnitResponse: *unitResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// GetByUniqueName returns the administrative unit tagged with uniqueName, nil when there is none.
+// api doc: https://learn.microsoft.com/en-us/graph/api/directory-list-administrativeunits?view=graph-rest-1.0&tabs=http
+func (s *Service) GetByUniqueName(ctx context.Context, uniqueName string) (*AdministrativeUnitResponse, error) {
+	if uniqueName == "" {
+		return nil, nil
+	}
+	filter := fmt.Sprintf("uniqueName eq '%s'", strings.ReplaceAll(uniqueName, "'", "''"))
+	resp, err := s.sdk.Directory().AdministrativeUnits().Get(ctx, &graphdirectory.AdministrativeUnitsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphdirectory.AdministrativeUnitsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+			Select: unitProperties,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find administrative unit by unique name: %w", err)
+	}
+
+	for _, unit := range resp.GetValue() {
+		if unit.GetId() != nil {
+			return unitResponse(unit), nil
+		}
+	}
+	return nil, nil
+}
+
+// Update applies the display name, the description and the membership of the spec to the
+// administrative unit.
+// api doc: https://learn.microsoft.com/en-us/graph/api/administrativeunit-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, unitID string, unitSpec entraUnit.EntraAdministrativeUnitSpec) error {
+	unit := administrativeUnit(unitSpec)
+	if _, err := s.sdk.Directory().AdministrativeUnits().ByAdministrativeUnitId(unitID).Patch(ctx, unit, nil); err != nil {
+		return fmt.Errorf("failed to update administrative unit: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, unitID string) error {
+	if err := s.sdk.Directory().AdministrativeUnits().ByAdministrativeUnitId(unitID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete administrative unit by ID: %w", err)
+	}
+	return nil
+}
+
+// MembershipType returns the membership type of the spec, Assigned by default.
+func MembershipType(unitSpec entraUnit.EntraAdministrativeUnitSpec) string {
+	if strings.EqualFold(unitSpec.MembershipType, MembershipTypeDynamic) {
+		return MembershipTypeDynamic
+	}
+	return MembershipTypeAssigned
+}
+
+func administrativeUnit(unitSpec entraUnit.EntraAdministrativeUnitSpec) *models.AdministrativeUnit {
+	membershipType := MembershipType(unitSpec)
+
+	unit := models.NewAdministrativeUnit()
+	unit.SetDisplayName(&unitSpec.DisplayName)
+	unit.SetDescription(&unitSpec.Description)
+	unit.SetMembershipType(&membershipType)
+	if membershipType == MembershipTypeDynamic {
+		processingState := membershipRuleProcessingOn
+		unit.SetMembershipRule(&unitSpec.MembershipRule)
+		unit.SetMembershipRuleProcessingState(&processingState)
+	}
+	return unit
+}
+
+func unitResponse(unit models.AdministrativeUnitable) *AdministrativeUnitResponse {
+	response := &AdministrativeUnitResponse{MembershipType: MembershipTypeAssigned}
+	if unit.GetId() != nil {
+		response.ID = *unit.GetId()
+	}
+	if unit.GetDisplayName() != nil {
+		response.DisplayName = *unit.GetDisplayName()
+	}
+	if unit.GetDescription() != nil {
+		response.Description = *unit.GetDescription()
+	}
+	// Graph returns no membership type for assigned units
+	if unit.GetMembershipType() != nil && strings.EqualFold(*unit.GetMembershipType(), MembershipTypeDynamic) {
+		response.MembershipType = MembershipTypeDynamic
+	}
+	if unit.GetMembershipRule() != nil {
+		response.MembershipRule = *unit.GetMembershipRule()
+	}
+	if unit.GetIsMemberManagementRestricted() != nil {
+		response.IsMemberManagementRestricted = *unit.GetIsMemberManagementRestricted()
+	}
+	return response
+}
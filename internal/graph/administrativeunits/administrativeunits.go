@@ -0,0 +1,46 @@
+package administrativeunits
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	entraUnit "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+const (
+	MembershipTypeAssigned = "Assigned"
+	MembershipTypeDynamic  = "Dynamic"
+
+	// membershipRuleProcessingOn evaluates the membership rule of dynamic administrative units
+	membershipRuleProcessingOn = "On"
+)
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+type AdministrativeUnitResponse struct {
+	ID                           string `json:"id"`
+	DisplayName                  string `json:"displayName"`
+	Description                  string `json:"description"`
+	MembershipType               string `json:"membershipType"`
+	MembershipRule               string `json:"membershipRule"`
+	IsMemberManagementRestricted bool   `json:"isMemberManagementRestricted"`
+}
+
+type AdministrativeUnitGetResponse struct {
+	AdministrativeUnitResponse
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	Get(ctx context.Context, unitID string) (*AdministrativeUnitGetResponse, error)
+	Create(ctx context.Context, unitSpec entraUnit.EntraAdministrativeUnitSpec, uniqueName string) (*AdministrativeUnitResponse, error)
+	GetByUniqueName(ctx context.Context, uniqueName string) (*AdministrativeUnitResponse, error)
+	Update(ctx context.Context, unitID string, unitSpec entraUnit.EntraAdministrativeUnitSpec) error
+	Delete(ctx context.Context, unitID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
@@ -2,8 +2,11 @@ package appregistration
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	graphapplications "github.com/microsoftgraph/msgraph-sdk-go/applications"
 	graphmodels "github.com/microsoftgraph/msgraph-sdk-go/models"
 	appregistration "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -26,7 +29,8 @@ type AppRegistrationCreateRequest struct {
 
 type API interface {
 	Get(ctx context.Context, appID string) (*AppRegistrationGetResponse, error)
-	Create(ctx context.Context, app appregistration.EntraAppRegistrationSpec) (*AppRegistrationCreateRequest, error)
+	Create(ctx context.Context, app appregistration.EntraAppRegistrationSpec, uniqueName string) (*AppRegistrationCreateRequest, error)
+	GetByUniqueName(ctx context.Context, uniqueName string) (*AppRegistrationCreateRequest, error)
 	Delete(ctx context.Context, appID string) error
 }
 
@@ -34,10 +38,15 @@ func (s *Service) Get(ctx context.Context, appID string) (*AppRegistrationGetRes
 	return nil, nil
 }
 
-func (s *Service) Create(ctx context.Context, app appregistration.EntraAppRegistrationSpec) (*AppRegistrationCreateRequest, error) {
+// Create creates the application, tagged with uniqueName so that it can be found again with
+// GetByUniqueName when the creation is retried.
+func (s *Service) Create(ctx context.Context, app appregistration.EntraAppRegistrationSpec, uniqueName string) (*AppRegistrationCreateRequest, error) {
 	logger := log.FromContext(ctx)
 	entraApp := graphmodels.NewApplication()
 	entraApp.SetDisplayName(&app.Name)
+	if uniqueName != "" {
+		entraApp.SetUniqueName(&uniqueName)
+	}
 
 	client, err := s.sdk.Applications().Post(ctx, entraApp, nil)
 	if err != nil {
@@ -53,6 +62,35 @@ func (s *Service) Create(ctx context.Context, app appregistration.EntraAppRegist
 	}, nil
 }
 
+// GetByUniqueName returns the application tagged with uniqueName, nil when there is none.
+// api doc: https://learn.microsoft.com/en-us/graph/api/application-list?view=graph-rest-1.0&tabs=http
+func (s *Service) GetByUniqueName(ctx context.Context, uniqueName string) (*AppRegistrationCreateRequest, error) {
+	if uniqueName == "" {
+		return nil, nil
+	}
+	filter := fmt.Sprintf("uniqueName eq '%s'", strings.ReplaceAll(uniqueName, "'", "''"))
+	resp, err := s.sdk.Applications().Get(ctx, &graphapplications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphapplications.ApplicationsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+			Select: []string{"id", "appId"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find application by unique name: %w", err)
+	}
+
+	for _, app := range resp.GetValue() {
+		if app.GetId() == nil || app.GetAppId() == nil {
+			continue
+		}
+		return &AppRegistrationCreateRequest{
+			AppClientID: *app.GetId(),
+			AppObjectID: *app.GetAppId(),
+		}, nil
+	}
+	return nil, nil
+}
+
 func (s *Service) Delete(ctx context.Context, appID string) error {
 	logger := log.FromContext(ctx)
 	err := s.sdk.Applications().ByApplicationId(appID).Delete(ctx, nil)
@@ -0,0 +1,72 @@
+package conditionalaccess
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// StateReportOnly evaluates a policy at sign-in and logs the result without enforcing it.
+const StateReportOnly = "enabledForReportingButNotEnforced"
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+// Policy is a conditional access policy with the object IDs of the users, groups and roles it
+// targets resolved. Empty lists and unset controls are nil.
+type Policy struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	State       string `json:"state"`
+
+	IncludeUsers  []string `json:"includeUsers"`
+	ExcludeUsers  []string `json:"excludeUsers"`
+	IncludeGroups []string `json:"includeGroups"`
+	ExcludeGroups []string `json:"excludeGroups"`
+	IncludeRoles  []string `json:"includeRoles"`
+	ExcludeRoles  []string `json:"excludeRoles"`
+
+	IncludeApplications []string `json:"includeApplications"`
+	ExcludeApplications []string `json:"excludeApplications"`
+	IncludeUserActions  []string `json:"includeUserActions"`
+
+	ClientAppTypes   []string `json:"clientAppTypes"`
+	IncludePlatforms []string `json:"includePlatforms"`
+	ExcludePlatforms []string `json:"excludePlatforms"`
+	IncludeLocations []string `json:"includeLocations"`
+	ExcludeLocations []string `json:"excludeLocations"`
+	SignInRiskLevels []string `json:"signInRiskLevels"`
+	UserRiskLevels   []string `json:"userRiskLevels"`
+
+	// GrantOperator and BuiltInControls are empty without grant controls.
+	GrantOperator   string   `json:"grantOperator"`
+	BuiltInControls []string `json:"builtInControls"`
+
+	SignInFrequency                 *SignInFrequency `json:"signInFrequency"`
+	PersistentBrowser               string           `json:"persistentBrowser"`
+	ApplicationEnforcedRestrictions bool             `json:"applicationEnforcedRestrictions"`
+	DisableResilienceDefaults       bool             `json:"disableResilienceDefaults"`
+}
+
+// SignInFrequency is how often users have to sign in again.
+type SignInFrequency struct {
+	Value int32  `json:"value"`
+	Type  string `json:"type"`
+}
+
+type PolicyGetResponse struct {
+	Policy
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	Get(ctx context.Context, policyID string) (*PolicyGetResponse, error)
+	Create(ctx context.Context, policy Policy) (*Policy, error)
+	Update(ctx context.Context, policyID string, policy Policy) error
+	Delete(ctx context.Context, policyID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
@@ -0,0 +1,311 @@
+package conditionalaccess
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Create creates the conditional access policy.
+// api doc: https://learn.microsoft.com/en-us/graph/api/conditionalaccessroot-post-policies?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, policy Policy) (*Policy, error) {
+	body, err := policyModel(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.sdk.Identity().ConditionalAccess().Policies().Post(ctx, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conditional access policy: %w", err)
+	}
+	return policyResponse(resp), nil
+}
+
+func (s *Service) Get(ctx context.Context, policyID string) (*PolicyGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if policyID == "" {
+		return nil, fmt.Errorf("conditional access policy id is empty")
+	}
+
+	resp, err := s.sdk.Identity().ConditionalAccess().Policies().ByConditionalAccessPolicyId(policyID).Get(ctx, nil)
+	if err != nil {
+		response := &PolicyGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get conditional access policy", "policyID", policyID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get conditional access policy %w", err)
+	}
+
+	return &PolicyGetResponse{Policy: *policyResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// Update replaces the state, the conditions and the controls of the policy.
+// api doc: https://learn.microsoft.com/en-us/graph/api/conditionalaccesspolicy-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, policyID string, policy Policy) error {
+	body, err := policyModel(policy)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.sdk.Identity().ConditionalAccess().Policies().ByConditionalAccessPolicyId(policyID).Patch(ctx, body, nil); err != nil {
+		return fmt.Errorf("failed to update conditional access policy: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, policyID string) error {
+	if err := s.sdk.Identity().ConditionalAccess().Policies().ByConditionalAccessPolicyId(policyID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete conditional access policy by ID: %w", err)
+	}
+	return nil
+}
+
+func policyModel(policy Policy) (*models.ConditionalAccessPolicy, error) {
+	state, err := parseEnum[models.ConditionalAccessPolicyState](policy.State, models.ParseConditionalAccessPolicyState)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state: %w", err)
+	}
+	clientAppTypes, err := parseEnums[models.ConditionalAccessClientApp](policy.ClientAppTypes, models.ParseConditionalAccessClientApp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client app type: %w", err)
+	}
+	signInRiskLevels, err := parseEnums[models.RiskLevel](policy.SignInRiskLevels, models.ParseRiskLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sign-in risk level: %w", err)
+	}
+	userRiskLevels, err := parseEnums[models.RiskLevel](policy.UserRiskLevels, models.ParseRiskLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user risk level: %w", err)
+	}
+
+	users := models.NewConditionalAccessUsers()
+	users.SetIncludeUsers(nonNil(policy.IncludeUsers))
+	users.SetExcludeUsers(nonNil(policy.ExcludeUsers))
+	users.SetIncludeGroups(nonNil(policy.IncludeGroups))
+	users.SetExcludeGroups(nonNil(policy.ExcludeGroups))
+	users.SetIncludeRoles(nonNil(policy.IncludeRoles))
+	users.SetExcludeRoles(nonNil(policy.ExcludeRoles))
+
+	applications := models.NewConditionalAccessApplications()
+	applications.SetIncludeApplications(nonNil(policy.IncludeApplications))
+	applications.SetExcludeApplications(nonNil(policy.ExcludeApplications))
+	applications.SetIncludeUserActions(nonNil(policy.IncludeUserActions))
+
+	conditions := models.NewConditionalAccessConditionSet()
+	conditions.SetUsers(users)
+	conditions.SetApplications(applications)
+	conditions.SetClientAppTypes(clientAppTypes)
+	conditions.SetSignInRiskLevels(signInRiskLevels)
+	conditions.SetUserRiskLevels(userRiskLevels)
+	// conditions and controls that are not set are sent as null, for updates to remove them
+	removedConditions := map[string]any{}
+	if len(policy.IncludePlatforms) > 0 {
+		include, err := parseEnums[models.ConditionalAccessDevicePlatform](policy.IncludePlatforms, models.ParseConditionalAccessDevicePlatform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform: %w", err)
+		}
+		exclude, err := parseEnums[models.ConditionalAccessDevicePlatform](policy.ExcludePlatforms, models.ParseConditionalAccessDevicePlatform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform: %w", err)
+		}
+		platforms := models.NewConditionalAccessPlatforms()
+		platforms.SetIncludePlatforms(include)
+		platforms.SetExcludePlatforms(exclude)
+		conditions.SetPlatforms(platforms)
+	} else {
+		removedConditions["platforms"] = nil
+	}
+	if len(policy.IncludeLocations) > 0 {
+		locations := models.NewConditionalAccessLocations()
+		locations.SetIncludeLocations(policy.IncludeLocations)
+		locations.SetExcludeLocations(nonNil(policy.ExcludeLocations))
+		conditions.SetLocations(locations)
+	} else {
+		removedConditions["locations"] = nil
+	}
+	conditions.SetAdditionalData(removedConditions)
+
+	body := models.NewConditionalAccessPolicy()
+	body.SetDisplayName(&policy.DisplayName)
+	body.SetState(state)
+	body.SetConditions(conditions)
+	removedControls := map[string]any{}
+
+	if len(policy.BuiltInControls) > 0 {
+		builtInControls, err := parseEnums[models.ConditionalAccessGrantControl](policy.BuiltInControls, models.ParseConditionalAccessGrantControl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grant control: %w", err)
+		}
+		grantControls := models.NewConditionalAccessGrantControls()
+		grantControls.SetOperator(&policy.GrantOperator)
+		grantControls.SetBuiltInControls(builtInControls)
+		body.SetGrantControls(grantControls)
+	} else {
+		removedControls["grantControls"] = nil
+	}
+
+	sessionControls, err := sessionControlsModel(policy)
+	if err != nil {
+		return nil, err
+	}
+	if sessionControls != nil {
+		body.SetSessionControls(sessionControls)
+	} else {
+		removedControls["sessionControls"] = nil
+	}
+	body.SetAdditionalData(removedControls)
+	return body, nil
+}
+
+// sessionControlsModel returns the session controls of the policy, nil when it has none.
+func sessionControlsModel(policy Policy) (*models.ConditionalAccessSessionControls, error) {
+	if policy.SignInFrequency == nil && policy.PersistentBrowser == "" && !policy.ApplicationEnforcedRestrictions && !policy.DisableResilienceDefaults {
+		return nil, nil
+	}
+
+	enabled := true
+	controls := models.NewConditionalAccessSessionControls()
+	if policy.SignInFrequency != nil {
+		frequencyType, err := parseEnum[models.SigninFrequencyType](policy.SignInFrequency.Type, models.ParseSigninFrequencyType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sign-in frequency type: %w", err)
+		}
+		interval := models.TIMEBASED_SIGNINFREQUENCYINTERVAL
+		frequency := models.NewSignInFrequencySessionControl()
+		frequency.SetIsEnabled(&enabled)
+		frequency.SetValue(&policy.SignInFrequency.Value)
+		frequency.SetTypeEscaped(frequencyType)
+		frequency.SetFrequencyInterval(&interval)
+		controls.SetSignInFrequency(frequency)
+	}
+	if policy.PersistentBrowser != "" {
+		mode, err := parseEnum[models.PersistentBrowserSessionMode](policy.PersistentBrowser, models.ParsePersistentBrowserSessionMode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid persistent browser mode: %w", err)
+		}
+		browser := models.NewPersistentBrowserSessionControl()
+		browser.SetIsEnabled(&enabled)
+		browser.SetMode(mode)
+		controls.SetPersistentBrowser(browser)
+	}
+	if policy.ApplicationEnforcedRestrictions {
+		restrictions := models.NewApplicationEnforcedRestrictionsSessionControl()
+		restrictions.SetIsEnabled(&enabled)
+		controls.SetApplicationEnforcedRestrictions(restrictions)
+	}
+	if policy.DisableResilienceDefaults {
+		controls.SetDisableResilienceDefaults(&policy.DisableResilienceDefaults)
+	}
+	return controls, nil
+}
+
+func policyResponse(policy models.ConditionalAccessPolicyable) *Policy {
+	response := &Policy{}
+	if policy.GetId() != nil {
+		response.ID = *policy.GetId()
+	}
+	if policy.GetDisplayName() != nil {
+		response.DisplayName = *policy.GetDisplayName()
+	}
+	if policy.GetState() != nil {
+		response.State = policy.GetState().String()
+	}
+
+	if conditions := policy.GetConditions(); conditions != nil {
+		if users := conditions.GetUsers(); users != nil {
+			response.IncludeUsers = nilIfEmpty(users.GetIncludeUsers())
+			response.ExcludeUsers = nilIfEmpty(users.GetExcludeUsers())
+			response.IncludeGroups = nilIfEmpty(users.GetIncludeGroups())
+			response.ExcludeGroups = nilIfEmpty(users.GetExcludeGroups())
+			response.IncludeRoles = nilIfEmpty(users.GetIncludeRoles())
+			response.ExcludeRoles = nilIfEmpty(users.GetExcludeRoles())
+		}
+		if applications := conditions.GetApplications(); applications != nil {
+			response.IncludeApplications = nilIfEmpty(applications.GetIncludeApplications())
+			response.ExcludeApplications = nilIfEmpty(applications.GetExcludeApplications())
+			response.IncludeUserActions = nilIfEmpty(applications.GetIncludeUserActions())
+		}
+		response.ClientAppTypes = nilIfEmpty(models.SerializeConditionalAccessClientApp(conditions.GetClientAppTypes()))
+		response.SignInRiskLevels = nilIfEmpty(models.SerializeRiskLevel(conditions.GetSignInRiskLevels()))
+		response.UserRiskLevels = nilIfEmpty(models.SerializeRiskLevel(conditions.GetUserRiskLevels()))
+		if platforms := conditions.GetPlatforms(); platforms != nil {
+			response.IncludePlatforms = nilIfEmpty(models.SerializeConditionalAccessDevicePlatform(platforms.GetIncludePlatforms()))
+			response.ExcludePlatforms = nilIfEmpty(models.SerializeConditionalAccessDevicePlatform(platforms.GetExcludePlatforms()))
+		}
+		if locations := conditions.GetLocations(); locations != nil {
+			response.IncludeLocations = nilIfEmpty(locations.GetIncludeLocations())
+			response.ExcludeLocations = nilIfEmpty(locations.GetExcludeLocations())
+		}
+	}
+
+	if grantControls := policy.GetGrantControls(); grantControls != nil {
+		response.BuiltInControls = nilIfEmpty(models.SerializeConditionalAccessGrantControl(grantControls.GetBuiltInControls()))
+		if response.BuiltInControls != nil && grantControls.GetOperator() != nil {
+			response.GrantOperator = *grantControls.GetOperator()
+		}
+	}
+
+	if sessionControls := policy.GetSessionControls(); sessionControls != nil {
+		if frequency := sessionControls.GetSignInFrequency(); frequency != nil && isEnabled(frequency.GetIsEnabled()) &&
+			frequency.GetValue() != nil && frequency.GetTypeEscaped() != nil {
+			response.SignInFrequency = &SignInFrequency{Value: *frequency.GetValue(), Type: frequency.GetTypeEscaped().String()}
+		}
+		if browser := sessionControls.GetPersistentBrowser(); browser != nil && isEnabled(browser.GetIsEnabled()) && browser.GetMode() != nil {
+			response.PersistentBrowser = browser.GetMode().String()
+		}
+		if restrictions := sessionControls.GetApplicationEnforcedRestrictions(); restrictions != nil {
+			response.ApplicationEnforcedRestrictions = isEnabled(restrictions.GetIsEnabled())
+		}
+		response.DisableResilienceDefaults = isEnabled(sessionControls.GetDisableResilienceDefaults())
+	}
+	return response
+}
+
+// parseEnum parses value with the parse function of a Graph enumeration.
+func parseEnum[T any](value string, parse func(string) (any, error)) (*T, error) {
+	parsed, err := parse(value)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := parsed.(*T)
+	if !ok || result == nil {
+		return nil, fmt.Errorf("unsupported value %q", value)
+	}
+	return result, nil
+}
+
+func parseEnums[T any](values []string, parse func(string) (any, error)) ([]T, error) {
+	result := make([]T, 0, len(values))
+	for _, value := range values {
+		parsed, err := parseEnum[T](value, parse)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *parsed)
+	}
+	return result, nil
+}
+
+// nonNil returns values or an empty list, Graph expects every list of the conditions.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nilIfEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func isEnabled(value *bool) bool {
+	return value != nil && *value
+}
@@ -0,0 +1,123 @@
+package fakegraph
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AdministrativeUnitMembers returns the IDs of the members of an administrative unit.
+func (s *Server) AdministrativeUnitMembers(unitID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.unitMembers[unitID]...)
+}
+
+// routeDirectory serves /directory/administrativeUnits and the groups created as their members.
+func (s *Server) routeDirectory(w http.ResponseWriter, r *http.Request, segments []string) {
+	const collection = "administrativeUnits"
+	if len(segments) == 0 || segments[0] != collection {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.listObjects(w, r, collection)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.createObject(w, r, collection)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.getObject(w, collection, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		s.updateAdministrativeUnit(w, r, segments[1])
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		s.deleteObject(w, collection, segments[1])
+	case len(segments) == 3 && segments[2] == "members" && r.Method == http.MethodGet:
+		if _, ok := s.objects[collection][segments[1]]; !ok {
+			writeNotFound(w, segments[1])
+			return
+		}
+		objects := make([]map[string]any, 0, len(s.unitMembers[segments[1]]))
+		for _, id := range s.unitMembers[segments[1]] {
+			if object, ok := s.directoryObject(id); ok {
+				objects = append(objects, copyObject(object))
+			}
+		}
+		s.writePage(w, r, objects)
+	case len(segments) == 3 && segments[2] == "members" && r.Method == http.MethodPost:
+		s.createUnitMember(w, r, segments[1])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+// validateAdministrativeUnit rejects dynamic administrative units without a membership rule.
+func validateAdministrativeUnit(w http.ResponseWriter, unit map[string]any) bool {
+	membershipType, _ := unit["membershipType"].(string)
+	rule, _ := unit["membershipRule"].(string)
+	if strings.EqualFold(membershipType, "Dynamic") && rule == "" {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			"Invalid value specified for property 'membershipRule' of resource 'AdministrativeUnit'.")
+		return false
+	}
+	return true
+}
+
+// updateAdministrativeUnit updates an administrative unit like Graph does: restricted management
+// can only be set when the unit is created.
+func (s *Server) updateAdministrativeUnit(w http.ResponseWriter, r *http.Request, id string) {
+	unit, ok := s.objects["administrativeUnits"][id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	update, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	if restricted, ok := update["isMemberManagementRestricted"]; ok && restricted != unit["isMemberManagementRestricted"] {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			"Property 'isMemberManagementRestricted' is read-only and cannot be set.")
+		return
+	}
+	merged := copyObject(unit)
+	for key, value := range update {
+		merged[key] = value
+	}
+	if !validateAdministrativeUnit(w, merged) {
+		return
+	}
+
+	for key, value := range update {
+		if key == "id" || key == "@odata.type" {
+			continue
+		}
+		unit[key] = value
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createUnitMember creates a group as member of an administrative unit. Members of dynamic
+// administrative units are selected by their rule and cannot be added.
+func (s *Server) createUnitMember(w http.ResponseWriter, r *http.Request, unitID string) {
+	unit, ok := s.objects["administrativeUnits"][unitID]
+	if !ok {
+		writeNotFound(w, unitID)
+		return
+	}
+	object, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	if object["@odata.type"] != odataTypes["groups"] {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "Only groups can be created in an administrative unit.")
+		return
+	}
+	if membershipType, _ := unit["membershipType"].(string); strings.EqualFold(membershipType, "Dynamic") {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "Members cannot be added to an administrative unit with dynamic membership.")
+		return
+	}
+
+	if id := s.insertObject(w, "groups", object); id != "" {
+		s.unitMembers[unitID] = append(s.unitMembers[unitID], id)
+	}
+}
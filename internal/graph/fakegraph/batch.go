@@ -0,0 +1,77 @@
+package fakegraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+)
+
+// maxBatchSteps is the Graph limit of requests in a single $batch request.
+const maxBatchSteps = 20
+
+type batchRequest struct {
+	Requests []batchStep `json:"requests"`
+}
+
+type batchStep struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type batchStepResponse struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// serveBatch runs the steps of a JSON $batch request in order. Faults apply to the steps,
+// not to the $batch request itself, the same way Graph reports per step failures.
+// api doc: https://learn.microsoft.com/en-us/graph/json-batching
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request) {
+	batch := batchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unable to read JSON request payload.")
+		return
+	}
+	if len(batch.Requests) > maxBatchSteps {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("The number of requests in a batch cannot exceed %d.", maxBatchSteps))
+		return
+	}
+
+	responses := make([]batchStepResponse, 0, len(batch.Requests))
+	for _, step := range batch.Requests {
+		var body *bytes.Reader
+		if len(step.Body) > 0 {
+			body = bytes.NewReader(step.Body)
+		} else {
+			body = bytes.NewReader(nil)
+		}
+
+		req, err := http.NewRequestWithContext(r.Context(), step.Method, s.srv.URL+apiVersion+step.URL, body)
+		if err != nil {
+			responses = append(responses, batchStepResponse{ID: step.ID, Status: http.StatusBadRequest})
+			continue
+		}
+		for key, value := range step.Headers {
+			req.Header.Set(key, value)
+		}
+
+		recorder := httptest.NewRecorder()
+		s.serve(recorder, req)
+
+		response := batchStepResponse{ID: step.ID, Status: recorder.Code}
+		if recorder.Body.Len() > 0 {
+			response.Headers = map[string]string{"Content-Type": recorder.Header().Get("Content-Type")}
+			response.Body = recorder.Body.Bytes()
+		}
+		responses = append(responses, response)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"responses": responses})
+}
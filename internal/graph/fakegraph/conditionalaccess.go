@@ -0,0 +1,146 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// user and group values of conditional access policies that are not object IDs
+var conditionalAccessKeywords = []string{"All", "None", "GuestsOrExternalUsers"}
+
+// location values of conditional access policies that are not named location IDs
+var locationKeywords = []string{"All", "AllTrusted"}
+
+// routeIdentity serves /identity/conditionalAccess/policies and namedLocations.
+func (s *Server) routeIdentity(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) < 3 || segments[1] != "conditionalAccess" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+		return
+	}
+	if segments[2] == "namedLocations" {
+		s.routeNamedLocations(w, r, segments)
+		return
+	}
+	const collection = "conditionalAccessPolicies"
+	if segments[2] != "policies" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+		return
+	}
+
+	switch {
+	case len(segments) == 3 && r.Method == http.MethodGet:
+		s.listObjects(w, r, collection)
+	case len(segments) == 3 && r.Method == http.MethodPost:
+		s.createObject(w, r, collection)
+	case len(segments) == 4 && r.Method == http.MethodGet:
+		s.getObject(w, collection, segments[3])
+	case len(segments) == 4 && r.Method == http.MethodPatch:
+		s.updateConditionalAccessPolicy(w, r, segments[3])
+	case len(segments) == 4 && r.Method == http.MethodDelete:
+		s.deleteObject(w, collection, segments[3])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+// validateConditionalAccessPolicy rejects policies Graph rejects: without users, applications,
+// client app types or controls, blocking along with other grant controls or targeting users,
+// groups and named locations that do not exist.
+func (s *Server) validateConditionalAccessPolicy(w http.ResponseWriter, policy map[string]any) bool {
+	switch policy["state"] {
+	case "enabled", "disabled", "enabledForReportingButNotEnforced":
+	default:
+		return writeInvalidPolicy(w, "state")
+	}
+
+	conditions, _ := policy["conditions"].(map[string]any)
+	users, _ := conditions["users"].(map[string]any)
+	if len(stringList(users["includeUsers"])) == 0 && len(stringList(users["includeGroups"])) == 0 && len(stringList(users["includeRoles"])) == 0 {
+		return writeInvalidPolicy(w, "conditions.users")
+	}
+	for _, property := range []string{"includeUsers", "excludeUsers"} {
+		for _, id := range stringList(users[property]) {
+			if _, ok := s.objects["users"][id]; !ok && !contains(conditionalAccessKeywords, id) {
+				return writeInvalidPolicy(w, "conditions.users."+property)
+			}
+		}
+	}
+	for _, property := range []string{"includeGroups", "excludeGroups"} {
+		for _, id := range stringList(users[property]) {
+			if _, ok := s.objects["groups"][id]; !ok {
+				return writeInvalidPolicy(w, "conditions.users."+property)
+			}
+		}
+	}
+
+	applications, _ := conditions["applications"].(map[string]any)
+	if len(stringList(applications["includeApplications"])) == 0 && len(stringList(applications["includeUserActions"])) == 0 {
+		return writeInvalidPolicy(w, "conditions.applications")
+	}
+	locations, _ := conditions["locations"].(map[string]any)
+	for _, property := range []string{"includeLocations", "excludeLocations"} {
+		for _, id := range stringList(locations[property]) {
+			if _, ok := s.objects["namedLocations"][id]; !ok && !contains(locationKeywords, id) {
+				return writeInvalidPolicy(w, "conditions.locations."+property)
+			}
+		}
+	}
+	if len(stringList(conditions["clientAppTypes"])) == 0 {
+		return writeInvalidPolicy(w, "conditions.clientAppTypes")
+	}
+
+	grantControls, _ := policy["grantControls"].(map[string]any)
+	if grantControls == nil && policy["sessionControls"] == nil {
+		return writeInvalidPolicy(w, "grantControls")
+	}
+	if controls := stringList(grantControls["builtInControls"]); contains(controls, "block") && len(controls) > 1 {
+		return writeInvalidPolicy(w, "grantControls.builtInControls")
+	}
+	return true
+}
+
+// updateConditionalAccessPolicy updates a policy when the result is still a valid policy.
+func (s *Server) updateConditionalAccessPolicy(w http.ResponseWriter, r *http.Request, id string) {
+	policy, ok := s.objects["conditionalAccessPolicies"][id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	update, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	merged := copyObject(policy)
+	for key, value := range update {
+		merged[key] = value
+	}
+	if !s.validateConditionalAccessPolicy(w, merged) {
+		return
+	}
+
+	for key, value := range update {
+		if key == "id" || key == "@odata.type" {
+			continue
+		}
+		policy[key] = value
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeInvalidPolicy(w http.ResponseWriter, property string) bool {
+	writeError(w, http.StatusBadRequest, "BadRequest",
+		fmt.Sprintf("Invalid value specified for property '%s' of resource 'ConditionalAccessPolicy'.", property))
+	return false
+}
+
+// stringList returns the strings of a decoded JSON array.
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,640 @@
+package fakegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// odata types of the directory objects served, by collection
+var odataTypes = map[string]string{
+	"users":                     "#microsoft.graph.user",
+	"groups":                    "#microsoft.graph.group",
+	"applications":              "#microsoft.graph.application",
+	"servicePrincipals":         "#microsoft.graph.servicePrincipal",
+	"oauth2PermissionGrants":    "#microsoft.graph.oAuth2PermissionGrant",
+	"administrativeUnits":       "#microsoft.graph.administrativeUnit",
+	"conditionalAccessPolicies": "#microsoft.graph.conditionalAccessPolicy",
+}
+
+// collections served below another segment than their name, e.g. /directory/administrativeUnits
+var nestedCollections = map[string]bool{"administrativeUnits": true, "conditionalAccessPolicies": true, "namedLocations": true}
+
+// collections that can be referenced as group members and owners
+var directoryCollections = []string{"users", "groups", "servicePrincipals"}
+
+// AddObject stores object in collection, e.g. users, bypassing validation, and returns its ID.
+// An ID is generated when the object has none.
+func (s *Server) AddObject(collection string, object map[string]any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.store(collection, object)
+}
+
+// AddUser stores a user and returns its ID.
+func (s *Server) AddUser(displayName string) string {
+	return s.AddObject("users", map[string]any{"displayName": displayName})
+}
+
+// AddServicePrincipal stores a service principal for a new application ID and returns its ID.
+func (s *Server) AddServicePrincipal(displayName string) string {
+	return s.AddObject("servicePrincipals", map[string]any{"displayName": displayName, "appId": newID()})
+}
+
+// AddExternalApplication registers a multi-tenant application living in another tenant and
+// returns its application ID. Service principals can be created for it.
+func (s *Server) AddExternalApplication(displayName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	appID := newID()
+	s.externalApps[appID] = displayName
+	return appID
+}
+
+// Object returns a copy of the object with the given ID in collection.
+func (s *Server) Object(collection, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[collection][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+// Objects returns copies of all objects in collection, ordered by ID.
+func (s *Server) Objects(collection string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedObjects(collection)
+}
+
+// DeleteObject deletes an object as if it was removed outside of the controller.
+func (s *Server) DeleteObject(collection, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(collection, id)
+}
+
+// Members returns the IDs of the direct members of a group.
+func (s *Server) Members(groupID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.members[groupID]...)
+}
+
+// Owners returns the IDs of the owners of a group or service principal.
+func (s *Server) Owners(objectID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.owners[objectID]...)
+}
+
+// AddMember adds a member to a group as if it was added outside of the controller.
+func (s *Server) AddMember(groupID, memberID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !contains(s.members[groupID], memberID) {
+		s.members[groupID] = append(s.members[groupID], memberID)
+		s.touch(groupID)
+	}
+}
+
+// RemoveMember removes a member from a group as if it was removed outside of the controller.
+func (s *Server) RemoveMember(groupID, memberID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if contains(s.members[groupID], memberID) {
+		s.members[groupID] = without(s.members[groupID], memberID)
+		s.touch(groupID)
+	}
+}
+
+func (s *Server) store(collection string, object map[string]any) string {
+	id, _ := object["id"].(string)
+	if id == "" {
+		id = newID()
+	}
+	object["id"] = id
+	// named locations keep the type they were created with, ip or country
+	if odataType, ok := odataTypes[collection]; ok {
+		object["@odata.type"] = odataType
+	}
+	s.objects[collection][id] = object
+	if collection == "groups" {
+		s.touch(id)
+	}
+	return id
+}
+
+func (s *Server) remove(collection, id string) bool {
+	if _, ok := s.objects[collection][id]; !ok {
+		return false
+	}
+	delete(s.objects[collection], id)
+
+	delete(s.owners, id)
+	// app role assignments of deleted principals and resources are revoked
+	for assignmentID, assignment := range s.appRoleAssignments {
+		if assignment["principalId"] == id || assignment["resourceId"] == id {
+			delete(s.appRoleAssignments, assignmentID)
+		}
+	}
+	// so are the delegated permission grants of deleted clients and resources
+	for grantID, grant := range s.objects["oauth2PermissionGrants"] {
+		if grant["clientId"] == id || grant["resourceId"] == id {
+			delete(s.objects["oauth2PermissionGrants"], grantID)
+		}
+	}
+	// and the directory roles and eligibilities of deleted principals
+	for assignmentID, assignment := range s.roleAssignments {
+		if assignment["principalId"] == id {
+			delete(s.roleAssignments, assignmentID)
+		}
+	}
+	s.removeEligibilities(id)
+	// deleted objects leave their administrative units, deleted units lose their members
+	delete(s.unitMembers, id)
+	for unitID, members := range s.unitMembers {
+		if contains(members, id) {
+			s.unitMembers[unitID] = without(members, id)
+		}
+	}
+	if collection == "groups" {
+		delete(s.members, id)
+		s.touch(id)
+	}
+	// deleted directory objects disappear from every group they were part of
+	for groupID, members := range s.members {
+		if contains(members, id) {
+			s.members[groupID] = without(members, id)
+			s.touch(groupID)
+		}
+	}
+	for ownedID, owners := range s.owners {
+		if contains(owners, id) {
+			s.owners[ownedID] = without(owners, id)
+			if _, ok := s.objects["groups"][ownedID]; ok {
+				s.touch(ownedID)
+			}
+		}
+	}
+	return true
+}
+
+// touch records a change of the group for delta queries.
+func (s *Server) touch(groupID string) {
+	s.version++
+	s.changes[groupID] = s.version
+}
+
+// touchGroup records a change of the object for delta queries when it is a group.
+func (s *Server) touchGroup(collection, id string) {
+	if collection == "groups" {
+		s.touch(id)
+	}
+}
+
+func (s *Server) sortedObjects(collection string) []map[string]any {
+	ids := make([]string, 0, len(s.objects[collection]))
+	for id := range s.objects[collection] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	objects := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		objects = append(objects, copyObject(s.objects[collection][id]))
+	}
+	return objects
+}
+
+// directoryObject finds a user, group or service principal by ID.
+func (s *Server) directoryObject(id string) (map[string]any, bool) {
+	for _, collection := range directoryCollections {
+		if object, ok := s.objects[collection][id]; ok {
+			return object, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, collection string) {
+	objects := s.sortedObjects(collection)
+
+	if filter := r.URL.Query().Get("$filter"); filter != "" {
+		property, value, ok := parseEqFilter(filter)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", "Unsupported query.")
+			return
+		}
+		var filtered []map[string]any
+		for _, object := range objects {
+			if fmt.Sprint(object[property]) == value {
+				filtered = append(filtered, object)
+			}
+		}
+		objects = filtered
+	}
+
+	s.writePage(w, r, objects)
+}
+
+func (s *Server) createObject(w http.ResponseWriter, r *http.Request, collection string) {
+	object, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	s.insertObject(w, collection, object)
+}
+
+// insertObject validates and stores a new object of collection and writes it to the response.
+// It returns the ID of the object, empty when it was rejected.
+func (s *Server) insertObject(w http.ResponseWriter, collection string, object map[string]any) string {
+	delete(object, "id")
+
+	if displayName, _ := object["displayName"].(string); displayName == "" && collection != "servicePrincipals" && collection != "oauth2PermissionGrants" {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			fmt.Sprintf("Invalid value specified for property 'displayName' of resource '%s'.", strings.TrimSuffix(collection, "s")))
+		return ""
+	}
+
+	// uniqueName is an alternate key of groups, applications and administrative units
+	if uniqueName, _ := object["uniqueName"].(string); uniqueName != "" {
+		for _, existing := range s.objects[collection] {
+			if existing["uniqueName"] == uniqueName {
+				writeError(w, http.StatusBadRequest, "Request_MultipleObjectsWithSameKeyValue",
+					"Another object with the same value for property uniqueName already exists.")
+				return ""
+			}
+		}
+	}
+
+	var memberIDs, ownerIDs []string
+	var password string
+	var ok bool
+	switch collection {
+	case "groups":
+		if nickname, _ := object["mailNickname"].(string); nickname == "" {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'mailNickname' of resource 'Group'.")
+			return ""
+		}
+		if memberIDs, ok = s.bindIDs(w, object, "members@odata.bind"); !ok {
+			return ""
+		}
+		if ownerIDs, ok = s.bindIDs(w, object, "owners@odata.bind"); !ok {
+			return ""
+		}
+	case "users":
+		if password, ok = s.validateUser(w, object); !ok {
+			return ""
+		}
+	case "applications":
+		object["appId"] = newID()
+	case "servicePrincipals":
+		appID, _ := object["appId"].(string)
+		if appID == "" {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'appId' of resource 'ServicePrincipal'.")
+			return ""
+		}
+		if !s.validateServicePrincipal(w, object, appID) {
+			return ""
+		}
+	case "oauth2PermissionGrants":
+		if !s.validateOAuth2PermissionGrant(w, object) {
+			return ""
+		}
+	case "administrativeUnits":
+		if !validateAdministrativeUnit(w, object) {
+			return ""
+		}
+	case "conditionalAccessPolicies":
+		if !s.validateConditionalAccessPolicy(w, object) {
+			return ""
+		}
+	case "namedLocations":
+		if !validateNamedLocation(w, object) {
+			return ""
+		}
+	}
+
+	id := s.store(collection, object)
+	if password != "" {
+		s.passwords[id] = password
+	}
+	if len(memberIDs) > 0 {
+		s.members[id] = memberIDs
+	}
+	if len(ownerIDs) > 0 {
+		s.owners[id] = ownerIDs
+	}
+	writeJSON(w, http.StatusCreated, copyObject(object))
+	return id
+}
+
+func (s *Server) getObject(w http.ResponseWriter, collection, id string) {
+	object, ok := s.objects[collection][id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, copyObject(object))
+}
+
+func (s *Server) updateObject(w http.ResponseWriter, r *http.Request, collection, id string) {
+	object, ok := s.objects[collection][id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	update, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	if collection == "groups" {
+		memberIDs, ok := s.bindIDs(w, update, "members@odata.bind")
+		if !ok {
+			return
+		}
+		// the whole request fails when any of the references is already a member
+		for _, memberID := range memberIDs {
+			if contains(s.members[id], memberID) {
+				writeAlreadyExists(w, "members")
+				return
+			}
+		}
+		if len(memberIDs) > 0 {
+			s.members[id] = append(s.members[id], memberIDs...)
+		}
+		s.touch(id)
+	}
+
+	for key, value := range update {
+		if key == "id" || key == "@odata.type" {
+			continue
+		}
+		object[key] = value
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteObject(w http.ResponseWriter, collection, id string) {
+	if !s.remove(collection, id) {
+		writeNotFound(w, id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeRelation serves the members of groups and the owners of groups and service principals.
+func (s *Server) routeRelation(w http.ResponseWriter, r *http.Request, collection, objectID string, segments []string) {
+	if _, ok := s.objects[collection][objectID]; !ok {
+		writeNotFound(w, objectID)
+		return
+	}
+
+	relation := segments[0]
+	var refs map[string][]string
+	switch {
+	case collection == "groups" && (relation == "members" || relation == "transitiveMembers"):
+		refs = s.members
+	case relation == "owners":
+		refs = s.owners
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Resource not found for the segment '%s'.", relation))
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		ids := refs[objectID]
+		if relation == "transitiveMembers" {
+			ids = s.transitiveMembers(objectID)
+		}
+		objects := make([]map[string]any, 0, len(ids))
+		for _, id := range ids {
+			if object, ok := s.directoryObject(id); ok {
+				objects = append(objects, copyObject(object))
+			}
+		}
+		s.writePage(w, r, objects)
+	case relation != "transitiveMembers" && len(segments) == 2 && segments[1] == "$ref" && r.Method == http.MethodPost:
+		body, ok := decodeObject(w, r)
+		if !ok {
+			return
+		}
+		odataID, _ := body["@odata.id"].(string)
+		id := refID(odataID)
+		if _, ok := s.directoryObject(id); !ok {
+			writeNotFound(w, id)
+			return
+		}
+		if contains(refs[objectID], id) {
+			writeAlreadyExists(w, relation)
+			return
+		}
+		refs[objectID] = append(refs[objectID], id)
+		s.touchGroup(collection, objectID)
+		w.WriteHeader(http.StatusNoContent)
+	case relation != "transitiveMembers" && len(segments) == 3 && segments[2] == "$ref" && r.Method == http.MethodDelete:
+		id := segments[1]
+		if !contains(refs[objectID], id) {
+			writeNotFound(w, id)
+			return
+		}
+		refs[objectID] = without(refs[objectID], id)
+		s.touchGroup(collection, objectID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+// transitiveMembers walks nested groups and returns every member once.
+func (s *Server) transitiveMembers(groupID string) []string {
+	var result []string
+	seen := map[string]bool{groupID: true}
+	queue := []string{groupID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, id := range s.members[current] {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			result = append(result, id)
+			if _, ok := s.objects["groups"][id]; ok {
+				queue = append(queue, id)
+			}
+		}
+	}
+	return result
+}
+
+// groupDelta serves /groups/delta. Delta tokens are the change version the previous round
+// ended at, or latest; deleted groups are returned with @removed.
+func (s *Server) groupDelta(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w)
+		return
+	}
+
+	query := r.URL.Query()
+	// the latest token starts change tracking without returning the current groups
+	if query.Get("$deltatoken") == "latest" {
+		next := url.Values{}
+		next.Set("$deltatoken", strconv.Itoa(s.version))
+		writeJSON(w, http.StatusOK, map[string]any{
+			"value":            []map[string]any{},
+			"@odata.deltaLink": s.srv.URL + r.URL.Path + "?" + next.Encode(),
+		})
+		return
+	}
+	since := 0
+	if token := query.Get("$deltatoken"); token != "" {
+		var err error
+		if since, err = strconv.Atoi(token); err != nil {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid delta token.")
+			return
+		}
+	}
+
+	var changed []map[string]any
+	for _, group := range s.sortedObjects("groups") {
+		if since == 0 || s.changes[group["id"].(string)] > since {
+			changed = append(changed, group)
+		}
+	}
+	if since > 0 {
+		var removed []string
+		for id, version := range s.changes {
+			if _, ok := s.objects["groups"][id]; !ok && version > since {
+				removed = append(removed, id)
+			}
+		}
+		sort.Strings(removed)
+		for _, id := range removed {
+			changed = append(changed, map[string]any{"id": id, "@removed": map[string]any{"reason": "changed"}})
+		}
+	}
+
+	offset, _ := strconv.Atoi(query.Get("$skiptoken"))
+	end := min(offset+s.pageSize(r), len(changed))
+	page := map[string]any{"value": changed[min(offset, end):end]}
+
+	next := url.Values{}
+	if end < len(changed) {
+		next.Set("$deltatoken", strconv.Itoa(since))
+		next.Set("$skiptoken", strconv.Itoa(end))
+		page["@odata.nextLink"] = s.srv.URL + r.URL.Path + "?" + next.Encode()
+	} else {
+		next.Set("$deltatoken", strconv.Itoa(s.version))
+		page["@odata.deltaLink"] = s.srv.URL + r.URL.Path + "?" + next.Encode()
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// writePage writes a collection response honouring $top and $skiptoken.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, objects []map[string]any) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("$skiptoken"))
+	end := min(offset+s.pageSize(r), len(objects))
+	page := map[string]any{"value": objects[min(offset, end):end]}
+
+	if end < len(objects) {
+		next := r.URL.Query()
+		next.Set("$skiptoken", strconv.Itoa(end))
+		page["@odata.nextLink"] = s.srv.URL + r.URL.Path + "?" + next.Encode()
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) pageSize(r *http.Request) int {
+	size := s.PageSize
+	if top, err := strconv.Atoi(r.URL.Query().Get("$top")); err == nil && top > 0 && top < size {
+		size = top
+	}
+	return max(size, 1)
+}
+
+// bindIDs resolves the directory object URLs of an @odata.bind property and removes it from
+// object. It writes a 404 and returns false when a reference does not exist.
+func (s *Server) bindIDs(w http.ResponseWriter, object map[string]any, property string) ([]string, bool) {
+	raw, ok := object[property].([]any)
+	delete(object, property)
+	if !ok {
+		return nil, true
+	}
+
+	ids := make([]string, 0, len(raw))
+	for _, ref := range raw {
+		odataID, _ := ref.(string)
+		id := refID(odataID)
+		if _, ok := s.directoryObject(id); !ok {
+			writeNotFound(w, id)
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+func writeAlreadyExists(w http.ResponseWriter, property string) {
+	writeError(w, http.StatusBadRequest, "Request_BadRequest",
+		fmt.Sprintf("One or more added object references already exist for the following modified properties: '%s'.", property))
+}
+
+func decodeObject(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	object := map[string]any{}
+	if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unable to read JSON request payload.")
+		return nil, false
+	}
+	return object, true
+}
+
+// refID returns the object ID of a directory object URL such as .../v1.0/users/{id}.
+func refID(odataID string) string {
+	return odataID[strings.LastIndex(odataID, "/")+1:]
+}
+
+// parseEqFilter parses filters of the form property eq 'value'.
+func parseEqFilter(filter string) (string, string, bool) {
+	property, value, ok := strings.Cut(filter, " eq ")
+	if !ok || len(value) < 2 || !strings.HasPrefix(value, "'") || !strings.HasSuffix(value, "'") {
+		return "", "", false
+	}
+	return strings.TrimSpace(property), value[1 : len(value)-1], true
+}
+
+func copyObject(object map[string]any) map[string]any {
+	result := make(map[string]any, len(object))
+	for key, value := range object {
+		result[key] = value
+	}
+	return result
+}
+
+func contains(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func without(ids []string, id string) []string {
+	var result []string
+	for _, candidate := range ids {
+		if candidate != id {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package fakegraph
+
+import (
+	"net/http"
+	"net/netip"
+)
+
+// odata types of the named locations Graph supports
+const (
+	ipNamedLocationType      = "#microsoft.graph.ipNamedLocation"
+	countryNamedLocationType = "#microsoft.graph.countryNamedLocation"
+)
+
+// routeNamedLocations serves /identity/conditionalAccess/namedLocations.
+func (s *Server) routeNamedLocations(w http.ResponseWriter, r *http.Request, segments []string) {
+	const collection = "namedLocations"
+	switch {
+	case len(segments) == 3 && r.Method == http.MethodGet:
+		s.listObjects(w, r, collection)
+	case len(segments) == 3 && r.Method == http.MethodPost:
+		s.createObject(w, r, collection)
+	case len(segments) == 4 && r.Method == http.MethodGet:
+		s.getObject(w, collection, segments[3])
+	case len(segments) == 4 && r.Method == http.MethodPatch:
+		s.updateNamedLocation(w, r, segments[3])
+	case len(segments) == 4 && r.Method == http.MethodDelete:
+		s.deleteNamedLocation(w, segments[3])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+// validateNamedLocation rejects named locations of unknown types, IP named locations without
+// valid ranges and country named locations without countries.
+func validateNamedLocation(w http.ResponseWriter, location map[string]any) bool {
+	switch location["@odata.type"] {
+	case ipNamedLocationType:
+		ranges, _ := location["ipRanges"].([]any)
+		if len(ranges) == 0 {
+			return writeInvalidNamedLocation(w, "ipRanges")
+		}
+		for _, item := range ranges {
+			ipRange, _ := item.(map[string]any)
+			cidr, _ := ipRange["cidrAddress"].(string)
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return writeInvalidNamedLocation(w, "ipRanges")
+			}
+			if rangeType := ipRange["@odata.type"]; (rangeType == "#microsoft.graph.iPv4CidrRange") != prefix.Addr().Is4() {
+				return writeInvalidNamedLocation(w, "ipRanges")
+			}
+		}
+	case countryNamedLocationType:
+		if len(stringList(location["countriesAndRegions"])) == 0 {
+			return writeInvalidNamedLocation(w, "countriesAndRegions")
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "A type of named location must be specified.")
+		return false
+	}
+	return true
+}
+
+// updateNamedLocation updates a named location of the same type when the result is still valid.
+func (s *Server) updateNamedLocation(w http.ResponseWriter, r *http.Request, id string) {
+	location, ok := s.objects["namedLocations"][id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	update, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+	if odataType, ok := update["@odata.type"]; ok && odataType != location["@odata.type"] {
+		writeError(w, http.StatusBadRequest, "BadRequest", "The type of a named location cannot be changed.")
+		return
+	}
+	merged := copyObject(location)
+	for key, value := range update {
+		merged[key] = value
+	}
+	if !validateNamedLocation(w, merged) {
+		return
+	}
+
+	for key, value := range update {
+		if key == "id" || key == "@odata.type" {
+			continue
+		}
+		location[key] = value
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteNamedLocation deletes a named location like Graph does: named locations used by
+// conditional access policies cannot be deleted.
+func (s *Server) deleteNamedLocation(w http.ResponseWriter, id string) {
+	for _, policy := range s.objects["conditionalAccessPolicies"] {
+		conditions, _ := policy["conditions"].(map[string]any)
+		locations, _ := conditions["locations"].(map[string]any)
+		if contains(stringList(locations["includeLocations"]), id) || contains(stringList(locations["excludeLocations"]), id) {
+			writeError(w, http.StatusBadRequest, "BadRequest",
+				"The named location cannot be deleted because it is used by a conditional access policy.")
+			return
+		}
+	}
+	s.deleteObject(w, "namedLocations", id)
+}
+
+func writeInvalidNamedLocation(w http.ResponseWriter, property string) bool {
+	writeError(w, http.StatusBadRequest, "BadRequest", "Invalid value specified for property '"+property+"' of resource 'NamedLocation'.")
+	return false
+}
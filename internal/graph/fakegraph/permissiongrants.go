@@ -0,0 +1,79 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// validateOAuth2PermissionGrant checks that a delegated permission grant to create references
+// existing client and resource service principals and is the only grant of its consent type
+// between them.
+func (s *Server) validateOAuth2PermissionGrant(w http.ResponseWriter, object map[string]any) bool {
+	for _, property := range []string{"clientId", "resourceId"} {
+		id, _ := object[property].(string)
+		if _, ok := s.objects["servicePrincipals"][id]; !ok {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest",
+				fmt.Sprintf("Invalid value specified for property '%s' of resource 'OAuth2PermissionGrant'.", property))
+			return false
+		}
+	}
+
+	for _, existing := range s.objects["oauth2PermissionGrants"] {
+		if existing["clientId"] == object["clientId"] && existing["resourceId"] == object["resourceId"] &&
+			existing["consentType"] == object["consentType"] && existing["principalId"] == object["principalId"] {
+			writeError(w, http.StatusConflict, "Request_MultipleObjectsWithSameKeyValue",
+				"Permission entry already exists.")
+			return false
+		}
+	}
+	return true
+}
+
+// AddPermissionScope adds an enabled delegated permission with the given value to a service
+// principal, as if it was published by its application, and returns the ID of the scope.
+func (s *Server) AddPermissionScope(servicePrincipalID, value string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.objects["servicePrincipals"][servicePrincipalID]
+	if !ok {
+		return ""
+	}
+	scopeID := newID()
+	scopes, _ := sp["oauth2PermissionScopes"].([]any)
+	sp["oauth2PermissionScopes"] = append(scopes, map[string]any{
+		"id":        scopeID,
+		"value":     value,
+		"type":      "Admin",
+		"isEnabled": true,
+	})
+	return scopeID
+}
+
+// PermissionGrants returns copies of the delegated permission grants of a client service
+// principal.
+func (s *Server) PermissionGrants(clientID string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var grants []map[string]any
+	for _, grant := range s.sortedObjects("oauth2PermissionGrants") {
+		if grant["clientId"] == clientID {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// appRoleAssignmentsOf returns copies of the app role assignments granted to a principal.
+func (s *Server) appRoleAssignmentsOf(principalID string) []map[string]any {
+	var assignments []map[string]any
+	for _, assignment := range s.appRoleAssignments {
+		if assignment["principalId"] == principalID {
+			assignments = append(assignments, copyObject(assignment))
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i]["id"].(string) < assignments[j]["id"].(string)
+	})
+	return assignments
+}
@@ -0,0 +1,223 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EligibilityRequests returns copies of the PIM eligibility schedule requests received, for
+// directory roles and groups, in the order they were received.
+func (s *Server) EligibilityRequests() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]map[string]any, 0, len(s.eligibilityRequests))
+	for _, request := range s.eligibilityRequests {
+		requests = append(requests, copyObject(request))
+	}
+	return requests
+}
+
+// EligibilitySchedules returns copies of the directory role and group eligibility schedules of
+// a principal.
+func (s *Server) EligibilitySchedules(principalID string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedules := filterByProperty(sortedByID(s.roleEligibilities), "principalId", principalID)
+	return append(schedules, filterByProperty(sortedByID(s.groupEligibilities), "principalId", principalID)...)
+}
+
+// SetEligibilityEnd moves the end of an eligibility schedule, e.g. to make it expire soon.
+func (s *Server) SetEligibilityEnd(scheduleID string, end time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, schedules := range []map[string]map[string]any{s.roleEligibilities, s.groupEligibilities} {
+		if schedule, ok := schedules[scheduleID]; ok {
+			schedule["scheduleInfo"] = map[string]any{
+				"startDateTime": scheduleStart(schedule),
+				"expiration":    map[string]any{"type": "afterDateTime", "endDateTime": end.UTC().Format(time.RFC3339)},
+			}
+		}
+	}
+}
+
+// DeleteEligibilitySchedule removes an eligibility schedule as if it expired or was removed
+// outside of the controller.
+func (s *Server) DeleteEligibilitySchedule(scheduleID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.roleEligibilities, scheduleID)
+	delete(s.groupEligibilities, scheduleID)
+}
+
+// routeGroupEligibility serves /identityGovernance/privilegedAccess/group/eligibilityScheduleRequests
+// and eligibilitySchedules.
+func (s *Server) routeGroupEligibility(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) < 4 || segments[1] != "privilegedAccess" || segments[2] != "group" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+		return
+	}
+
+	switch {
+	case segments[3] == "eligibilityScheduleRequests" && len(segments) == 4 && r.Method == http.MethodPost:
+		s.createEligibilityRequest(w, r, true)
+	case segments[3] == "eligibilitySchedules" && len(segments) == 4 && r.Method == http.MethodGet:
+		s.listEligibilitySchedules(w, r, s.groupEligibilities)
+	case segments[3] == "eligibilitySchedules" && len(segments) == 5 && r.Method == http.MethodGet:
+		s.getEligibilitySchedule(w, s.groupEligibilities, segments[4])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+func (s *Server) listEligibilitySchedules(w http.ResponseWriter, r *http.Request, schedules map[string]map[string]any) {
+	filter := r.URL.Query().Get("$filter")
+	property, value, ok := parseEqFilter(filter)
+	if !ok {
+		// Graph requires filtering eligibility schedules by principal or group
+		writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", "Unsupported query.")
+		return
+	}
+	s.writePage(w, r, filterByProperty(sortedByID(schedules), property, value))
+}
+
+func (s *Server) getEligibilitySchedule(w http.ResponseWriter, schedules map[string]map[string]any, id string) {
+	schedule, ok := schedules[id]
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, copyObject(schedule))
+}
+
+// createEligibilityRequest processes an eligibility schedule request like PIM does when no
+// approval is required: the schedule is created, changed or removed immediately. Requests with
+// actions set with RequireApproval are left pending approval instead.
+func (s *Server) createEligibilityRequest(w http.ResponseWriter, r *http.Request, group bool) {
+	request, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	if justification, _ := request["justification"].(string); justification == "" {
+		writeError(w, http.StatusBadRequest, "JustificationRule", "The policy requires a justification.")
+		return
+	}
+	principalID, _ := request["principalId"].(string)
+	principal, ok := s.directoryObject(principalID)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'principalId'.")
+		return
+	}
+
+	schedules := s.roleEligibilities
+	var matches func(schedule map[string]any) bool
+	if group {
+		schedules = s.groupEligibilities
+		groupID, _ := request["groupId"].(string)
+		if _, ok := s.objects["groups"][groupID]; !ok {
+			writeNotFound(w, groupID)
+			return
+		}
+		if accessID := request["accessId"]; accessID != "member" && accessID != "owner" {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'accessId'.")
+			return
+		}
+		matches = func(schedule map[string]any) bool {
+			return schedule["principalId"] == principalID && schedule["groupId"] == groupID && schedule["accessId"] == request["accessId"]
+		}
+	} else {
+		if principal["@odata.type"] == odataTypes["groups"] && principal["isAssignableToRole"] != true {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Only groups with isAssignableToRole set to true can be assigned to roles.")
+			return
+		}
+		roleDefinitionID, _ := request["roleDefinitionId"].(string)
+		if _, ok := s.roleDefinitions[roleDefinitionID]; !ok {
+			writeNotFound(w, roleDefinitionID)
+			return
+		}
+		scope, _ := request["directoryScopeId"].(string)
+		if scope != "/" && !strings.HasPrefix(scope, "/administrativeUnits/") {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'directoryScopeId'.")
+			return
+		}
+		matches = func(schedule map[string]any) bool {
+			return schedule["principalId"] == principalID && schedule["roleDefinitionId"] == roleDefinitionID && schedule["directoryScopeId"] == scope
+		}
+	}
+
+	var existing map[string]any
+	for _, schedule := range schedules {
+		if matches(schedule) {
+			existing = schedule
+		}
+	}
+
+	status := "Provisioned"
+	action, _ := request["action"].(string)
+	pending := s.approvalActions[action]
+	if pending {
+		status = "PendingApproval"
+	}
+	switch action {
+	case "adminAssign":
+		if existing != nil {
+			writeError(w, http.StatusBadRequest, "RoleAssignmentExists", "The Role assignment already exists.")
+			return
+		}
+		if pending {
+			break
+		}
+		existing = map[string]any{"id": newID(), "status": "Provisioned", "memberType": "Direct"}
+		for _, property := range []string{"principalId", "roleDefinitionId", "directoryScopeId", "groupId", "accessId"} {
+			if value, ok := request[property]; ok {
+				existing[property] = value
+			}
+		}
+		existing["scheduleInfo"] = request["scheduleInfo"]
+		schedules[existing["id"].(string)] = existing
+	case "adminUpdate", "adminExtend":
+		if existing == nil {
+			writeError(w, http.StatusBadRequest, "RoleAssignmentDoesNotExist", "The Role assignment does not exist.")
+			return
+		}
+		if !pending {
+			existing["scheduleInfo"] = request["scheduleInfo"]
+		}
+	case "adminRemove":
+		if existing == nil {
+			writeError(w, http.StatusBadRequest, "RoleAssignmentDoesNotExist", "The Role assignment does not exist.")
+			return
+		}
+		if !pending {
+			delete(schedules, existing["id"].(string))
+			status = "Revoked"
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", fmt.Sprintf("Unsupported action '%s'.", action))
+		return
+	}
+
+	request["id"] = newID()
+	request["status"] = status
+	request["targetScheduleId"] = existing["id"]
+	s.eligibilityRequests = append(s.eligibilityRequests, request)
+	writeJSON(w, http.StatusCreated, copyObject(request))
+}
+
+// removeEligibilities removes the eligibility schedules of a deleted principal or group.
+func (s *Server) removeEligibilities(id string) {
+	for _, schedules := range []map[string]map[string]any{s.roleEligibilities, s.groupEligibilities} {
+		for scheduleID, schedule := range schedules {
+			if schedule["principalId"] == id || schedule["groupId"] == id {
+				delete(schedules, scheduleID)
+			}
+		}
+	}
+}
+
+func scheduleStart(schedule map[string]any) any {
+	info, _ := schedule["scheduleInfo"].(map[string]any)
+	return info["startDateTime"]
+}
@@ -0,0 +1,195 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// built-in directory roles served by every server, by template ID
+var builtInRoles = map[string]string{
+	"62e90394-69f5-4237-9190-012177145e10": "Global Administrator",
+	"fe930be7-5e62-47db-91af-98c3a49a38b1": "User Administrator",
+	"fdd7a751-b60b-444a-984c-02652fe8fa1c": "Groups Administrator",
+	"9b895d92-2cd3-44c7-9d02-a6ac2d5ea5c3": "Application Administrator",
+}
+
+// builtInRoleDefinitions returns the role definitions a tenant starts with. The ID of built-in
+// roles is their template ID.
+func builtInRoleDefinitions() map[string]map[string]any {
+	definitions := map[string]map[string]any{}
+	for templateID, displayName := range builtInRoles {
+		definitions[templateID] = map[string]any{
+			"@odata.type": "#microsoft.graph.unifiedRoleDefinition",
+			"id":          templateID,
+			"templateId":  templateID,
+			"displayName": displayName,
+			"isBuiltIn":   true,
+			"isEnabled":   true,
+		}
+	}
+	return definitions
+}
+
+// AddRoleDefinition stores an enabled custom directory role and returns its ID.
+func (s *Server) AddRoleDefinition(displayName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := newID()
+	s.roleDefinitions[id] = map[string]any{
+		"@odata.type": "#microsoft.graph.unifiedRoleDefinition",
+		"id":          id,
+		"templateId":  id,
+		"displayName": displayName,
+		"isBuiltIn":   false,
+		"isEnabled":   true,
+	}
+	return id
+}
+
+// RoleAssignments returns copies of the directory role assignments of a principal.
+func (s *Server) RoleAssignments(principalID string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filterRoleAssignments("principalId", principalID)
+}
+
+// DeleteRoleAssignment removes a directory role assignment as if it was removed outside of the
+// controller.
+func (s *Server) DeleteRoleAssignment(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.roleAssignments, id)
+}
+
+// routeRoleManagement serves /roleManagement/directory/roleDefinitions, roleAssignments and the
+// PIM roleEligibilityScheduleRequests and roleEligibilitySchedules.
+func (s *Server) routeRoleManagement(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) < 3 || segments[1] != "directory" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+		return
+	}
+
+	switch {
+	case segments[2] == "roleDefinitions" && len(segments) == 3 && r.Method == http.MethodGet:
+		definitions := sortedByID(s.roleDefinitions)
+		if filter := r.URL.Query().Get("$filter"); filter != "" {
+			property, value, ok := parseEqFilter(filter)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", "Unsupported query.")
+				return
+			}
+			definitions = filterByProperty(definitions, property, value)
+		}
+		s.writePage(w, r, definitions)
+	case segments[2] == "roleAssignments" && len(segments) == 3 && r.Method == http.MethodGet:
+		filter := r.URL.Query().Get("$filter")
+		if filter == "" {
+			s.writePage(w, r, sortedByID(s.roleAssignments))
+			return
+		}
+		property, value, ok := parseEqFilter(filter)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Request_UnsupportedQuery", "Unsupported query.")
+			return
+		}
+		s.writePage(w, r, s.filterRoleAssignments(property, value))
+	case segments[2] == "roleAssignments" && len(segments) == 3 && r.Method == http.MethodPost:
+		s.createRoleAssignment(w, r)
+	case segments[2] == "roleAssignments" && len(segments) == 4 && r.Method == http.MethodGet:
+		assignment, ok := s.roleAssignments[segments[3]]
+		if !ok {
+			writeNotFound(w, segments[3])
+			return
+		}
+		writeJSON(w, http.StatusOK, copyObject(assignment))
+	case segments[2] == "roleAssignments" && len(segments) == 4 && r.Method == http.MethodDelete:
+		if _, ok := s.roleAssignments[segments[3]]; !ok {
+			writeNotFound(w, segments[3])
+			return
+		}
+		delete(s.roleAssignments, segments[3])
+		w.WriteHeader(http.StatusNoContent)
+	case segments[2] == "roleEligibilityScheduleRequests" && len(segments) == 3 && r.Method == http.MethodPost:
+		s.createEligibilityRequest(w, r, false)
+	case segments[2] == "roleEligibilitySchedules" && len(segments) == 3 && r.Method == http.MethodGet:
+		s.listEligibilitySchedules(w, r, s.roleEligibilities)
+	case segments[2] == "roleEligibilitySchedules" && len(segments) == 4 && r.Method == http.MethodGet:
+		s.getEligibilitySchedule(w, s.roleEligibilities, segments[3])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+// createRoleAssignment assigns a directory role like Graph does: the principal and the role must
+// exist, groups must be assignable to roles and a role is assigned once per principal and scope.
+func (s *Server) createRoleAssignment(w http.ResponseWriter, r *http.Request) {
+	assignment, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	principalID, _ := assignment["principalId"].(string)
+	principal, ok := s.directoryObject(principalID)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			"Invalid value specified for property 'principalId' of resource 'UnifiedRoleAssignment'.")
+		return
+	}
+	if principal["@odata.type"] == odataTypes["groups"] && principal["isAssignableToRole"] != true {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			"Only groups with isAssignableToRole set to true can be assigned to roles.")
+		return
+	}
+	roleDefinitionID, _ := assignment["roleDefinitionId"].(string)
+	if _, ok := s.roleDefinitions[roleDefinitionID]; !ok {
+		writeNotFound(w, roleDefinitionID)
+		return
+	}
+	scope, _ := assignment["directoryScopeId"].(string)
+	if scope != "/" && !strings.HasPrefix(scope, "/administrativeUnits/") {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			"Invalid value specified for property 'directoryScopeId' of resource 'UnifiedRoleAssignment'.")
+		return
+	}
+
+	for _, existing := range s.roleAssignments {
+		if existing["principalId"] == principalID && existing["roleDefinitionId"] == roleDefinitionID && existing["directoryScopeId"] == scope {
+			writeError(w, http.StatusConflict, "RoleAssignmentExists", "A conflicting object with one or more of the specified property values is present in the directory.")
+			return
+		}
+	}
+
+	id := newID()
+	assignment["id"] = id
+	assignment["@odata.type"] = "#microsoft.graph.unifiedRoleAssignment"
+	s.roleAssignments[id] = assignment
+	writeJSON(w, http.StatusCreated, copyObject(assignment))
+}
+
+func (s *Server) filterRoleAssignments(property, value string) []map[string]any {
+	return filterByProperty(sortedByID(s.roleAssignments), property, value)
+}
+
+// sortedByID returns copies of objects ordered by ID.
+func sortedByID(objects map[string]map[string]any) []map[string]any {
+	result := make([]map[string]any, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, copyObject(object))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["id"].(string) < result[j]["id"].(string)
+	})
+	return result
+}
+
+func filterByProperty(objects []map[string]any, property, value string) []map[string]any {
+	var filtered []map[string]any
+	for _, object := range objects {
+		if fmt.Sprint(object[property]) == value {
+			filtered = append(filtered, object)
+		}
+	}
+	return filtered
+}
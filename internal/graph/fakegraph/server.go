@@ -0,0 +1,418 @@
+// Package fakegraph provides an in-memory Microsoft Graph v1.0 server for tests. It serves the
+// subset of the API used by the controller (groups, members, owners, users, invitations,
+// applications, service principals and their app role assignments, delegated permission grants,
+// directory roles and their assignments, PIM eligibilities for directory roles and groups,
+// administrative units and the groups created in them, conditional access policies and named
+// locations, group delta queries and $batch) and can inject errors and throttling, so that reconcilers can be exercised
+// end to end without a tenant.
+package fakegraph
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/google/uuid"
+)
+
+const (
+	apiVersion = "/v1.0"
+	// Token is the signature of the bearer tokens issued by Credential and required by the server.
+	Token = "fake-graph-token"
+	// defaultPageSize matches the Graph default page size.
+	defaultPageSize = 100
+)
+
+// DefaultRoles are the application permissions in the tokens issued by Credential until
+// changed with SetRoles.
+var DefaultRoles = []string{"Group.ReadWrite.All", "Application.ReadWrite.All", "User.ReadWrite.All", "AppRoleAssignment.ReadWrite.All", "DelegatedPermissionGrant.ReadWrite.All", "RoleManagement.ReadWrite.Directory", "PrivilegedEligibilitySchedule.ReadWrite.AzureADGroup", "AdministrativeUnit.ReadWrite.All", "Policy.ReadWrite.ConditionalAccess", "Policy.Read.All"}
+
+// Request is a request received by the server, including the steps of $batch requests.
+type Request struct {
+	Method string
+	// Path is relative to the API version, e.g. /groups/{id}/members/$ref.
+	Path  string
+	Query string
+}
+
+// Fault makes the server fail matching requests instead of serving them.
+type Fault struct {
+	// Method matches the HTTP method, any method when empty.
+	Method string
+	// Path is a path.Match pattern relative to the API version, e.g. /groups/*/members/$ref.
+	Path string
+	// StatusCode is the HTTP status returned.
+	StatusCode int
+	// Code and Message are returned in the OData error body.
+	Code    string
+	Message string
+	// RetryAfter sets the Retry-After header when not empty.
+	RetryAfter string
+	// Times is the number of matching requests that fail, every request when 0.
+	Times int
+}
+
+// Server is a fake Microsoft Graph endpoint backed by an httptest.Server.
+type Server struct {
+	srv *httptest.Server
+
+	mu sync.Mutex
+	// PageSize is the maximum number of items returned per page by list endpoints.
+	PageSize int
+	// collection name, e.g. groups, to object ID to object
+	objects map[string]map[string]map[string]any
+	members map[string][]string
+	owners  map[string][]string
+	// passwords of the users created through the API, by user ID
+	passwords map[string]string
+	// display names of the applications of other tenants, by application ID
+	externalApps map[string]string
+	// app role assignments, by assignment ID
+	appRoleAssignments map[string]map[string]any
+	// directory role definitions and assignments, by ID
+	roleDefinitions map[string]map[string]any
+	roleAssignments map[string]map[string]any
+	// PIM eligibility schedules of directory roles and groups, by ID, and the requests received
+	roleEligibilities   map[string]map[string]any
+	groupEligibilities  map[string]map[string]any
+	eligibilityRequests []map[string]any
+	// eligibility schedule request actions left pending approval
+	approvalActions map[string]bool
+	// members of administrative units, by unit ID
+	unitMembers map[string][]string
+	// delta tracking: every group change bumps version and records it for the group
+	version   int
+	changes   map[string]int
+	faults    []*Fault
+	requests  []Request
+	requestID int
+	roles     []string
+}
+
+// NewServer starts a fake Graph server. It must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		PageSize: defaultPageSize,
+		objects: map[string]map[string]map[string]any{
+			"users":             {},
+			"groups":            {},
+			"applications":      {},
+			"servicePrincipals": {},
+			// delegated permission grants, served as a collection without being directory objects
+			"oauth2PermissionGrants": {},
+			// administrative units, served under /directory
+			"administrativeUnits": {},
+			// conditional access policies and named locations, served under /identity/conditionalAccess
+			"conditionalAccessPolicies": {},
+			"namedLocations":            {},
+		},
+		members:            map[string][]string{},
+		owners:             map[string][]string{},
+		passwords:          map[string]string{},
+		externalApps:       map[string]string{},
+		appRoleAssignments: map[string]map[string]any{},
+		roleDefinitions:    builtInRoleDefinitions(),
+		roleAssignments:    map[string]map[string]any{},
+		roleEligibilities:  map[string]map[string]any{},
+		groupEligibilities: map[string]map[string]any{},
+		unitMembers:        map[string][]string{},
+		changes:            map[string]int{},
+		roles:              DefaultRoles,
+	}
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// BaseURL returns the Graph base URL to configure the SDK with, including the API version.
+func (s *Server) BaseURL() string {
+	return s.srv.URL + apiVersion
+}
+
+// Credential returns a token credential issuing tokens accepted by the server. Tokens are
+// unsigned JWTs carrying the roles set with SetRoles, followed by Token as signature.
+func (s *Server) Credential() azcore.TokenCredential {
+	return credential{server: s}
+}
+
+// SetRoles sets the application permissions in the roles claim of the tokens issued from now on.
+func (s *Server) SetRoles(roles ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles = roles
+}
+
+// RequireApproval leaves eligibility schedule requests with the given actions pending approval
+// from now on, without applying them. It replaces the actions of previous calls.
+func (s *Server) RequireApproval(actions ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.approvalActions = map[string]bool{}
+	for _, action := range actions {
+		s.approvalActions[action] = true
+	}
+}
+
+type credential struct {
+	server *Server
+}
+
+func (c credential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.server.mu.Lock()
+	claims, err := json.Marshal(map[string]any{"iss": "fakegraph", "roles": c.server.roles})
+	c.server.mu.Unlock()
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	token := encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + encode(claims) + "." + encode([]byte(Token))
+	return azcore.AccessToken{Token: token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// Inject registers a fault. Faults are matched in registration order.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := fault
+	s.faults = append(s.faults, &f)
+}
+
+// Throttle answers the next times matching requests with 429 Too Many Requests and a
+// Retry-After of zero, so that the SDK retry handler retries them immediately.
+func (s *Server) Throttle(method, pattern string, times int) {
+	s.Inject(Fault{
+		Method:     method,
+		Path:       pattern,
+		StatusCode: http.StatusTooManyRequests,
+		Code:       "TooManyRequests",
+		Message:    "Too many requests.",
+		RetryAfter: "0",
+		Times:      times,
+	})
+}
+
+// ClearFaults removes all registered faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// CountRequests returns the number of received requests matching method and the path.Match
+// pattern. An empty method matches any method.
+func (s *Server) CountRequests(method, pattern string) int {
+	count := 0
+	for _, req := range s.Requests() {
+		if matches(method, pattern, req.Method, req.Path) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") || !strings.HasSuffix(auth, "."+base64.RawURLEncoding.EncodeToString([]byte(Token))) {
+		writeError(w, http.StatusUnauthorized, "InvalidAuthenticationToken", "Access token is empty or invalid.")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, apiVersion+"/") {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Invalid version.")
+		return
+	}
+	// the SDK compression handler gzips request bodies. Retried requests keep the
+	// Content-Encoding header but are resent uncompressed, so the payload is sniffed.
+	if r.Body != nil {
+		body := bufio.NewReader(r.Body)
+		if magic, err := body.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+			decompressed, err := gzip.NewReader(body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Unable to read compressed request payload.")
+				return
+			}
+			defer decompressed.Close()
+			r.Body = decompressed
+		} else {
+			r.Body = io.NopCloser(body)
+		}
+	}
+
+	if r.Method == http.MethodPost && strings.TrimPrefix(r.URL.Path, apiVersion) == "/$batch" {
+		s.record(r.Method, "/$batch", r.URL.RawQuery)
+		s.serveBatch(w, r)
+		return
+	}
+	s.serve(w, r)
+}
+
+// serve handles a single, non batch, request.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	relative := strings.TrimPrefix(r.URL.Path, apiVersion)
+	s.record(r.Method, relative, r.URL.RawQuery)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestID++
+	w.Header().Set("request-id", fmt.Sprintf("fake-%d", s.requestID))
+
+	if fault := s.matchFault(r.Method, relative); fault != nil {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeError(w, fault.StatusCode, fault.Code, fault.Message)
+		return
+	}
+
+	s.route(w, r, strings.Split(strings.Trim(relative, "/"), "/"))
+}
+
+func (s *Server) record(method, relative, query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: method, Path: relative, Query: query})
+}
+
+func (s *Server) matchFault(method, relative string) *Fault {
+	for i, fault := range s.faults {
+		if !matches(fault.Method, fault.Path, method, relative) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func matches(method, pattern, reqMethod, reqPath string) bool {
+	if method != "" && !strings.EqualFold(method, reqMethod) {
+		return false
+	}
+	ok, err := path.Match(pattern, reqPath)
+	return err == nil && ok
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	collection := segments[0]
+	if collection == "invitations" && len(segments) == 1 && r.Method == http.MethodPost {
+		s.createInvitation(w, r)
+		return
+	}
+	if collection == "roleManagement" {
+		s.routeRoleManagement(w, r, segments)
+		return
+	}
+	if collection == "identityGovernance" {
+		s.routeGroupEligibility(w, r, segments)
+		return
+	}
+	if collection == "directory" {
+		s.routeDirectory(w, r, segments[1:])
+		return
+	}
+	if collection == "identity" {
+		s.routeIdentity(w, r, segments)
+		return
+	}
+	if _, ok := s.objects[collection]; !ok || nestedCollections[collection] {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Resource not found for the segment '%s'.", collection))
+		return
+	}
+
+	switch {
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.listObjects(w, r, collection)
+		case http.MethodPost:
+			s.createObject(w, r, collection)
+		default:
+			writeMethodNotAllowed(w)
+		}
+	case collection == "groups" && len(segments) == 2 && (segments[1] == "delta" || segments[1] == "delta()"):
+		s.groupDelta(w, r)
+	case len(segments) == 2:
+		switch r.Method {
+		case http.MethodGet:
+			s.getObject(w, collection, segments[1])
+		case http.MethodPatch:
+			s.updateObject(w, r, collection, segments[1])
+		case http.MethodDelete:
+			s.deleteObject(w, collection, segments[1])
+		default:
+			writeMethodNotAllowed(w)
+		}
+	case collection == "servicePrincipals" && len(segments) == 3 && segments[2] == "appRoleAssignments" && r.Method == http.MethodGet:
+		if _, ok := s.objects[collection][segments[1]]; !ok {
+			writeNotFound(w, segments[1])
+			return
+		}
+		s.writePage(w, r, s.appRoleAssignmentsOf(segments[1]))
+	case collection == "servicePrincipals" && segments[2] == "appRoleAssignedTo":
+		s.routeAppRoleAssignedTo(w, r, segments[1], segments[3:])
+	case collection == "groups" || collection == "servicePrincipals":
+		s.routeRelation(w, r, collection, segments[1], segments[2:])
+	default:
+		writeError(w, http.StatusBadRequest, "BadRequest", "Unsupported segment.")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+			"innerError": map[string]any{
+				"request-id": w.Header().Get("request-id"),
+			},
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "Request_ResourceNotFound",
+		fmt.Sprintf("Resource '%s' does not exist or one of its queried reference-property objects are not present.", id))
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "Request_BadRequest", "Method not allowed.")
+}
+
+func newID() string {
+	return uuid.NewString()
+}
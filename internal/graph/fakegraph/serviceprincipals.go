@@ -0,0 +1,170 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// validateServicePrincipal checks that a service principal to create references a known
+// application that has none yet, and inherits the display name of the application.
+func (s *Server) validateServicePrincipal(w http.ResponseWriter, object map[string]any, appID string) bool {
+	for _, existing := range s.objects["servicePrincipals"] {
+		if existing["appId"] == appID {
+			writeError(w, http.StatusConflict, "Request_MultipleObjectsWithSameKeyValue",
+				fmt.Sprintf("The service principal cannot be created, updated, or restored because the service principal name %s is already in use.", appID))
+			return false
+		}
+	}
+
+	if displayName, ok := s.externalApps[appID]; ok {
+		object["displayName"] = displayName
+		return true
+	}
+	for _, app := range s.objects["applications"] {
+		if app["appId"] == appID {
+			object["displayName"] = app["displayName"]
+			return true
+		}
+	}
+
+	writeError(w, http.StatusBadRequest, "Request_BadRequest",
+		fmt.Sprintf("The appId '%s' of the service principal does not reference a valid application object.", appID))
+	return false
+}
+
+// AddAppRole adds an enabled app role with the given value to a service principal, as if it
+// was declared by its application, and returns the ID of the role.
+func (s *Server) AddAppRole(servicePrincipalID, value string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.objects["servicePrincipals"][servicePrincipalID]
+	if !ok {
+		return ""
+	}
+	roleID := newID()
+	roles, _ := sp["appRoles"].([]any)
+	sp["appRoles"] = append(roles, map[string]any{
+		"id":                 roleID,
+		"value":              value,
+		"displayName":        value,
+		"isEnabled":          true,
+		"allowedMemberTypes": []any{"User", "Application"},
+	})
+	return roleID
+}
+
+// AppRoleAssignments returns copies of the app role assignments granted for a resource service
+// principal.
+func (s *Server) AppRoleAssignments(resourceID string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appRoleAssignedTo(resourceID)
+}
+
+func (s *Server) appRoleAssignedTo(resourceID string) []map[string]any {
+	var assignments []map[string]any
+	for _, assignment := range s.appRoleAssignments {
+		if assignment["resourceId"] == resourceID {
+			assignments = append(assignments, copyObject(assignment))
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return assignments[i]["id"].(string) < assignments[j]["id"].(string)
+	})
+	return assignments
+}
+
+// routeAppRoleAssignedTo serves the app role assignments granted for a resource service principal.
+func (s *Server) routeAppRoleAssignedTo(w http.ResponseWriter, r *http.Request, resourceID string, segments []string) {
+	if _, ok := s.objects["servicePrincipals"][resourceID]; !ok {
+		writeNotFound(w, resourceID)
+		return
+	}
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.writePage(w, r, s.appRoleAssignedTo(resourceID))
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createAppRoleAssignment(w, r, resourceID)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		assignment, ok := s.appRoleAssignments[segments[0]]
+		if !ok || assignment["resourceId"] != resourceID {
+			writeNotFound(w, segments[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, copyObject(assignment))
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		assignment, ok := s.appRoleAssignments[segments[0]]
+		if !ok || assignment["resourceId"] != resourceID {
+			writeNotFound(w, segments[0])
+			return
+		}
+		delete(s.appRoleAssignments, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMethodNotAllowed(w)
+	}
+}
+
+func (s *Server) createAppRoleAssignment(w http.ResponseWriter, r *http.Request, resourceID string) {
+	assignment, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	principalID, _ := assignment["principalId"].(string)
+	principal, ok := s.directoryObject(principalID)
+	if !ok {
+		writeNotFound(w, principalID)
+		return
+	}
+	if assignment["resourceId"] != resourceID {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "The resourceId of the app role assignment must match the service principal.")
+		return
+	}
+
+	appRoleID, _ := assignment["appRoleId"].(string)
+	if appRoleID != "00000000-0000-0000-0000-000000000000" && !s.hasAppRole(resourceID, appRoleID) {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest",
+			fmt.Sprintf("Permission %s being assigned was not found on application %s.", appRoleID, resourceID))
+		return
+	}
+
+	for _, existing := range s.appRoleAssignments {
+		if existing["resourceId"] == resourceID && existing["principalId"] == principalID && existing["appRoleId"] == appRoleID {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Permission being assigned already exists on the object")
+			return
+		}
+	}
+
+	id := newID()
+	assignment["id"] = id
+	assignment["@odata.type"] = "#microsoft.graph.appRoleAssignment"
+	assignment["principalType"] = principalType(principal)
+	assignment["principalDisplayName"] = principal["displayName"]
+	assignment["resourceDisplayName"] = s.objects["servicePrincipals"][resourceID]["displayName"]
+	s.appRoleAssignments[id] = assignment
+	writeJSON(w, http.StatusCreated, copyObject(assignment))
+}
+
+func (s *Server) hasAppRole(servicePrincipalID, appRoleID string) bool {
+	roles, _ := s.objects["servicePrincipals"][servicePrincipalID]["appRoles"].([]any)
+	for _, role := range roles {
+		if role, ok := role.(map[string]any); ok && role["id"] == appRoleID {
+			return true
+		}
+	}
+	return false
+}
+
+// principalType returns the principalType of app role assignments for a directory object.
+func principalType(object map[string]any) string {
+	switch object["@odata.type"] {
+	case odataTypes["groups"]:
+		return "Group"
+	case odataTypes["servicePrincipals"]:
+		return "ServicePrincipal"
+	}
+	return "User"
+}
@@ -0,0 +1,106 @@
+package fakegraph
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Password returns the password a user was created with through the API.
+func (s *Server) Password(userID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	password, ok := s.passwords[userID]
+	return password, ok
+}
+
+// RedeemInvitation marks the invitation of a guest user as accepted.
+func (s *Server) RedeemInvitation(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user, ok := s.objects["users"][userID]; ok {
+		user["externalUserState"] = "Accepted"
+	}
+}
+
+// validateUser checks the properties required to create a user and returns its password,
+// which is removed from the user since Graph never returns it.
+func (s *Server) validateUser(w http.ResponseWriter, object map[string]any) (string, bool) {
+	for _, property := range []string{"userPrincipalName", "mailNickname"} {
+		if value, _ := object[property].(string); value == "" {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", fmt.Sprintf("Invalid value specified for property '%s' of resource 'User'.", property))
+			return "", false
+		}
+	}
+	if _, ok := object["accountEnabled"].(bool); !ok {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'accountEnabled' of resource 'User'.")
+		return "", false
+	}
+	profile, _ := object["passwordProfile"].(map[string]any)
+	password, _ := profile["password"].(string)
+	if password == "" {
+		writeError(w, http.StatusBadRequest, "Request_BadRequest", "Invalid value specified for property 'passwordProfile' of resource 'User'.")
+		return "", false
+	}
+
+	upn := object["userPrincipalName"].(string)
+	for _, existing := range s.objects["users"] {
+		if existingUPN, _ := existing["userPrincipalName"].(string); strings.EqualFold(existingUPN, upn) {
+			writeError(w, http.StatusBadRequest, "Request_BadRequest", "Another object with the same value for property userPrincipalName already exists.")
+			return "", false
+		}
+	}
+
+	delete(object, "passwordProfile")
+	object["userType"] = "Member"
+	return password, true
+}
+
+// createInvitation serves POST /invitations. The invited user is created as a guest pending
+// acceptance, inviting the same email address again returns the existing guest.
+func (s *Server) createInvitation(w http.ResponseWriter, r *http.Request) {
+	invitation, ok := decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	email, _ := invitation["invitedUserEmailAddress"].(string)
+	if !strings.Contains(email, "@") {
+		writeError(w, http.StatusBadRequest, "BadRequest", "The invited user email address is invalid.")
+		return
+	}
+	if redirect, _ := invitation["inviteRedirectUrl"].(string); redirect == "" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "The invite redirect URL is required.")
+		return
+	}
+
+	var user map[string]any
+	for _, existing := range s.objects["users"] {
+		if existing["userType"] == "Guest" && strings.EqualFold(fmt.Sprint(existing["mail"]), email) {
+			user = existing
+			break
+		}
+	}
+	if user == nil {
+		displayName, _ := invitation["invitedUserDisplayName"].(string)
+		if displayName == "" {
+			displayName = email
+		}
+		user = map[string]any{
+			"displayName":       displayName,
+			"mail":              email,
+			"userPrincipalName": strings.ReplaceAll(email, "@", "_") + "#EXT#@fake.onmicrosoft.com",
+			"userType":          "Guest",
+			"externalUserState": "PendingAcceptance",
+			"accountEnabled":    true,
+		}
+		s.store("users", user)
+	}
+
+	invitation["id"] = newID()
+	invitation["status"] = user["externalUserState"]
+	invitation["inviteRedeemUrl"] = "https://login.microsoftonline.com/redeem?rd=" + url.QueryEscape(invitation["id"].(string))
+	invitation["invitedUser"] = map[string]any{"id": user["id"]}
+	writeJSON(w, http.StatusCreated, invitation)
+}
@@ -3,12 +3,18 @@ package groups
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	graphgroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	entraGroup "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 )
 
-func (s *Service) Create(ctx context.Context, groupSpec entraGroup.EntraSecurityGroupSpec) (*GroupCreateResponse, error) {
+// Create creates the group, tagged with uniqueName so that it can be found again with
+// GetByUniqueName when the creation is retried. The group is created in the administrative unit
+// with administrativeUnitID when not empty.
+// api doc: https://learn.microsoft.com/en-us/graph/api/administrativeunit-post-members?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, groupSpec entraGroup.EntraSecurityGroupSpec, uniqueName string, administrativeUnitID string) (*GroupCreateResponse, error) {
 
 	group := models.NewGroup()
 	group.SetDisplayName(&groupSpec.Name)
@@ -17,6 +23,16 @@ func (s *Service) Create(ctx context.Context, groupSpec entraGroup.EntraSecurity
 	group.SetMailNickname(&groupSpec.MailNickname)
 	group.SetSecurityEnabled(&groupSpec.SecurityEnabled)
 	group.SetGroupTypes(groupSpec.GroupTypes)
+	if groupSpec.IsAssignableToRole {
+		group.SetIsAssignableToRole(&groupSpec.IsAssignableToRole)
+	}
+	if uniqueName != "" {
+		group.SetUniqueName(&uniqueName)
+	}
+
+	if administrativeUnitID != "" {
+		return s.createInAdministrativeUnit(ctx, group, administrativeUnitID)
+	}
 
 	resp, err := s.sdk.Groups().Post(ctx, group, nil)
 	if err != nil {
@@ -28,3 +44,51 @@ func (s *Service) Create(ctx context.Context, groupSpec entraGroup.EntraSecurity
 		ID:          *resp.GetId(),
 	}, nil
 }
+
+func (s *Service) createInAdministrativeUnit(ctx context.Context, group models.Groupable, administrativeUnitID string) (*GroupCreateResponse, error) {
+	resp, err := s.sdk.Directory().AdministrativeUnits().ByAdministrativeUnitId(administrativeUnitID).Members().Post(ctx, group, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group in administrative unit %s: %w", administrativeUnitID, err)
+	}
+	if resp.GetId() == nil {
+		return nil, fmt.Errorf("administrative unit member response has no ID")
+	}
+
+	response := &GroupCreateResponse{ID: *resp.GetId()}
+	if created, ok := resp.(models.Groupable); ok && created.GetDisplayName() != nil {
+		response.DisplayName = *created.GetDisplayName()
+	} else if group.GetDisplayName() != nil {
+		response.DisplayName = *group.GetDisplayName()
+	}
+	return response, nil
+}
+
+// GetByUniqueName returns the group tagged with uniqueName, nil when there is none.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-list?view=graph-rest-1.0&tabs=http
+func (s *Service) GetByUniqueName(ctx context.Context, uniqueName string) (*GroupCreateResponse, error) {
+	if uniqueName == "" {
+		return nil, nil
+	}
+	filter := fmt.Sprintf("uniqueName eq '%s'", strings.ReplaceAll(uniqueName, "'", "''"))
+	resp, err := s.sdk.Groups().Get(ctx, &graphgroups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphgroups.GroupsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+			Select: []string{"id", "displayName"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group by unique name: %w", err)
+	}
+
+	for _, group := range resp.GetValue() {
+		if group.GetId() == nil {
+			continue
+		}
+		response := &GroupCreateResponse{ID: *group.GetId()}
+		if group.GetDisplayName() != nil {
+			response.DisplayName = *group.GetDisplayName()
+		}
+		return response, nil
+	}
+	return nil, nil
+}
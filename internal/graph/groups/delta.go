@@ -0,0 +1,89 @@
+package groups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	graphgroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrDeltaTokenExpired is returned when Graph no longer accepts the stored delta link
+// and change tracking has to start over.
+var ErrDeltaTokenExpired = errors.New("group delta token expired")
+
+// Delta returns the IDs of the groups that changed since deltaLink was issued, including
+// membership changes and deletions, together with the delta link for the next round.
+// An empty deltaLink returns no groups, only a delta link tracking changes from now on, so that
+// starting change tracking does not page through every group in the tenant.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-delta?view=graph-rest-1.0&tabs=http
+// api doc: https://learn.microsoft.com/en-us/graph/delta-query-overview#use-delta-query-to-track-changes-in-a-resource-collection
+func (s *Service) Delta(ctx context.Context, deltaLink string) (*GroupDeltaResponse, error) {
+	logger := log.FromContext(ctx)
+
+	builder := s.sdk.Groups().Delta()
+	initial := false
+	if deltaLink == "" {
+		// Graph only reports changes of the selected properties, members is selected so that
+		// membership changes are reported. The delta link carries the query of this request.
+		reqInfo, err := builder.ToGetRequestInformation(ctx, &graphgroups.DeltaRequestBuilderGetRequestConfiguration{
+			QueryParameters: &graphgroups.DeltaRequestBuilderGetQueryParameters{
+				Select: []string{"id", "displayName", "members"},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build group delta request: %w", err)
+		}
+		uri, err := reqInfo.GetUri()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build group delta request: %w", err)
+		}
+		query := uri.Query()
+		query.Set("$deltatoken", "latest")
+		uri.RawQuery = query.Encode()
+		deltaLink = uri.String()
+		initial = true
+	}
+	builder = builder.WithUrl(deltaLink)
+
+	resp, err := builder.GetAsDeltaGetResponse(ctx, nil)
+	result := &GroupDeltaResponse{}
+	seen := make(map[string]struct{})
+
+	for {
+		if err != nil {
+			var odataErr *odataerrors.ODataError
+			if errors.As(err, &odataErr) && odataErr.GetStatusCode() == 410 {
+				return nil, ErrDeltaTokenExpired
+			}
+			return nil, fmt.Errorf("failed to get group delta: %w", err)
+		}
+
+		for _, group := range resp.GetValue() {
+			if group.GetId() == nil {
+				continue
+			}
+			if _, ok := seen[*group.GetId()]; ok {
+				continue
+			}
+			seen[*group.GetId()] = struct{}{}
+			result.ChangedGroupIDs = append(result.ChangedGroupIDs, *group.GetId())
+		}
+
+		nextLink := resp.GetOdataNextLink()
+		if nextLink == nil || *nextLink == "" {
+			break
+		}
+		resp, err = s.sdk.Groups().Delta().WithUrl(*nextLink).GetAsDeltaGetResponse(ctx, nil)
+	}
+
+	if resp.GetOdataDeltaLink() == nil {
+		return nil, fmt.Errorf("group delta response did not contain a delta link")
+	}
+	result.DeltaLink = *resp.GetOdataDeltaLink()
+
+	logger.Info("fetched group delta", "changedGroups", len(result.ChangedGroupIDs), "initial", initial)
+	return result, nil
+}
@@ -22,11 +22,40 @@ type GroupGetResponse struct {
 	HttpStatusCode string `json:"httpStatusCode"`
 }
 
+// MemberFailure describes a member that Graph refused to add or remove.
+type MemberFailure struct {
+	ID         string `json:"id"`
+	StatusCode int32  `json:"statusCode"`
+	Message    string `json:"message"`
+}
+
+// DirectoryObject is a member or owner of a group.
+type DirectoryObject struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName"`
+}
+
+type MemberUpdateResponse struct {
+	Succeeded []string        `json:"succeeded"`
+	Failed    []MemberFailure `json:"failed"`
+}
+
+type GroupDeltaResponse struct {
+	ChangedGroupIDs []string `json:"changedGroupIds"`
+	DeltaLink       string   `json:"deltaLink"`
+}
+
 type API interface {
 	Get(ctx context.Context, groupID string) (*GroupGetResponse, error)
-	Create(ctx context.Context, groupSpec entraGroup.EntraSecurityGroupSpec) (*GroupCreateResponse, error)
+	Create(ctx context.Context, groupSpec entraGroup.EntraSecurityGroupSpec, uniqueName string, administrativeUnitID string) (*GroupCreateResponse, error)
+	GetByUniqueName(ctx context.Context, uniqueName string) (*GroupCreateResponse, error)
 	Delete(ctx context.Context, groupID string) error
-	// AddMembers(ctx context.Context, entraGroup entraGroup.EntraSecurityGroup, groupID string, resourceType string, memberRefs []string) error
+	AddMembers(ctx context.Context, groupID string, resourceType string, memberIDs []string) (*MemberUpdateResponse, error)
+	RemoveMembers(ctx context.Context, groupID string, memberIDs []string) (*MemberUpdateResponse, error)
+	ListMembers(ctx context.Context, groupID string, transitive bool) ([]DirectoryObject, error)
+	ListOwners(ctx context.Context, groupID string) ([]DirectoryObject, error)
+	Delta(ctx context.Context, deltaLink string) (*GroupDeltaResponse, error)
 	// CheckMembers(ctx context.Context, entraGroup entraGroup.EntraSecurityGroup, groupID string, memberId string) error
 }
 
@@ -75,58 +104,6 @@ type API interface {
 // 	return nil
 // }
 
-// func (c *GraphClient) AddMembersToGroup(ctx context.Context, groupID string, resourceType string, memberRefs []string) error {
-
-// 	if len(memberRefs) == 0 {
-// 		return nil
-// 	}
-
-// 	bindRefs := make([]string, 0, len(memberRefs))
-// 	for _, memberId := range memberRefs {
-// 		if strings.HasPrefix(memberId, "https://graph.microsoft.com/") {
-// 			bindRefs = append(bindRefs, memberId)
-// 			continue
-// 		}
-// 		bindRefs = append(bindRefs, fmt.Sprintf("https://graph.microsoft.com/v1.0/%s/%s", resourceType, memberId))
-// 	}
-
-// 	groups := models.NewGroup()
-// 	additionalData := map[string]any{
-// 		"members@odata.bind": bindRefs,
-// 	}
-// 	groups.SetAdditionalData(additionalData)
-// 	_, err := c.sdk.Groups().ByGroupId(groupID).Patch(ctx, groups, nil)
-// 	if err != nil {
-// 		// batch addtion can fail if any of the members already exist in the group or anything invalid
-// 		// try adding members one by one
-// 		for _, memberId := range memberRefs {
-// 			member, er := c.resolveMemberReference(ctx, memberId)
-// 			if er != nil {
-// 				return fmt.Errorf("failed to resolve member reference: %v", er)
-// 			}
-// 			additionalData := map[string]any{
-// 				"members@odata.bind": []string{member},
-// 			}
-// 			groups.SetAdditionalData(additionalData)
-// 			_, err := c.sdk.Groups().ByGroupId(groupID).Patch(ctx, groups, nil)
-// 			if err != nil {
-// 				odataErr, ok := err.(*odataerrors.ODataError)
-// 				if ok && (odataErr.GetStatusCode() == 400 || odataErr.GetStatusCode() == 409) {
-// 					logger := log.FromContext(ctx)
-// 					logger.Info("member already exists in group or bad request", "memberId", memberId, "statusCode", odataErr.GetStatusCode())
-// 					continue
-// 				}
-// 				return fmt.Errorf("failed to add member %s to group: %v", memberId, err)
-// 			}
-// 		}
-// 	}
-// 	return nil
-// }
-
-// func (c *GraphClient) RemoveMembersFromGroup(ctx context.Context, groupID string) error {
-// 	return nil
-// }
-
 func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
 	return &Service{sdk: sdk}
 }
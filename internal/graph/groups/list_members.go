@@ -0,0 +1,137 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	graphgroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// listPageSize is the page size requested from Graph; 999 is the maximum allowed for directory objects.
+const listPageSize int32 = 999
+
+var directoryObjectSelect = []string{"id", "displayName"}
+
+// ListMembers returns all members of the group, following @odata.nextLink across pages.
+// When transitive is true, members of nested groups are included as well.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-list-members?view=graph-rest-1.0&tabs=http
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-list-transitivemembers?view=graph-rest-1.0&tabs=http
+func (s *Service) ListMembers(ctx context.Context, groupID string, transitive bool) ([]DirectoryObject, error) {
+	logger := log.FromContext(ctx)
+
+	if groupID == "" {
+		return nil, fmt.Errorf("group id is empty")
+	}
+
+	top := listPageSize
+	var (
+		resp models.DirectoryObjectCollectionResponseable
+		err  error
+	)
+	if transitive {
+		resp, err = s.sdk.Groups().ByGroupId(groupID).TransitiveMembers().Get(ctx, &graphgroups.ItemTransitiveMembersRequestBuilderGetRequestConfiguration{
+			QueryParameters: &graphgroups.ItemTransitiveMembersRequestBuilderGetQueryParameters{
+				Select: directoryObjectSelect,
+				Top:    &top,
+			},
+		})
+	} else {
+		resp, err = s.sdk.Groups().ByGroupId(groupID).Members().Get(ctx, &graphgroups.ItemMembersRequestBuilderGetRequestConfiguration{
+			QueryParameters: &graphgroups.ItemMembersRequestBuilderGetQueryParameters{
+				Select: directoryObjectSelect,
+				Top:    &top,
+			},
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	members, err := s.collectDirectoryObjects(ctx, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through group members: %w", err)
+	}
+
+	logger.Info("listed group members", "groupID", groupID, "transitive", transitive, "count", len(members))
+	return members, nil
+}
+
+// ListOwners returns all owners of the group, following @odata.nextLink across pages.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-list-owners?view=graph-rest-1.0&tabs=http
+func (s *Service) ListOwners(ctx context.Context, groupID string) ([]DirectoryObject, error) {
+	logger := log.FromContext(ctx)
+
+	if groupID == "" {
+		return nil, fmt.Errorf("group id is empty")
+	}
+
+	top := listPageSize
+	resp, err := s.sdk.Groups().ByGroupId(groupID).Owners().Get(ctx, &graphgroups.ItemOwnersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphgroups.ItemOwnersRequestBuilderGetQueryParameters{
+			Select: directoryObjectSelect,
+			Top:    &top,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group owners: %w", err)
+	}
+
+	owners, err := s.collectDirectoryObjects(ctx, resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through group owners: %w", err)
+	}
+
+	logger.Info("listed group owners", "groupID", groupID, "count", len(owners))
+	return owners, nil
+}
+
+func (s *Service) collectDirectoryObjects(ctx context.Context, resp models.DirectoryObjectCollectionResponseable) ([]DirectoryObject, error) {
+	iterator, err := msgraphcore.NewPageIterator[models.DirectoryObjectable](resp, s.sdk.GetAdapter(), models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []DirectoryObject
+	err = iterator.Iterate(ctx, func(item models.DirectoryObjectable) bool {
+		objects = append(objects, toDirectoryObject(item))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func toDirectoryObject(item models.DirectoryObjectable) DirectoryObject {
+	object := DirectoryObject{}
+	if item.GetId() != nil {
+		object.ID = *item.GetId()
+	}
+	if item.GetOdataType() != nil {
+		object.Type = directoryObjectType(*item.GetOdataType())
+	}
+	// users, groups and service principals are deserialized into their derived types
+	if named, ok := item.(interface{ GetDisplayName() *string }); ok && named.GetDisplayName() != nil {
+		object.DisplayName = *named.GetDisplayName()
+	}
+	return object
+}
+
+// directoryObjectType maps an @odata.type such as #microsoft.graph.user to the
+// member types used in the EntraSecurityGroup spec.
+func directoryObjectType(odataType string) string {
+	switch strings.TrimPrefix(odataType, "#microsoft.graph.") {
+	case "user":
+		return "User"
+	case "group":
+		return "Group"
+	case "servicePrincipal":
+		return "ServicePrincipal"
+	}
+	return strings.TrimPrefix(odataType, "#microsoft.graph.")
+}
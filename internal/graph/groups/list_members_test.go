@@ -0,0 +1,91 @@
+package groups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestListMembersFollowsNextLinks(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	graphServer.PageSize = 10
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	userIDs := addUsers(graphServer, 25)
+	for _, id := range userIDs {
+		graphServer.AddMember(groupID, id)
+	}
+
+	members, err := service.ListMembers(context.Background(), groupID, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(members).To(HaveLen(25))
+	g.Expect(members[0]).To(Equal(DirectoryObject{ID: userIDs[0], Type: "User", DisplayName: "user 0"}))
+	g.Expect(graphServer.CountRequests(http.MethodGet, "/groups/"+groupID+"/members")).To(Equal(3))
+
+	// the first page asks for the largest page Graph allows
+	for _, req := range graphServer.Requests() {
+		if req.Path == "/groups/"+groupID+"/members" {
+			g.Expect(req.Query).To(ContainSubstring("$top=999"))
+			break
+		}
+	}
+}
+
+func TestListMembersTransitive(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	nestedID := graphServer.AddObject("groups", map[string]any{"displayName": "Platform"})
+	userIDs := addUsers(graphServer, 2)
+	graphServer.AddMember(groupID, userIDs[0])
+	graphServer.AddMember(groupID, nestedID)
+	graphServer.AddMember(nestedID, userIDs[1])
+
+	direct, err := service.ListMembers(context.Background(), groupID, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(direct).To(ConsistOf(
+		DirectoryObject{ID: userIDs[0], Type: "User", DisplayName: "user 0"},
+		DirectoryObject{ID: nestedID, Type: "Group", DisplayName: "Platform"},
+	))
+
+	transitive, err := service.ListMembers(context.Background(), groupID, true)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(transitive).To(ConsistOf(
+		DirectoryObject{ID: userIDs[0], Type: "User", DisplayName: "user 0"},
+		DirectoryObject{ID: nestedID, Type: "Group", DisplayName: "Platform"},
+		DirectoryObject{ID: userIDs[1], Type: "User", DisplayName: "user 1"},
+	))
+}
+
+func TestListMembersOfMissingGroup(t *testing.T) {
+	g := NewWithT(t)
+	service, _ := newTestService(t)
+
+	_, err := service.ListMembers(context.Background(), "00000000-0000-0000-0000-000000000001", false)
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = service.ListMembers(context.Background(), "", false)
+	g.Expect(err).To(MatchError("group id is empty"))
+}
+
+func TestListOwnersOfGroupWithoutOwners(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+
+	owners, err := service.ListOwners(context.Background(), groupID)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(owners).To(BeEmpty())
+}
+
+func TestDirectoryObjectType(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(directoryObjectType("#microsoft.graph.user")).To(Equal("User"))
+	g.Expect(directoryObjectType("#microsoft.graph.group")).To(Equal("Group"))
+	g.Expect(directoryObjectType("#microsoft.graph.servicePrincipal")).To(Equal("ServicePrincipal"))
+	g.Expect(directoryObjectType("#microsoft.graph.device")).To(Equal("device"))
+	g.Expect(directoryObjectType("orgContact")).To(Equal("orgContact"))
+}
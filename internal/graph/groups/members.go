@@ -0,0 +1,252 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// memberChunkSize is the maximum number of references Graph accepts in a single
+// members@odata.bind PATCH, and the maximum number of steps in a $batch request.
+const memberChunkSize = 20
+
+// AddMembers adds the given directory objects to the group. Members are written
+// in chunks of 20 using members@odata.bind; when a chunk is rejected, its members
+// are retried individually through a $batch request so that a single invalid or
+// already present member does not fail the whole chunk.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-update?view=graph-rest-1.0&tabs=http#example-2-add-multiple-members-to-a-group-in-a-single-request
+func (s *Service) AddMembers(ctx context.Context, groupID string, resourceType string, memberIDs []string) (*MemberUpdateResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if groupID == "" {
+		return nil, fmt.Errorf("group id is empty")
+	}
+
+	result := &MemberUpdateResponse{}
+	for _, chunk := range chunkMembers(memberIDs) {
+		group := models.NewGroup()
+		group.SetAdditionalData(map[string]any{
+			"members@odata.bind": s.bindRefs(resourceType, chunk),
+		})
+
+		_, err := s.sdk.Groups().ByGroupId(groupID).Patch(ctx, group, nil)
+		if err == nil {
+			result.Succeeded = append(result.Succeeded, chunk...)
+			continue
+		}
+
+		// batch addition can fail if any of the members already exist in the group or anything invalid
+		// retry the chunk member by member so that the failures can be reported per member
+		logger.Info("failed to add member chunk to group, retrying members individually", "groupID", groupID, "chunkSize", len(chunk), "error", err.Error())
+		if err := s.addMembersBatch(ctx, groupID, resourceType, chunk, result); err != nil {
+			return result, err
+		}
+	}
+
+	logger.Info("added members to group", "groupID", groupID, "resourceType", resourceType, "succeeded", len(result.Succeeded), "failed", len(result.Failed))
+	return result, nil
+}
+
+// RemoveMembers removes the given directory objects from the group using $batch
+// requests of up to 20 member reference deletions. Members which are no longer
+// part of the group are treated as removed.
+// api doc: https://learn.microsoft.com/en-us/graph/api/group-delete-members?view=graph-rest-1.0&tabs=http
+func (s *Service) RemoveMembers(ctx context.Context, groupID string, memberIDs []string) (*MemberUpdateResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if groupID == "" {
+		return nil, fmt.Errorf("group id is empty")
+	}
+
+	result := &MemberUpdateResponse{}
+	for _, chunk := range chunkMembers(memberIDs) {
+		batch := msgraphcore.NewBatchRequest(s.sdk.GetAdapter())
+		steps := make(map[string]string, len(chunk))
+
+		for _, memberID := range chunk {
+			reqInfo, err := s.sdk.Groups().ByGroupId(groupID).Members().ByDirectoryObjectId(memberID).Ref().ToDeleteRequestInformation(ctx, nil)
+			if err != nil {
+				return result, fmt.Errorf("failed to build remove member request for %s: %v", memberID, err)
+			}
+			if err := addBatchStep(batch, reqInfo, memberID, steps); err != nil {
+				return result, err
+			}
+		}
+
+		resp, err := batch.Send(ctx, s.sdk.GetAdapter())
+		if err != nil {
+			return result, fmt.Errorf("failed to send remove members batch request: %v", err)
+		}
+
+		for _, item := range resp.GetResponses() {
+			memberID, ok := stepMember(item, steps)
+			if !ok {
+				logger.Info("skipping batch response without a known step id", "groupID", groupID)
+				continue
+			}
+			if item.GetStatus() == nil {
+				result.Failed = append(result.Failed, missingStatusFailure(memberID))
+				continue
+			}
+			statusCode := *item.GetStatus()
+			switch {
+			case statusCode < 300:
+				result.Succeeded = append(result.Succeeded, memberID)
+			case statusCode == 404:
+				logger.Info("member is not part of the group", "memberId", memberID, "groupID", groupID)
+				result.Succeeded = append(result.Succeeded, memberID)
+			default:
+				result.Failed = append(result.Failed, MemberFailure{
+					ID:         memberID,
+					StatusCode: statusCode,
+					Message:    batchErrorMessage(item),
+				})
+			}
+		}
+	}
+
+	logger.Info("removed members from group", "groupID", groupID, "succeeded", len(result.Succeeded), "failed", len(result.Failed))
+	return result, nil
+}
+
+func (s *Service) addMembersBatch(ctx context.Context, groupID string, resourceType string, memberIDs []string, result *MemberUpdateResponse) error {
+	logger := log.FromContext(ctx)
+
+	batch := msgraphcore.NewBatchRequest(s.sdk.GetAdapter())
+	steps := make(map[string]string, len(memberIDs))
+
+	for _, memberID := range memberIDs {
+		ref := models.NewReferenceCreate()
+		odataID := s.bindRefs(resourceType, []string{memberID})[0]
+		ref.SetOdataId(&odataID)
+
+		reqInfo, err := s.sdk.Groups().ByGroupId(groupID).Members().Ref().ToPostRequestInformation(ctx, ref, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build add member request for %s: %v", memberID, err)
+		}
+		if err := addBatchStep(batch, reqInfo, memberID, steps); err != nil {
+			return err
+		}
+	}
+
+	resp, err := batch.Send(ctx, s.sdk.GetAdapter())
+	if err != nil {
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			return fmt.Errorf("failed to send add members batch request, status code %d: %v", odataErr.GetStatusCode(), err)
+		}
+		return fmt.Errorf("failed to send add members batch request: %v", err)
+	}
+
+	for _, item := range resp.GetResponses() {
+		memberID, ok := stepMember(item, steps)
+		if !ok {
+			logger.Info("skipping batch response without a known step id", "groupID", groupID)
+			continue
+		}
+		if item.GetStatus() == nil {
+			result.Failed = append(result.Failed, missingStatusFailure(memberID))
+			continue
+		}
+		statusCode := *item.GetStatus()
+		message := batchErrorMessage(item)
+		switch {
+		case statusCode < 300:
+			result.Succeeded = append(result.Succeeded, memberID)
+		case statusCode == 400 && isAlreadyMemberError(message):
+			logger.Info("member already exists in group", "memberId", memberID, "groupID", groupID)
+			result.Succeeded = append(result.Succeeded, memberID)
+		default:
+			result.Failed = append(result.Failed, MemberFailure{
+				ID:         memberID,
+				StatusCode: statusCode,
+				Message:    message,
+			})
+		}
+	}
+
+	return nil
+}
+
+// bindRefs converts member object IDs into directory object URLs relative to the
+// Graph endpoint the SDK is configured for. Full URLs are passed through untouched.
+func (s *Service) bindRefs(resourceType string, memberIDs []string) []string {
+	baseURL := strings.TrimSuffix(s.sdk.GetAdapter().GetBaseUrl(), "/")
+
+	refs := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if strings.HasPrefix(memberID, "https://") {
+			refs = append(refs, memberID)
+			continue
+		}
+		refs = append(refs, fmt.Sprintf("%s/%s/%s", baseURL, resourceType, memberID))
+	}
+	return refs
+}
+
+func addBatchStep(batch msgraphcore.BatchRequest, reqInfo *abstractions.RequestInformation, memberID string, steps map[string]string) error {
+	item, err := batch.AddBatchRequestStep(*reqInfo)
+	if err != nil {
+		return fmt.Errorf("failed to add batch request step for member %s: %v", memberID, err)
+	}
+	steps[*item.GetId()] = memberID
+	return nil
+}
+
+// stepMember returns the member of the $batch step a response item answers. Items
+// without an id, or with an id of no step, cannot be attributed to a member.
+func stepMember(item msgraphcore.BatchItem, steps map[string]string) (string, bool) {
+	if item.GetId() == nil {
+		return "", false
+	}
+	memberID, ok := steps[*item.GetId()]
+	return memberID, ok
+}
+
+// missingStatusFailure reports a member whose $batch response item has no status,
+// so the outcome of its step is unknown.
+func missingStatusFailure(memberID string) MemberFailure {
+	return MemberFailure{ID: memberID, Message: "batch response has no status"}
+}
+
+// batchErrorMessage extracts error.message from a failed $batch response item.
+func batchErrorMessage(item msgraphcore.BatchItem) string {
+	body := item.GetBody()
+	if body == nil {
+		return ""
+	}
+	odataErr, ok := body["error"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	// nested values are decoded by the kiota json parse node, which yields string pointers
+	switch message := odataErr["message"].(type) {
+	case *string:
+		if message != nil {
+			return *message
+		}
+	case string:
+		return message
+	}
+	return ""
+}
+
+// isAlreadyMemberError reports whether a 400 response was caused by the member
+// already being present in the group.
+func isAlreadyMemberError(message string) bool {
+	return strings.Contains(message, "added object references already exist")
+}
+
+func chunkMembers(memberIDs []string) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(memberIDs); start += memberChunkSize {
+		end := min(start+memberChunkSize, len(memberIDs))
+		chunks = append(chunks, memberIDs[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,152 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	azauth "github.com/microsoft/kiota-authentication-azure-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	. "github.com/onsi/gomega"
+
+	"github.com/vimal-vijayan/entra-governance/internal/graph/fakegraph"
+)
+
+// newTestService returns a Service talking to a fake Graph server, closed when the test ends.
+func newTestService(t *testing.T) (*Service, *fakegraph.Server) {
+	t.Helper()
+	graphServer := fakegraph.NewServer()
+	t.Cleanup(graphServer.Close)
+
+	auth, err := azauth.NewAzureIdentityAuthenticationProviderWithScopesAndValidHosts(graphServer.Credential(), []string{"https://graph.microsoft.com/.default"}, []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter, err := msgraphsdk.NewGraphRequestAdapter(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter.SetBaseUrl(graphServer.BaseURL())
+	return &Service{sdk: msgraphsdk.NewGraphServiceClient(adapter)}, graphServer
+}
+
+func addUsers(graphServer *fakegraph.Server, count int) []string {
+	ids := make([]string, 0, count)
+	for i := range count {
+		ids = append(ids, graphServer.AddUser(fmt.Sprintf("user %d", i)))
+	}
+	return ids
+}
+
+func TestChunkMembers(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(chunkMembers(nil)).To(BeEmpty())
+	g.Expect(chunkMembers(make([]string, 20))).To(HaveLen(1))
+
+	chunks := chunkMembers(make([]string, 45))
+	g.Expect(chunks).To(HaveLen(3))
+	g.Expect(chunks[0]).To(HaveLen(20))
+	g.Expect(chunks[1]).To(HaveLen(20))
+	g.Expect(chunks[2]).To(HaveLen(5))
+}
+
+func TestIsAlreadyMemberError(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(isAlreadyMemberError("One or more added object references already exist for the following modified properties: 'members'.")).To(BeTrue())
+	g.Expect(isAlreadyMemberError("Resource 'x' does not exist or one of its queried reference-property objects are not present.")).To(BeFalse())
+	g.Expect(isAlreadyMemberError("")).To(BeFalse())
+}
+
+func TestStepMember(t *testing.T) {
+	g := NewWithT(t)
+	steps := map[string]string{"1": "member-1"}
+
+	item := msgraphcore.NewBatchItem()
+	_, ok := stepMember(item, steps)
+	g.Expect(ok).To(BeFalse())
+
+	unknown := "2"
+	item.SetId(&unknown)
+	_, ok = stepMember(item, steps)
+	g.Expect(ok).To(BeFalse())
+
+	known := "1"
+	item.SetId(&known)
+	memberID, ok := stepMember(item, steps)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(memberID).To(Equal("member-1"))
+}
+
+func TestAddMembersInChunks(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	userIDs := addUsers(graphServer, 45)
+
+	resp, err := service.AddMembers(context.Background(), groupID, "users", userIDs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Succeeded).To(ConsistOf(userIDs))
+	g.Expect(resp.Failed).To(BeEmpty())
+	g.Expect(graphServer.Members(groupID)).To(ConsistOf(userIDs))
+	g.Expect(graphServer.CountRequests(http.MethodPatch, "/groups/"+groupID)).To(Equal(3))
+	g.Expect(graphServer.CountRequests(http.MethodPost, "/$batch")).To(BeZero())
+}
+
+func TestAddMembersRetriesRejectedChunkPerMember(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	userIDs := addUsers(graphServer, 3)
+	graphServer.AddMember(groupID, userIDs[0])
+	missingID := "00000000-0000-0000-0000-000000000001"
+
+	resp, err := service.AddMembers(context.Background(), groupID, "users", append(userIDs, missingID))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// members that are already present count as added
+	g.Expect(resp.Succeeded).To(ConsistOf(userIDs))
+	g.Expect(resp.Failed).To(HaveLen(1))
+	g.Expect(resp.Failed[0].ID).To(Equal(missingID))
+	g.Expect(resp.Failed[0].StatusCode).To(BeEquivalentTo(http.StatusNotFound))
+	g.Expect(graphServer.Members(groupID)).To(ConsistOf(userIDs))
+	g.Expect(graphServer.CountRequests(http.MethodPost, "/$batch")).To(Equal(1))
+}
+
+func TestAddMembersReportsFailedBatchSteps(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	userIDs := addUsers(graphServer, 2)
+	graphServer.Inject(fakegraph.Fault{Method: http.MethodPatch, Path: "/groups/" + groupID, StatusCode: http.StatusBadRequest, Code: "Request_BadRequest", Message: "Invalid request.", Times: 1})
+	graphServer.Inject(fakegraph.Fault{Method: http.MethodPost, Path: "/groups/" + groupID + "/members/$ref", StatusCode: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges to complete the operation.", Times: 1})
+
+	resp, err := service.AddMembers(context.Background(), groupID, "users", userIDs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(resp.Succeeded).To(ConsistOf(userIDs[1]))
+	g.Expect(resp.Failed).To(ConsistOf(MemberFailure{ID: userIDs[0], StatusCode: http.StatusForbidden, Message: "Insufficient privileges to complete the operation."}))
+}
+
+func TestRemoveMembers(t *testing.T) {
+	g := NewWithT(t)
+	service, graphServer := newTestService(t)
+	groupID := graphServer.AddObject("groups", map[string]any{"displayName": "Engineering"})
+	userIDs := addUsers(graphServer, 25)
+	for _, id := range userIDs[:24] {
+		graphServer.AddMember(groupID, id)
+	}
+	graphServer.Inject(fakegraph.Fault{Method: http.MethodDelete, Path: "/groups/" + groupID + "/members/" + userIDs[1] + "/$ref", StatusCode: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges to complete the operation."})
+
+	resp, err := service.RemoveMembers(context.Background(), groupID, userIDs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// members that are not part of the group count as removed
+	g.Expect(resp.Succeeded).To(HaveLen(24))
+	g.Expect(resp.Succeeded).To(ContainElement(userIDs[24]))
+	g.Expect(resp.Failed).To(ConsistOf(MemberFailure{ID: userIDs[1], StatusCode: http.StatusForbidden, Message: "Insufficient privileges to complete the operation."}))
+	g.Expect(graphServer.Members(groupID)).To(ConsistOf(userIDs[1]))
+	g.Expect(graphServer.CountRequests(http.MethodPost, "/$batch")).To(Equal(2))
+}
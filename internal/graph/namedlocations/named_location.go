@@ -0,0 +1,158 @@
+package namedlocations
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Create creates the IP or country named location.
+// api doc: https://learn.microsoft.com/en-us/graph/api/conditionalaccessroot-post-namedlocations?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, location NamedLocation) (*NamedLocation, error) {
+	body, err := namedLocationModel(location)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.sdk.Identity().ConditionalAccess().NamedLocations().Post(ctx, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create named location: %w", err)
+	}
+	return namedLocationResponse(resp), nil
+}
+
+func (s *Service) Get(ctx context.Context, locationID string) (*NamedLocationGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if locationID == "" {
+		return nil, fmt.Errorf("named location id is empty")
+	}
+
+	resp, err := s.sdk.Identity().ConditionalAccess().NamedLocations().ByNamedLocationId(locationID).Get(ctx, nil)
+	if err != nil {
+		response := &NamedLocationGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get named location", "locationID", locationID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get named location %w", err)
+	}
+
+	return &NamedLocationGetResponse{NamedLocation: *namedLocationResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// Update replaces the properties of the named location. The type of a named location cannot be
+// changed.
+// api doc: https://learn.microsoft.com/en-us/graph/api/namedlocation-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, locationID string, location NamedLocation) error {
+	body, err := namedLocationModel(location)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.sdk.Identity().ConditionalAccess().NamedLocations().ByNamedLocationId(locationID).Patch(ctx, body, nil); err != nil {
+		return fmt.Errorf("failed to update named location: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the named location. Graph refuses to delete named locations used by
+// conditional access policies.
+func (s *Service) Delete(ctx context.Context, locationID string) error {
+	if err := s.sdk.Identity().ConditionalAccess().NamedLocations().ByNamedLocationId(locationID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete named location by ID: %w", err)
+	}
+	return nil
+}
+
+// Graph requires the type of named locations in the request body, the SDK models do not set it
+var (
+	ipNamedLocationType      = "#microsoft.graph.ipNamedLocation"
+	countryNamedLocationType = "#microsoft.graph.countryNamedLocation"
+)
+
+func namedLocationModel(location NamedLocation) (models.NamedLocationable, error) {
+	switch location.Type {
+	case TypeIP:
+		ranges := make([]models.IpRangeable, 0, len(location.IPRanges))
+		for _, prefix := range location.IPRanges {
+			cidr := prefix.String()
+			if prefix.Addr().Is4() {
+				ipRange := models.NewIPv4CidrRange()
+				ipRange.SetCidrAddress(&cidr)
+				ranges = append(ranges, ipRange)
+			} else {
+				ipRange := models.NewIPv6CidrRange()
+				ipRange.SetCidrAddress(&cidr)
+				ranges = append(ranges, ipRange)
+			}
+		}
+		body := models.NewIpNamedLocation()
+		body.SetOdataType(&ipNamedLocationType)
+		body.SetDisplayName(&location.DisplayName)
+		body.SetIpRanges(ranges)
+		body.SetIsTrusted(&location.IsTrusted)
+		return body, nil
+	case TypeCountry:
+		parsed, err := models.ParseCountryLookupMethodType(location.CountryLookupMethod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid country lookup method: %w", err)
+		}
+		body := models.NewCountryNamedLocation()
+		body.SetOdataType(&countryNamedLocationType)
+		body.SetDisplayName(&location.DisplayName)
+		body.SetCountriesAndRegions(location.CountriesAndRegions)
+		body.SetIncludeUnknownCountriesAndRegions(&location.IncludeUnknownCountriesAndRegions)
+		body.SetCountryLookupMethod(parsed.(*models.CountryLookupMethodType))
+		return body, nil
+	}
+	return nil, fmt.Errorf("unsupported named location type %q", location.Type)
+}
+
+func namedLocationResponse(location models.NamedLocationable) *NamedLocation {
+	response := &NamedLocation{}
+	if location.GetId() != nil {
+		response.ID = *location.GetId()
+	}
+	if location.GetDisplayName() != nil {
+		response.DisplayName = *location.GetDisplayName()
+	}
+
+	switch location := location.(type) {
+	case models.IpNamedLocationable:
+		response.Type = TypeIP
+		for _, ipRange := range location.GetIpRanges() {
+			var cidr *string
+			switch ipRange := ipRange.(type) {
+			case models.IPv4CidrRangeable:
+				cidr = ipRange.GetCidrAddress()
+			case models.IPv6CidrRangeable:
+				cidr = ipRange.GetCidrAddress()
+			}
+			if cidr == nil {
+				continue
+			}
+			// ranges that cannot be parsed are left out, the location is then updated
+			if prefix, err := netip.ParsePrefix(*cidr); err == nil {
+				response.IPRanges = append(response.IPRanges, prefix)
+			}
+		}
+		if location.GetIsTrusted() != nil {
+			response.IsTrusted = *location.GetIsTrusted()
+		}
+	case models.CountryNamedLocationable:
+		response.Type = TypeCountry
+		response.CountriesAndRegions = location.GetCountriesAndRegions()
+		if location.GetIncludeUnknownCountriesAndRegions() != nil {
+			response.IncludeUnknownCountriesAndRegions = *location.GetIncludeUnknownCountriesAndRegions()
+		}
+		if location.GetCountryLookupMethod() != nil {
+			response.CountryLookupMethod = location.GetCountryLookupMethod().String()
+		}
+	}
+	return response
+}
@@ -0,0 +1,49 @@
+package namedlocations
+
+import (
+	"context"
+	"net/netip"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+const (
+	// TypeIP named locations are IP ranges, TypeCountry named locations are countries and regions
+	TypeIP      = "ip"
+	TypeCountry = "country"
+)
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+// NamedLocation is an IP or a country named location, the properties of the other type are
+// empty.
+type NamedLocation struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+
+	IPRanges  []netip.Prefix `json:"ipRanges"`
+	IsTrusted bool           `json:"isTrusted"`
+
+	CountriesAndRegions               []string `json:"countriesAndRegions"`
+	IncludeUnknownCountriesAndRegions bool     `json:"includeUnknownCountriesAndRegions"`
+	CountryLookupMethod               string   `json:"countryLookupMethod"`
+}
+
+type NamedLocationGetResponse struct {
+	NamedLocation
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	Get(ctx context.Context, locationID string) (*NamedLocationGetResponse, error)
+	Create(ctx context.Context, location NamedLocation) (*NamedLocation, error)
+	Update(ctx context.Context, locationID string, location NamedLocation) error
+	Delete(ctx context.Context, locationID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
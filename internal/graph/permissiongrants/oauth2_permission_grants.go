@@ -0,0 +1,95 @@
+package permissiongrants
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/oauth2permissiongrants"
+)
+
+// ListByClient returns the delegated permission grants of the client service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/oauth2permissiongrant-list?view=graph-rest-1.0&tabs=http
+func (s *Service) ListByClient(ctx context.Context, clientID string) ([]OAuth2PermissionGrantResponse, error) {
+	filter := fmt.Sprintf("clientId eq '%s'", strings.ReplaceAll(clientID, "'", "''"))
+	resp, err := s.sdk.Oauth2PermissionGrants().Get(ctx, &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delegated permission grants: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.OAuth2PermissionGrantable](resp, s.sdk.GetAdapter(), models.CreateOAuth2PermissionGrantCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var grants []OAuth2PermissionGrantResponse
+	err = iterator.Iterate(ctx, func(grant models.OAuth2PermissionGrantable) bool {
+		grants = append(grants, *grantResponse(grant))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through delegated permission grants: %w", err)
+	}
+	return grants, nil
+}
+
+// Create consents to the delegated scopes of the resource for the client on behalf of all users.
+// api doc: https://learn.microsoft.com/en-us/graph/api/oauth2permissiongrant-post?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, clientID string, resourceID string, scope string) (*OAuth2PermissionGrantResponse, error) {
+	consentType := ConsentTypeAllPrincipals
+	grant := models.NewOAuth2PermissionGrant()
+	grant.SetClientId(&clientID)
+	grant.SetResourceId(&resourceID)
+	grant.SetConsentType(&consentType)
+	grant.SetScope(&scope)
+
+	resp, err := s.sdk.Oauth2PermissionGrants().Post(ctx, grant, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delegated permission grant: %w", err)
+	}
+	return grantResponse(resp), nil
+}
+
+// Update replaces the delegated scopes of the grant.
+// api doc: https://learn.microsoft.com/en-us/graph/api/oauth2permissiongrant-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, grantID string, scope string) error {
+	grant := models.NewOAuth2PermissionGrant()
+	grant.SetScope(&scope)
+
+	if _, err := s.sdk.Oauth2PermissionGrants().ByOAuth2PermissionGrantId(grantID).Patch(ctx, grant, nil); err != nil {
+		return fmt.Errorf("failed to update delegated permission grant: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, grantID string) error {
+	if err := s.sdk.Oauth2PermissionGrants().ByOAuth2PermissionGrantId(grantID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete delegated permission grant: %w", err)
+	}
+	return nil
+}
+
+func grantResponse(grant models.OAuth2PermissionGrantable) *OAuth2PermissionGrantResponse {
+	response := &OAuth2PermissionGrantResponse{}
+	if grant.GetId() != nil {
+		response.ID = *grant.GetId()
+	}
+	if grant.GetClientId() != nil {
+		response.ClientID = *grant.GetClientId()
+	}
+	if grant.GetResourceId() != nil {
+		response.ResourceID = *grant.GetResourceId()
+	}
+	if grant.GetConsentType() != nil {
+		response.ConsentType = *grant.GetConsentType()
+	}
+	if grant.GetScope() != nil {
+		response.Scope = *grant.GetScope()
+	}
+	return response
+}
@@ -0,0 +1,33 @@
+package permissiongrants
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// ConsentTypeAllPrincipals marks delegated permission grants consented on behalf of all users.
+const ConsentTypeAllPrincipals = "AllPrincipals"
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+type OAuth2PermissionGrantResponse struct {
+	ID          string `json:"id"`
+	ClientID    string `json:"clientId"`
+	ResourceID  string `json:"resourceId"`
+	ConsentType string `json:"consentType"`
+	Scope       string `json:"scope"`
+}
+
+type API interface {
+	ListByClient(ctx context.Context, clientID string) ([]OAuth2PermissionGrantResponse, error)
+	Create(ctx context.Context, clientID string, resourceID string, scope string) (*OAuth2PermissionGrantResponse, error)
+	Update(ctx context.Context, grantID string, scope string) error
+	Delete(ctx context.Context, grantID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
@@ -0,0 +1,128 @@
+package pim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	graphig "github.com/microsoftgraph/msgraph-sdk-go/identitygovernance"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CreateGroupEligibilityRequest submits a request for the eligibility of a principal for the
+// membership or ownership of a group managed by PIM for groups.
+// api doc: https://learn.microsoft.com/en-us/graph/api/privilegedaccessgroup-post-eligibilityschedulerequests?view=graph-rest-1.0&tabs=http
+func (s *Service) CreateGroupEligibilityRequest(ctx context.Context, request EligibilityRequest) (*ScheduleRequestResponse, error) {
+	action, err := models.ParseScheduleRequestActions(request.Action)
+	if err != nil || action == nil {
+		return nil, fmt.Errorf("unsupported schedule request action %q", request.Action)
+	}
+	accessID, err := models.ParsePrivilegedAccessGroupRelationships(request.AccessID)
+	if err != nil || accessID == nil {
+		return nil, fmt.Errorf("unsupported group access %q", request.AccessID)
+	}
+
+	body := models.NewPrivilegedAccessGroupEligibilityScheduleRequest()
+	body.SetAction(action.(*models.ScheduleRequestActions))
+	body.SetAccessId(accessID.(*models.PrivilegedAccessGroupRelationships))
+	body.SetPrincipalId(&request.PrincipalID)
+	body.SetGroupId(&request.GroupID)
+	if request.Justification != "" {
+		body.SetJustification(&request.Justification)
+	}
+	if request.Action != ActionAdminRemove {
+		body.SetScheduleInfo(scheduleInfo(request))
+	}
+
+	resp, err := s.sdk.IdentityGovernance().PrivilegedAccess().Group().EligibilityScheduleRequests().Post(ctx, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group eligibility schedule request: %w", err)
+	}
+
+	response := &ScheduleRequestResponse{}
+	if resp.GetId() != nil {
+		response.ID = *resp.GetId()
+	}
+	if resp.GetStatus() != nil {
+		response.Status = *resp.GetStatus()
+	}
+	if resp.GetTargetScheduleId() != nil {
+		response.TargetScheduleID = *resp.GetTargetScheduleId()
+	}
+	return response, nil
+}
+
+// GetGroupEligibilitySchedule returns the eligibility schedule of a group access, with the HTTP
+// status code of Graph when it fails.
+// api doc: https://learn.microsoft.com/en-us/graph/api/privilegedaccessgroupeligibilityschedule-get?view=graph-rest-1.0&tabs=http
+func (s *Service) GetGroupEligibilitySchedule(ctx context.Context, scheduleID string) (*EligibilityScheduleGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if scheduleID == "" {
+		return nil, fmt.Errorf("group eligibility schedule id is empty")
+	}
+
+	resp, err := s.sdk.IdentityGovernance().PrivilegedAccess().Group().EligibilitySchedules().ByPrivilegedAccessGroupEligibilityScheduleId(scheduleID).Get(ctx, nil)
+	if err != nil {
+		response := &EligibilityScheduleGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get group eligibility schedule", "scheduleID", scheduleID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get group eligibility schedule %w", err)
+	}
+
+	return &EligibilityScheduleGetResponse{EligibilitySchedule: groupEligibilitySchedule(resp), HttpStatusCode: "200"}, nil
+}
+
+// ListGroupEligibilitySchedules returns the eligibility schedules of the group.
+// api doc: https://learn.microsoft.com/en-us/graph/api/privilegedaccessgroup-list-eligibilityschedules?view=graph-rest-1.0&tabs=http
+func (s *Service) ListGroupEligibilitySchedules(ctx context.Context, groupID string) ([]EligibilitySchedule, error) {
+	filter := fmt.Sprintf("groupId eq '%s'", strings.ReplaceAll(groupID, "'", "''"))
+	resp, err := s.sdk.IdentityGovernance().PrivilegedAccess().Group().EligibilitySchedules().Get(ctx, &graphig.PrivilegedAccessGroupEligibilitySchedulesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphig.PrivilegedAccessGroupEligibilitySchedulesRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group eligibility schedules: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.PrivilegedAccessGroupEligibilityScheduleable](resp, s.sdk.GetAdapter(), models.CreatePrivilegedAccessGroupEligibilityScheduleCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var schedules []EligibilitySchedule
+	err = iterator.Iterate(ctx, func(schedule models.PrivilegedAccessGroupEligibilityScheduleable) bool {
+		schedules = append(schedules, groupEligibilitySchedule(schedule))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through group eligibility schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func groupEligibilitySchedule(schedule models.PrivilegedAccessGroupEligibilityScheduleable) EligibilitySchedule {
+	result := EligibilitySchedule{}
+	if schedule.GetId() != nil {
+		result.ID = *schedule.GetId()
+	}
+	if schedule.GetPrincipalId() != nil {
+		result.PrincipalID = *schedule.GetPrincipalId()
+	}
+	if schedule.GetGroupId() != nil {
+		result.GroupID = *schedule.GetGroupId()
+	}
+	if schedule.GetAccessId() != nil {
+		result.AccessID = schedule.GetAccessId().String()
+	}
+	if schedule.GetStatus() != nil {
+		result.Status = *schedule.GetStatus()
+	}
+	result.StartDateTime, result.EndDateTime = scheduleTimes(schedule.GetScheduleInfo())
+	return result
+}
@@ -0,0 +1,84 @@
+package pim
+
+import (
+	"context"
+	"time"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// Schedule request actions of Privileged Identity Management.
+const (
+	ActionAdminAssign = "adminAssign"
+	ActionAdminUpdate = "adminUpdate"
+	ActionAdminRemove = "adminRemove"
+	ActionAdminExtend = "adminExtend"
+)
+
+// Access IDs of PIM for groups eligibilities.
+const (
+	AccessMember = "member"
+	AccessOwner  = "owner"
+)
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+// EligibilityRequest asks PIM to make a principal eligible for a directory role, or for the
+// membership or ownership of a group, or to change or remove that eligibility.
+type EligibilityRequest struct {
+	Action        string
+	PrincipalID   string
+	Justification string
+	// RoleDefinitionID and DirectoryScopeID select the directory role.
+	RoleDefinitionID string
+	DirectoryScopeID string
+	// GroupID and AccessID select the group access.
+	GroupID  string
+	AccessID string
+	// StartDateTime is the start of the eligibility and EndDateTime its end, the eligibility
+	// does not expire when nil.
+	StartDateTime time.Time
+	EndDateTime   *time.Time
+}
+
+// ScheduleRequestResponse is a submitted schedule request.
+type ScheduleRequestResponse struct {
+	ID string `json:"id"`
+	// Status of the request, e.g. Provisioned, PendingApproval or Denied.
+	Status string `json:"status"`
+	// TargetScheduleID is the eligibility schedule created or changed by the request.
+	TargetScheduleID string `json:"targetScheduleId"`
+}
+
+// EligibilitySchedule is the eligibility of a principal for a directory role or group access.
+type EligibilitySchedule struct {
+	ID               string     `json:"id"`
+	PrincipalID      string     `json:"principalId"`
+	RoleDefinitionID string     `json:"roleDefinitionId,omitempty"`
+	DirectoryScopeID string     `json:"directoryScopeId,omitempty"`
+	GroupID          string     `json:"groupId,omitempty"`
+	AccessID         string     `json:"accessId,omitempty"`
+	Status           string     `json:"status"`
+	StartDateTime    *time.Time `json:"startDateTime,omitempty"`
+	EndDateTime      *time.Time `json:"endDateTime,omitempty"`
+}
+
+type EligibilityScheduleGetResponse struct {
+	EligibilitySchedule
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	CreateRoleEligibilityRequest(ctx context.Context, request EligibilityRequest) (*ScheduleRequestResponse, error)
+	GetRoleEligibilitySchedule(ctx context.Context, scheduleID string) (*EligibilityScheduleGetResponse, error)
+	ListRoleEligibilitySchedules(ctx context.Context, principalID string) ([]EligibilitySchedule, error)
+	CreateGroupEligibilityRequest(ctx context.Context, request EligibilityRequest) (*ScheduleRequestResponse, error)
+	GetGroupEligibilitySchedule(ctx context.Context, scheduleID string) (*EligibilityScheduleGetResponse, error)
+	ListGroupEligibilitySchedules(ctx context.Context, groupID string) ([]EligibilitySchedule, error)
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
@@ -0,0 +1,124 @@
+package pim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphrm "github.com/microsoftgraph/msgraph-sdk-go/rolemanagement"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CreateRoleEligibilityRequest submits a request for the eligibility of a principal for a
+// directory role.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-post-roleeligibilityschedulerequests?view=graph-rest-1.0&tabs=http
+func (s *Service) CreateRoleEligibilityRequest(ctx context.Context, request EligibilityRequest) (*ScheduleRequestResponse, error) {
+	action, err := models.ParseUnifiedRoleScheduleRequestActions(request.Action)
+	if err != nil || action == nil {
+		return nil, fmt.Errorf("unsupported schedule request action %q", request.Action)
+	}
+
+	body := models.NewUnifiedRoleEligibilityScheduleRequest()
+	body.SetAction(action.(*models.UnifiedRoleScheduleRequestActions))
+	body.SetPrincipalId(&request.PrincipalID)
+	body.SetRoleDefinitionId(&request.RoleDefinitionID)
+	body.SetDirectoryScopeId(&request.DirectoryScopeID)
+	if request.Justification != "" {
+		body.SetJustification(&request.Justification)
+	}
+	if request.Action != ActionAdminRemove {
+		body.SetScheduleInfo(scheduleInfo(request))
+	}
+
+	resp, err := s.sdk.RoleManagement().Directory().RoleEligibilityScheduleRequests().Post(ctx, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role eligibility schedule request: %w", err)
+	}
+
+	response := &ScheduleRequestResponse{}
+	if resp.GetId() != nil {
+		response.ID = *resp.GetId()
+	}
+	if resp.GetStatus() != nil {
+		response.Status = *resp.GetStatus()
+	}
+	if resp.GetTargetScheduleId() != nil {
+		response.TargetScheduleID = *resp.GetTargetScheduleId()
+	}
+	return response, nil
+}
+
+// GetRoleEligibilitySchedule returns the eligibility schedule of a directory role, with the HTTP
+// status code of Graph when it fails.
+// api doc: https://learn.microsoft.com/en-us/graph/api/unifiedroleeligibilityschedule-get?view=graph-rest-1.0&tabs=http
+func (s *Service) GetRoleEligibilitySchedule(ctx context.Context, scheduleID string) (*EligibilityScheduleGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if scheduleID == "" {
+		return nil, fmt.Errorf("role eligibility schedule id is empty")
+	}
+
+	resp, err := s.sdk.RoleManagement().Directory().RoleEligibilitySchedules().ByUnifiedRoleEligibilityScheduleId(scheduleID).Get(ctx, nil)
+	if err != nil {
+		response := &EligibilityScheduleGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get role eligibility schedule", "scheduleID", scheduleID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get role eligibility schedule %w", err)
+	}
+
+	return &EligibilityScheduleGetResponse{EligibilitySchedule: roleEligibilitySchedule(resp), HttpStatusCode: "200"}, nil
+}
+
+// ListRoleEligibilitySchedules returns the directory role eligibility schedules of the principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-list-roleeligibilityschedules?view=graph-rest-1.0&tabs=http
+func (s *Service) ListRoleEligibilitySchedules(ctx context.Context, principalID string) ([]EligibilitySchedule, error) {
+	filter := fmt.Sprintf("principalId eq '%s'", strings.ReplaceAll(principalID, "'", "''"))
+	resp, err := s.sdk.RoleManagement().Directory().RoleEligibilitySchedules().Get(ctx, &graphrm.DirectoryRoleEligibilitySchedulesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphrm.DirectoryRoleEligibilitySchedulesRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role eligibility schedules: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.UnifiedRoleEligibilityScheduleable](resp, s.sdk.GetAdapter(), models.CreateUnifiedRoleEligibilityScheduleCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var schedules []EligibilitySchedule
+	err = iterator.Iterate(ctx, func(schedule models.UnifiedRoleEligibilityScheduleable) bool {
+		schedules = append(schedules, roleEligibilitySchedule(schedule))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through role eligibility schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+func roleEligibilitySchedule(schedule models.UnifiedRoleEligibilityScheduleable) EligibilitySchedule {
+	result := EligibilitySchedule{}
+	if schedule.GetId() != nil {
+		result.ID = *schedule.GetId()
+	}
+	if schedule.GetPrincipalId() != nil {
+		result.PrincipalID = *schedule.GetPrincipalId()
+	}
+	if schedule.GetRoleDefinitionId() != nil {
+		result.RoleDefinitionID = *schedule.GetRoleDefinitionId()
+	}
+	if schedule.GetDirectoryScopeId() != nil {
+		result.DirectoryScopeID = *schedule.GetDirectoryScopeId()
+	}
+	if schedule.GetStatus() != nil {
+		result.Status = *schedule.GetStatus()
+	}
+	result.StartDateTime, result.EndDateTime = scheduleTimes(schedule.GetScheduleInfo())
+	return result
+}
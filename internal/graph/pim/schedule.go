@@ -0,0 +1,38 @@
+package pim
+
+import (
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// scheduleInfo converts the start and end of an eligibility request to a request schedule.
+func scheduleInfo(request EligibilityRequest) models.RequestScheduleable {
+	info := models.NewRequestSchedule()
+	start := request.StartDateTime
+	info.SetStartDateTime(&start)
+
+	expiration := models.NewExpirationPattern()
+	expirationType := models.NOEXPIRATION_EXPIRATIONPATTERNTYPE
+	if request.EndDateTime != nil {
+		expirationType = models.AFTERDATETIME_EXPIRATIONPATTERNTYPE
+		end := *request.EndDateTime
+		expiration.SetEndDateTime(&end)
+	}
+	expiration.SetTypeEscaped(&expirationType)
+	info.SetExpiration(expiration)
+	return info
+}
+
+// scheduleTimes returns the start and end of a request schedule, the end is nil for schedules
+// that do not expire.
+func scheduleTimes(info models.RequestScheduleable) (start *time.Time, end *time.Time) {
+	if info == nil {
+		return nil, nil
+	}
+	start = info.GetStartDateTime()
+	if expiration := info.GetExpiration(); expiration != nil {
+		end = expiration.GetEndDateTime()
+	}
+	return start, end
+}
@@ -0,0 +1,104 @@
+package rolemanagement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphrm "github.com/microsoftgraph/msgraph-sdk-go/rolemanagement"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GetRoleAssignment returns the directory role assignment, with the HTTP status code of Graph
+// when it fails.
+// api doc: https://learn.microsoft.com/en-us/graph/api/unifiedroleassignment-get?view=graph-rest-1.0&tabs=http
+func (s *Service) GetRoleAssignment(ctx context.Context, assignmentID string) (*RoleAssignmentGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if assignmentID == "" {
+		return nil, fmt.Errorf("role assignment id is empty")
+	}
+
+	resp, err := s.sdk.RoleManagement().Directory().RoleAssignments().ByUnifiedRoleAssignmentId(assignmentID).Get(ctx, nil)
+	if err != nil {
+		response := &RoleAssignmentGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get role assignment", "assignmentID", assignmentID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get role assignment %w", err)
+	}
+
+	return &RoleAssignmentGetResponse{RoleAssignmentResponse: *roleAssignmentResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// ListRoleAssignments returns the directory role assignments of the principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-list-roleassignments?view=graph-rest-1.0&tabs=http
+func (s *Service) ListRoleAssignments(ctx context.Context, principalID string) ([]RoleAssignmentResponse, error) {
+	filter := fmt.Sprintf("principalId eq '%s'", strings.ReplaceAll(principalID, "'", "''"))
+	resp, err := s.sdk.RoleManagement().Directory().RoleAssignments().Get(ctx, &graphrm.DirectoryRoleAssignmentsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphrm.DirectoryRoleAssignmentsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role assignments: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.UnifiedRoleAssignmentable](resp, s.sdk.GetAdapter(), models.CreateUnifiedRoleAssignmentCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var assignments []RoleAssignmentResponse
+	err = iterator.Iterate(ctx, func(assignment models.UnifiedRoleAssignmentable) bool {
+		assignments = append(assignments, *roleAssignmentResponse(assignment))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// CreateRoleAssignment assigns the directory role to the principal at the directory scope, "/"
+// for the tenant or /administrativeUnits/{id}.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-post-roleassignments?view=graph-rest-1.0&tabs=http
+func (s *Service) CreateRoleAssignment(ctx context.Context, principalID string, roleDefinitionID string, directoryScopeID string) (*RoleAssignmentResponse, error) {
+	assignment := models.NewUnifiedRoleAssignment()
+	assignment.SetPrincipalId(&principalID)
+	assignment.SetRoleDefinitionId(&roleDefinitionID)
+	assignment.SetDirectoryScopeId(&directoryScopeID)
+
+	resp, err := s.sdk.RoleManagement().Directory().RoleAssignments().Post(ctx, assignment, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role assignment: %w", err)
+	}
+	return roleAssignmentResponse(resp), nil
+}
+
+func (s *Service) DeleteRoleAssignment(ctx context.Context, assignmentID string) error {
+	if err := s.sdk.RoleManagement().Directory().RoleAssignments().ByUnifiedRoleAssignmentId(assignmentID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete role assignment: %w", err)
+	}
+	return nil
+}
+
+func roleAssignmentResponse(assignment models.UnifiedRoleAssignmentable) *RoleAssignmentResponse {
+	response := &RoleAssignmentResponse{}
+	if assignment.GetId() != nil {
+		response.ID = *assignment.GetId()
+	}
+	if assignment.GetPrincipalId() != nil {
+		response.PrincipalID = *assignment.GetPrincipalId()
+	}
+	if assignment.GetRoleDefinitionId() != nil {
+		response.RoleDefinitionID = *assignment.GetRoleDefinitionId()
+	}
+	if assignment.GetDirectoryScopeId() != nil {
+		response.DirectoryScopeID = *assignment.GetDirectoryScopeId()
+	}
+	return response
+}
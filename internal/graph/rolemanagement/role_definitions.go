@@ -0,0 +1,63 @@
+package rolemanagement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	graphrm "github.com/microsoftgraph/msgraph-sdk-go/rolemanagement"
+)
+
+// GetRoleDefinitionByTemplateID returns the directory role with the template ID, nil when there
+// is none. Built-in roles have the same template ID in every tenant.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-list-roledefinitions?view=graph-rest-1.0&tabs=http
+func (s *Service) GetRoleDefinitionByTemplateID(ctx context.Context, templateID string) (*RoleDefinitionResponse, error) {
+	return s.findRoleDefinition(ctx, "templateId", templateID)
+}
+
+// GetRoleDefinitionByDisplayName returns the directory role with the display name, nil when
+// there is none.
+// api doc: https://learn.microsoft.com/en-us/graph/api/rbacapplication-list-roledefinitions?view=graph-rest-1.0&tabs=http
+func (s *Service) GetRoleDefinitionByDisplayName(ctx context.Context, displayName string) (*RoleDefinitionResponse, error) {
+	return s.findRoleDefinition(ctx, "displayName", displayName)
+}
+
+func (s *Service) findRoleDefinition(ctx context.Context, property, value string) (*RoleDefinitionResponse, error) {
+	filter := fmt.Sprintf("%s eq '%s'", property, strings.ReplaceAll(value, "'", "''"))
+	resp, err := s.sdk.RoleManagement().Directory().RoleDefinitions().Get(ctx, &graphrm.DirectoryRoleDefinitionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphrm.DirectoryRoleDefinitionsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role definition by %s: %w", property, err)
+	}
+
+	for _, definition := range resp.GetValue() {
+		if definition.GetId() != nil {
+			return roleDefinitionResponse(definition), nil
+		}
+	}
+	return nil, nil
+}
+
+func roleDefinitionResponse(definition models.UnifiedRoleDefinitionable) *RoleDefinitionResponse {
+	response := &RoleDefinitionResponse{}
+	if definition.GetId() != nil {
+		response.ID = *definition.GetId()
+	}
+	if definition.GetTemplateId() != nil {
+		response.TemplateID = *definition.GetTemplateId()
+	}
+	if definition.GetDisplayName() != nil {
+		response.DisplayName = *definition.GetDisplayName()
+	}
+	if definition.GetIsBuiltIn() != nil {
+		response.IsBuiltIn = *definition.GetIsBuiltIn()
+	}
+	if definition.GetIsEnabled() != nil {
+		response.IsEnabled = *definition.GetIsEnabled()
+	}
+	return response
+}
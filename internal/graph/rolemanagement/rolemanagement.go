@@ -0,0 +1,48 @@
+package rolemanagement
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// DirectoryScopeTenant is the directory scope of role assignments applying to the whole tenant.
+const DirectoryScopeTenant = "/"
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+// RoleDefinitionResponse is a built-in or custom Entra directory role.
+type RoleDefinitionResponse struct {
+	ID          string `json:"id"`
+	TemplateID  string `json:"templateId"`
+	DisplayName string `json:"displayName"`
+	IsBuiltIn   bool   `json:"isBuiltIn"`
+	IsEnabled   bool   `json:"isEnabled"`
+}
+
+type RoleAssignmentResponse struct {
+	ID               string `json:"id"`
+	PrincipalID      string `json:"principalId"`
+	RoleDefinitionID string `json:"roleDefinitionId"`
+	DirectoryScopeID string `json:"directoryScopeId"`
+}
+
+type RoleAssignmentGetResponse struct {
+	RoleAssignmentResponse
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	GetRoleDefinitionByTemplateID(ctx context.Context, templateID string) (*RoleDefinitionResponse, error)
+	GetRoleDefinitionByDisplayName(ctx context.Context, displayName string) (*RoleDefinitionResponse, error)
+	GetRoleAssignment(ctx context.Context, assignmentID string) (*RoleAssignmentGetResponse, error)
+	ListRoleAssignments(ctx context.Context, principalID string) ([]RoleAssignmentResponse, error)
+	CreateRoleAssignment(ctx context.Context, principalID string, roleDefinitionID string, directoryScopeID string) (*RoleAssignmentResponse, error)
+	DeleteRoleAssignment(ctx context.Context, assignmentID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
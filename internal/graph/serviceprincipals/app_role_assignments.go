@@ -0,0 +1,192 @@
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphsp "github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultAccessAppRoleID is the app role ID assigning a principal to an application that
+// declares no app roles.
+const DefaultAccessAppRoleID = "00000000-0000-0000-0000-000000000000"
+
+// AppRoles returns the app roles of the service principal, inherited from its application.
+func (s *Service) AppRoles(ctx context.Context, servicePrincipalID string) ([]AppRole, error) {
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Get(ctx, &graphsp.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphsp.ServicePrincipalItemRequestBuilderGetQueryParameters{
+			Select: []string{"id", "appRoles"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app roles of service principal: %w", err)
+	}
+
+	roles := make([]AppRole, 0, len(resp.GetAppRoles()))
+	for _, role := range resp.GetAppRoles() {
+		if role.GetId() == nil {
+			continue
+		}
+		appRole := AppRole{ID: role.GetId().String(), AllowedMemberTypes: role.GetAllowedMemberTypes()}
+		if role.GetValue() != nil {
+			appRole.Value = *role.GetValue()
+		}
+		if role.GetIsEnabled() != nil {
+			appRole.IsEnabled = *role.GetIsEnabled()
+		}
+		roles = append(roles, appRole)
+	}
+	return roles, nil
+}
+
+// OAuth2PermissionScopes returns the values of the enabled delegated permissions published by
+// the service principal.
+func (s *Service) OAuth2PermissionScopes(ctx context.Context, servicePrincipalID string) ([]string, error) {
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Get(ctx, &graphsp.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphsp.ServicePrincipalItemRequestBuilderGetQueryParameters{
+			Select: []string{"id", "oauth2PermissionScopes"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegated permissions of service principal: %w", err)
+	}
+
+	var scopes []string
+	for _, scope := range resp.GetOauth2PermissionScopes() {
+		if scope.GetValue() == nil || (scope.GetIsEnabled() != nil && !*scope.GetIsEnabled()) {
+			continue
+		}
+		scopes = append(scopes, *scope.GetValue())
+	}
+	return scopes, nil
+}
+
+// GetAppRoleAssignment returns an app role assignment granted for the resource service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list-approleassignedto?view=graph-rest-1.0&tabs=http
+func (s *Service) GetAppRoleAssignment(ctx context.Context, resourceID string, assignmentID string) (*AppRoleAssignmentGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if assignmentID == "" {
+		return nil, fmt.Errorf("app role assignment id is empty")
+	}
+
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(resourceID).AppRoleAssignedTo().ByAppRoleAssignmentId(assignmentID).Get(ctx, nil)
+	if err != nil {
+		response := &AppRoleAssignmentGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get app role assignment", "resourceID", resourceID, "assignmentID", assignmentID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get app role assignment %w", err)
+	}
+
+	return &AppRoleAssignmentGetResponse{AppRoleAssignmentResponse: *appRoleAssignmentResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// ListAppRoleAssignedTo returns the app role assignments granted for the resource service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list-approleassignedto?view=graph-rest-1.0&tabs=http
+func (s *Service) ListAppRoleAssignedTo(ctx context.Context, resourceID string) ([]AppRoleAssignmentResponse, error) {
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(resourceID).AppRoleAssignedTo().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app role assignments: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.AppRoleAssignmentable](resp, s.sdk.GetAdapter(), models.CreateAppRoleAssignmentCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var assignments []AppRoleAssignmentResponse
+	err = iterator.Iterate(ctx, func(assignment models.AppRoleAssignmentable) bool {
+		assignments = append(assignments, *appRoleAssignmentResponse(assignment))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through app role assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// ListAppRoleAssignments returns the app role assignments granted to the service principal,
+// e.g. its application permissions.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list-approleassignments?view=graph-rest-1.0&tabs=http
+func (s *Service) ListAppRoleAssignments(ctx context.Context, principalID string) ([]AppRoleAssignmentResponse, error) {
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(principalID).AppRoleAssignments().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app role assignments of service principal: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.AppRoleAssignmentable](resp, s.sdk.GetAdapter(), models.CreateAppRoleAssignmentCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var assignments []AppRoleAssignmentResponse
+	err = iterator.Iterate(ctx, func(assignment models.AppRoleAssignmentable) bool {
+		assignments = append(assignments, *appRoleAssignmentResponse(assignment))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through app role assignments of service principal: %w", err)
+	}
+	return assignments, nil
+}
+
+// CreateAppRoleAssignment assigns the app role of the resource service principal to a user,
+// group or service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-post-approleassignedto?view=graph-rest-1.0&tabs=http
+func (s *Service) CreateAppRoleAssignment(ctx context.Context, resourceID string, principalID string, appRoleID string) (*AppRoleAssignmentResponse, error) {
+	principal, err := uuid.Parse(principalID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid principal id %q: %w", principalID, err)
+	}
+	resource, err := uuid.Parse(resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource id %q: %w", resourceID, err)
+	}
+	appRole, err := uuid.Parse(appRoleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app role id %q: %w", appRoleID, err)
+	}
+
+	assignment := models.NewAppRoleAssignment()
+	assignment.SetPrincipalId(&principal)
+	assignment.SetResourceId(&resource)
+	assignment.SetAppRoleId(&appRole)
+
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(resourceID).AppRoleAssignedTo().Post(ctx, assignment, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app role assignment: %w", err)
+	}
+	return appRoleAssignmentResponse(resp), nil
+}
+
+func (s *Service) DeleteAppRoleAssignment(ctx context.Context, resourceID string, assignmentID string) error {
+	if err := s.sdk.ServicePrincipals().ByServicePrincipalId(resourceID).AppRoleAssignedTo().ByAppRoleAssignmentId(assignmentID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete app role assignment: %w", err)
+	}
+	return nil
+}
+
+func appRoleAssignmentResponse(assignment models.AppRoleAssignmentable) *AppRoleAssignmentResponse {
+	response := &AppRoleAssignmentResponse{}
+	if assignment.GetId() != nil {
+		response.ID = *assignment.GetId()
+	}
+	if assignment.GetPrincipalId() != nil {
+		response.PrincipalID = assignment.GetPrincipalId().String()
+	}
+	if assignment.GetPrincipalType() != nil {
+		response.PrincipalType = *assignment.GetPrincipalType()
+	}
+	if assignment.GetResourceId() != nil {
+		response.ResourceID = assignment.GetResourceId().String()
+	}
+	if assignment.GetAppRoleId() != nil {
+		response.AppRoleID = assignment.GetAppRoleId().String()
+	}
+	return response
+}
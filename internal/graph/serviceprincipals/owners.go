@@ -0,0 +1,69 @@
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphsp "github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+// ListOwners returns the object IDs of the owners of the service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list-owners?view=graph-rest-1.0&tabs=http
+func (s *Service) ListOwners(ctx context.Context, servicePrincipalID string) ([]string, error) {
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Owners().Get(ctx, &graphsp.ItemOwnersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphsp.ItemOwnersRequestBuilderGetQueryParameters{
+			Select: []string{"id"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service principal owners: %w", err)
+	}
+
+	iterator, err := msgraphcore.NewPageIterator[models.DirectoryObjectable](resp, s.sdk.GetAdapter(), models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	var owners []string
+	err = iterator.Iterate(ctx, func(owner models.DirectoryObjectable) bool {
+		if owner.GetId() != nil {
+			owners = append(owners, *owner.GetId())
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to page through service principal owners: %w", err)
+	}
+	return owners, nil
+}
+
+// AddOwner adds a user or service principal as owner. Owners that are already present are
+// treated as added.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-post-owners?view=graph-rest-1.0&tabs=http
+func (s *Service) AddOwner(ctx context.Context, servicePrincipalID string, ownerID string) error {
+	odataID := fmt.Sprintf("%s/directoryObjects/%s", strings.TrimSuffix(s.sdk.GetAdapter().GetBaseUrl(), "/"), ownerID)
+	ref := models.NewReferenceCreate()
+	ref.SetOdataId(&odataID)
+
+	err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Owners().Ref().Post(ctx, ref, nil)
+	if err != nil && !strings.Contains(err.Error(), "added object references already exist") {
+		return fmt.Errorf("failed to add owner %s to service principal: %w", ownerID, err)
+	}
+	return nil
+}
+
+// RemoveOwner removes an owner. Owners that are no longer present are treated as removed.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-delete-owners?view=graph-rest-1.0&tabs=http
+func (s *Service) RemoveOwner(ctx context.Context, servicePrincipalID string, ownerID string) error {
+	err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Owners().ByDirectoryObjectId(ownerID).Ref().Delete(ctx, nil)
+	if odataErr, ok := err.(*odataerrors.ODataError); ok && odataErr.GetStatusCode() == 404 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove owner %s from service principal: %w", ownerID, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphsp "github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	entraSP "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+var servicePrincipalProperties = []string{"id", "appId", "displayName", "tags", "appRoleAssignmentRequired", "notes"}
+
+func (s *Service) Get(ctx context.Context, servicePrincipalID string) (*ServicePrincipalGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if servicePrincipalID == "" {
+		return nil, fmt.Errorf("service principal id is empty")
+	}
+
+	resp, err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Get(ctx, &graphsp.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphsp.ServicePrincipalItemRequestBuilderGetQueryParameters{
+			Select: servicePrincipalProperties,
+		},
+	})
+	if err != nil {
+		response := &ServicePrincipalGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get service principal", "servicePrincipalID", servicePrincipalID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get service principal %w", err)
+	}
+
+	return &ServicePrincipalGetResponse{ServicePrincipalResponse: *servicePrincipalResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// GetByAppID returns the service principal of the application in the tenant, nil when the
+// application was not instantiated yet.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-list?view=graph-rest-1.0&tabs=http
+func (s *Service) GetByAppID(ctx context.Context, appID string) (*ServicePrincipalResponse, error) {
+	filter := fmt.Sprintf("appId eq '%s'", strings.ReplaceAll(appID, "'", "''"))
+	resp, err := s.sdk.ServicePrincipals().Get(ctx, &graphsp.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphsp.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: &filter,
+			Select: servicePrincipalProperties,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service principal by app id: %w", err)
+	}
+
+	for _, sp := range resp.GetValue() {
+		if sp.GetId() != nil {
+			return servicePrincipalResponse(sp), nil
+		}
+	}
+	return nil, nil
+}
+
+// Create instantiates the service principal of the application in the tenant.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-post-serviceprincipals?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, spSpec entraSP.EntraServicePrincipalSpec) (*ServicePrincipalResponse, error) {
+	sp := servicePrincipal(spSpec)
+	sp.SetAppId(&spSpec.AppID)
+
+	resp, err := s.sdk.ServicePrincipals().Post(ctx, sp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service principal: %w", err)
+	}
+	return servicePrincipalResponse(resp), nil
+}
+
+// Update sets the tags, notes and app role assignment requirement of the service principal.
+// api doc: https://learn.microsoft.com/en-us/graph/api/serviceprincipal-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, servicePrincipalID string, spSpec entraSP.EntraServicePrincipalSpec) error {
+	if _, err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Patch(ctx, servicePrincipal(spSpec), nil); err != nil {
+		return fmt.Errorf("failed to update service principal: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, servicePrincipalID string) error {
+	if err := s.sdk.ServicePrincipals().ByServicePrincipalId(servicePrincipalID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete service principal by ID: %w", err)
+	}
+	return nil
+}
+
+// servicePrincipal returns the properties of the service principal managed by the spec. Tags
+// are left untouched when the spec has none.
+func servicePrincipal(spSpec entraSP.EntraServicePrincipalSpec) *models.ServicePrincipal {
+	sp := models.NewServicePrincipal()
+	sp.SetAppRoleAssignmentRequired(&spSpec.AppRoleAssignmentRequired)
+	sp.SetNotes(&spSpec.Notes)
+	if len(spSpec.Tags) > 0 {
+		sp.SetTags(spSpec.Tags)
+	}
+	return sp
+}
+
+func servicePrincipalResponse(sp models.ServicePrincipalable) *ServicePrincipalResponse {
+	response := &ServicePrincipalResponse{Tags: sp.GetTags()}
+	if sp.GetId() != nil {
+		response.ID = *sp.GetId()
+	}
+	if sp.GetAppId() != nil {
+		response.AppID = *sp.GetAppId()
+	}
+	if sp.GetDisplayName() != nil {
+		response.DisplayName = *sp.GetDisplayName()
+	}
+	if sp.GetAppRoleAssignmentRequired() != nil {
+		response.AppRoleAssignmentRequired = *sp.GetAppRoleAssignmentRequired()
+	}
+	if sp.GetNotes() != nil {
+		response.Notes = *sp.GetNotes()
+	}
+	return response
+}
@@ -0,0 +1,69 @@
+package serviceprincipals
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	entraSP "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+type ServicePrincipalResponse struct {
+	ID                        string   `json:"id"`
+	AppID                     string   `json:"appId"`
+	DisplayName               string   `json:"displayName"`
+	Tags                      []string `json:"tags"`
+	AppRoleAssignmentRequired bool     `json:"appRoleAssignmentRequired"`
+	Notes                     string   `json:"notes"`
+}
+
+type ServicePrincipalGetResponse struct {
+	ServicePrincipalResponse
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+// AppRole is an app role defined by the application of a service principal.
+type AppRole struct {
+	ID                 string   `json:"id"`
+	Value              string   `json:"value"`
+	IsEnabled          bool     `json:"isEnabled"`
+	AllowedMemberTypes []string `json:"allowedMemberTypes"`
+}
+
+type AppRoleAssignmentResponse struct {
+	ID            string `json:"id"`
+	PrincipalID   string `json:"principalId"`
+	PrincipalType string `json:"principalType"`
+	ResourceID    string `json:"resourceId"`
+	AppRoleID     string `json:"appRoleId"`
+}
+
+type AppRoleAssignmentGetResponse struct {
+	AppRoleAssignmentResponse
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type API interface {
+	Get(ctx context.Context, servicePrincipalID string) (*ServicePrincipalGetResponse, error)
+	GetByAppID(ctx context.Context, appID string) (*ServicePrincipalResponse, error)
+	Create(ctx context.Context, spSpec entraSP.EntraServicePrincipalSpec) (*ServicePrincipalResponse, error)
+	Update(ctx context.Context, servicePrincipalID string, spSpec entraSP.EntraServicePrincipalSpec) error
+	Delete(ctx context.Context, servicePrincipalID string) error
+	ListOwners(ctx context.Context, servicePrincipalID string) ([]string, error)
+	AddOwner(ctx context.Context, servicePrincipalID string, ownerID string) error
+	RemoveOwner(ctx context.Context, servicePrincipalID string, ownerID string) error
+	AppRoles(ctx context.Context, servicePrincipalID string) ([]AppRole, error)
+	GetAppRoleAssignment(ctx context.Context, resourceID string, assignmentID string) (*AppRoleAssignmentGetResponse, error)
+	ListAppRoleAssignedTo(ctx context.Context, resourceID string) ([]AppRoleAssignmentResponse, error)
+	ListAppRoleAssignments(ctx context.Context, principalID string) ([]AppRoleAssignmentResponse, error)
+	OAuth2PermissionScopes(ctx context.Context, servicePrincipalID string) ([]string, error)
+	CreateAppRoleAssignment(ctx context.Context, resourceID string, principalID string, appRoleID string) (*AppRoleAssignmentResponse, error)
+	DeleteAppRoleAssignment(ctx context.Context, resourceID string, assignmentID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	entraUser "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// Create creates a cloud-only member user signing in with password.
+// api doc: https://learn.microsoft.com/en-us/graph/api/user-post-users?view=graph-rest-1.0&tabs=http
+func (s *Service) Create(ctx context.Context, userSpec entraUser.EntraUserSpec, password string) (*UserResponse, error) {
+	mailNickname := userSpec.MailNickname
+	if mailNickname == "" {
+		mailNickname, _, _ = strings.Cut(userSpec.UserPrincipalName, "@")
+	}
+	accountEnabled := AccountEnabled(userSpec)
+
+	passwordProfile := models.NewPasswordProfile()
+	passwordProfile.SetPassword(&password)
+	passwordProfile.SetForceChangePasswordNextSignIn(&userSpec.ForceChangePasswordNextSignIn)
+
+	user := models.NewUser()
+	user.SetDisplayName(&userSpec.DisplayName)
+	user.SetUserPrincipalName(&userSpec.UserPrincipalName)
+	user.SetMailNickname(&mailNickname)
+	user.SetAccountEnabled(&accountEnabled)
+	user.SetPasswordProfile(passwordProfile)
+
+	resp, err := s.sdk.Users().Post(ctx, user, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return userResponse(resp), nil
+}
+
+// Invite invites an external user to the tenant as a guest. Inviting a user that was already
+// invited returns the existing guest user.
+// api doc: https://learn.microsoft.com/en-us/graph/api/invitation-post?view=graph-rest-1.0&tabs=http
+func (s *Service) Invite(ctx context.Context, userSpec entraUser.EntraUserSpec) (*InvitationResponse, error) {
+	if userSpec.Invitation == nil {
+		return nil, fmt.Errorf("invitation spec is nil")
+	}
+	spec := userSpec.Invitation
+
+	invitation := models.NewInvitation()
+	invitation.SetInvitedUserEmailAddress(&spec.Email)
+	invitation.SetInvitedUserDisplayName(&userSpec.DisplayName)
+	invitation.SetInviteRedirectUrl(&spec.RedirectURL)
+	invitation.SetSendInvitationMessage(&spec.SendInvitationMessage)
+	if spec.Message != "" {
+		messageInfo := models.NewInvitedUserMessageInfo()
+		messageInfo.SetCustomizedMessageBody(&spec.Message)
+		invitation.SetInvitedUserMessageInfo(messageInfo)
+	}
+
+	resp, err := s.sdk.Invitations().Post(ctx, invitation, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite user: %w", err)
+	}
+	if resp.GetInvitedUser() == nil || resp.GetInvitedUser().GetId() == nil {
+		return nil, fmt.Errorf("invitation response has no invited user")
+	}
+
+	response := &InvitationResponse{UserID: *resp.GetInvitedUser().GetId()}
+	if resp.GetInviteRedeemUrl() != nil {
+		response.RedeemURL = *resp.GetInviteRedeemUrl()
+	}
+	if resp.GetStatus() != nil {
+		response.Status = *resp.GetStatus()
+	}
+	return response, nil
+}
+
+// AccountEnabled returns whether sign-in of the user is enabled by the spec, true by default.
+func AccountEnabled(userSpec entraUser.EntraUserSpec) bool {
+	return userSpec.AccountEnabled == nil || *userSpec.AccountEnabled
+}
@@ -0,0 +1,85 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	graphusers "github.com/microsoftgraph/msgraph-sdk-go/users"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// userProperties are the properties read from users, accountEnabled and externalUserState
+// are only returned when selected.
+var userProperties = []string{"id", "displayName", "userPrincipalName", "accountEnabled", "externalUserState"}
+
+func (s *Service) Get(ctx context.Context, userID string) (*UserGetResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if userID == "" {
+		return nil, fmt.Errorf("user id is empty")
+	}
+
+	resp, err := s.sdk.Users().ByUserId(userID).Get(ctx, &graphusers.UserItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphusers.UserItemRequestBuilderGetQueryParameters{
+			Select: userProperties,
+		},
+	})
+	if err != nil {
+		response := &UserGetResponse{}
+		if odataErr, ok := err.(*odataerrors.ODataError); ok {
+			response.HttpStatusCode = fmt.Sprintf("%d", odataErr.GetStatusCode())
+		}
+		logger.Error(err, "failed to get user", "userID", userID, "statusCode", response.HttpStatusCode)
+		return response, fmt.Errorf("failed to get user %w", err)
+	}
+
+	return &UserGetResponse{UserResponse: *userResponse(resp), HttpStatusCode: "200"}, nil
+}
+
+// GetByUserPrincipalName returns the user signing in with userPrincipalName, nil when there is none.
+// api doc: https://learn.microsoft.com/en-us/graph/api/user-list?view=graph-rest-1.0&tabs=http
+func (s *Service) GetByUserPrincipalName(ctx context.Context, userPrincipalName string) (*UserResponse, error) {
+	if userPrincipalName == "" {
+		return nil, nil
+	}
+	filter := fmt.Sprintf("userPrincipalName eq '%s'", strings.ReplaceAll(userPrincipalName, "'", "''"))
+	resp, err := s.sdk.Users().Get(ctx, &graphusers.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphusers.UsersRequestBuilderGetQueryParameters{
+			Filter: &filter,
+			Select: userProperties,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by user principal name: %w", err)
+	}
+
+	for _, user := range resp.GetValue() {
+		if user.GetId() != nil {
+			return userResponse(user), nil
+		}
+	}
+	return nil, nil
+}
+
+func userResponse(user models.Userable) *UserResponse {
+	response := &UserResponse{}
+	if user.GetId() != nil {
+		response.ID = *user.GetId()
+	}
+	if user.GetDisplayName() != nil {
+		response.DisplayName = *user.GetDisplayName()
+	}
+	if user.GetUserPrincipalName() != nil {
+		response.UserPrincipalName = *user.GetUserPrincipalName()
+	}
+	if user.GetAccountEnabled() != nil {
+		response.AccountEnabled = *user.GetAccountEnabled()
+	}
+	if user.GetExternalUserState() != nil {
+		response.ExternalUserState = *user.GetExternalUserState()
+	}
+	return response
+}
@@ -0,0 +1,31 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	entraUser "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+// Update sets the display name of the user and enables or disables its sign-in.
+// api doc: https://learn.microsoft.com/en-us/graph/api/user-update?view=graph-rest-1.0&tabs=http
+func (s *Service) Update(ctx context.Context, userID string, userSpec entraUser.EntraUserSpec) error {
+	accountEnabled := AccountEnabled(userSpec)
+
+	user := models.NewUser()
+	user.SetDisplayName(&userSpec.DisplayName)
+	user.SetAccountEnabled(&accountEnabled)
+
+	if _, err := s.sdk.Users().ByUserId(userID).Patch(ctx, user, nil); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID string) error {
+	if err := s.sdk.Users().ByUserId(userID).Delete(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete user by ID: %w", err)
+	}
+	return nil
+}
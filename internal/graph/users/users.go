@@ -0,0 +1,45 @@
+package users
+
+import (
+	"context"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	entraUser "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+type Service struct {
+	sdk *msgraphsdk.GraphServiceClient
+}
+
+type UserResponse struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	AccountEnabled    bool   `json:"accountEnabled"`
+	// ExternalUserState is PendingAcceptance or Accepted for invited users, empty otherwise.
+	ExternalUserState string `json:"externalUserState"`
+}
+
+type UserGetResponse struct {
+	UserResponse
+	HttpStatusCode string `json:"httpStatusCode"`
+}
+
+type InvitationResponse struct {
+	UserID    string `json:"userId"`
+	RedeemURL string `json:"redeemUrl"`
+	Status    string `json:"status"`
+}
+
+type API interface {
+	Get(ctx context.Context, userID string) (*UserGetResponse, error)
+	GetByUserPrincipalName(ctx context.Context, userPrincipalName string) (*UserResponse, error)
+	Create(ctx context.Context, userSpec entraUser.EntraUserSpec, password string) (*UserResponse, error)
+	Invite(ctx context.Context, userSpec entraUser.EntraUserSpec) (*InvitationResponse, error)
+	Update(ctx context.Context, userID string, userSpec entraUser.EntraUserSpec) error
+	Delete(ctx context.Context, userID string) error
+}
+
+func NewAPI(sdk *msgraphsdk.GraphServiceClient) API {
+	return &Service{sdk: sdk}
+}
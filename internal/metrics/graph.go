@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+var (
+	// object IDs, app IDs and other GUID path segments
+	guidSegment = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// function style segments with arguments, e.g. applications(appId='...')
+	keySegment = regexp.MustCompile(`\(.+\)$`)
+)
+
+// GraphMiddleware is a Kiota middleware recording request, latency and throttling metrics
+// for every request sent to Microsoft Graph, including retries.
+type GraphMiddleware struct{}
+
+// NewGraphMiddleware returns the metrics middleware. It should be the last middleware in
+// the pipeline so that every attempt made by the retry handler is observed.
+func NewGraphMiddleware() *GraphMiddleware {
+	return &GraphMiddleware{}
+}
+
+// Intercept implements the Kiota middleware interface.
+func (m *GraphMiddleware) Intercept(pipeline khttp.Pipeline, middlewareIndex int, req *http.Request) (*http.Response, error) {
+	operation := Operation(req)
+	start := time.Now()
+
+	resp, err := pipeline.Next(req, middlewareIndex)
+	GraphRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		GraphRequestsTotal.WithLabelValues(operation, "error").Inc()
+		return resp, err
+	}
+
+	GraphRequestsTotal.WithLabelValues(operation, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != "") {
+		GraphThrottledTotal.WithLabelValues(operation).Inc()
+	}
+
+	return resp, nil
+}
+
+// Operation returns a low cardinality name for a Graph request, made of the HTTP method and the
+// request path with object identifiers replaced, e.g. "PATCH /groups/{id}".
+func Operation(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	// drop the API version, e.g. v1.0 or beta
+	if len(segments) > 0 && (strings.HasPrefix(segments[0], "v1") || segments[0] == "beta") {
+		segments = segments[1:]
+	}
+
+	for i, segment := range segments {
+		switch {
+		case guidSegment.MatchString(segment):
+			segments[i] = "{id}"
+		case keySegment.MatchString(segment):
+			segments[i] = keySegment.ReplaceAllString(segment, "({key})")
+		}
+	}
+
+	return req.Method + " /" + strings.Join(segments, "/")
+}
+
+// InstrumentCredential wraps a token credential to count token acquisitions. azidentity serves
+// tokens from its cache on most calls, so only newly issued tokens and failures are counted.
+func InstrumentCredential(cred azcore.TokenCredential) azcore.TokenCredential {
+	return &instrumentedCredential{TokenCredential: cred}
+}
+
+type instrumentedCredential struct {
+	azcore.TokenCredential
+
+	mu        sync.Mutex
+	lastToken string
+}
+
+func (c *instrumentedCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	token, err := c.TokenCredential.GetToken(ctx, opts)
+	if err != nil {
+		TokenAcquisitionsTotal.WithLabelValues("error").Inc()
+		return token, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token.Token != c.lastToken {
+		c.lastToken = token.Token
+		TokenAcquisitionsTotal.WithLabelValues("success").Inc()
+	}
+
+	return token, nil
+}
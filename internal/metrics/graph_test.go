@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// pipeline answers every request with the given status code, or fails with err.
+type pipeline struct {
+	statusCode int
+	header     http.Header
+	err        error
+}
+
+func (p pipeline) Next(req *http.Request, _ int) (*http.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	header := p.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: p.statusCode, Header: header, Request: req}, nil
+}
+
+// credential issues the tokens in order, or fails with err.
+type credential struct {
+	tokens []string
+	err    error
+}
+
+func (c *credential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if c.err != nil {
+		return azcore.AccessToken{}, c.err
+	}
+	token := c.tokens[0]
+	if len(c.tokens) > 1 {
+		c.tokens = c.tokens[1:]
+	}
+	return azcore.AccessToken{Token: token}, nil
+}
+
+func TestOperation(t *testing.T) {
+	g := NewWithT(t)
+	operation := func(method, target string) string {
+		return Operation(httptest.NewRequest(method, target, nil))
+	}
+
+	g.Expect(operation(http.MethodGet, "https://graph.microsoft.com/v1.0/groups")).To(Equal("GET /groups"))
+	g.Expect(operation(http.MethodPatch, "https://graph.microsoft.com/v1.0/groups/0f5e8a6c-3b2a-4c1d-9e8f-7a6b5c4d3e2f")).To(Equal("PATCH /groups/{id}"))
+	g.Expect(operation(http.MethodDelete, "https://graph.microsoft.com/v1.0/groups/0f5e8a6c-3b2a-4c1d-9e8f-7a6b5c4d3e2f/members/1a2b3c4d-5e6f-4a1b-8c9d-0e1f2a3b4c5d/$ref")).To(Equal("DELETE /groups/{id}/members/{id}/$ref"))
+	g.Expect(operation(http.MethodGet, "https://graph.microsoft.com/beta/applications(appId='0f5e8a6c-3b2a-4c1d-9e8f-7a6b5c4d3e2f')")).To(Equal("GET /applications({key})"))
+	g.Expect(operation(http.MethodGet, "https://graph.microsoft.us/v1.0/groups/delta()?$deltatoken=abc")).To(Equal("GET /groups/delta()"))
+	g.Expect(operation(http.MethodPost, "http://127.0.0.1:8080/v1.0/$batch")).To(Equal("POST /$batch"))
+}
+
+func TestGraphMiddleware(t *testing.T) {
+	g := NewWithT(t)
+	middleware := NewGraphMiddleware()
+	target := "https://graph.microsoft.com/v1.0/users/0f5e8a6c-3b2a-4c1d-9e8f-7a6b5c4d3e2f"
+	const operation = "GET /users/{id}"
+	requests := func(statusCode string) float64 {
+		return testutil.ToFloat64(GraphRequestsTotal.WithLabelValues(operation, statusCode))
+	}
+	throttled := func() float64 {
+		return testutil.ToFloat64(GraphThrottledTotal.WithLabelValues(operation))
+	}
+	intercept := func(p pipeline) {
+		_, _ = middleware.Intercept(p, 0, httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	intercept(pipeline{statusCode: http.StatusOK})
+	g.Expect(requests("200")).To(Equal(1.0))
+	g.Expect(throttled()).To(BeZero())
+
+	step := "throttled with 429"
+	intercept(pipeline{statusCode: http.StatusTooManyRequests})
+	g.Expect(requests("429")).To(Equal(1.0), step)
+	g.Expect(throttled()).To(Equal(1.0), step)
+
+	step = "unavailable with and without Retry-After"
+	intercept(pipeline{statusCode: http.StatusServiceUnavailable, header: http.Header{"Retry-After": {"5"}}})
+	intercept(pipeline{statusCode: http.StatusServiceUnavailable})
+	g.Expect(requests("503")).To(Equal(2.0), step)
+	g.Expect(throttled()).To(Equal(2.0), step)
+
+	step = "transport errors"
+	intercept(pipeline{err: errors.New("connection refused")})
+	g.Expect(requests("error")).To(Equal(1.0), step)
+
+	g.Expect(testutil.CollectAndCount(GraphRequestDuration, "entra_governance_graph_request_duration_seconds")).To(BeNumerically(">=", 1))
+}
+
+func TestInstrumentCredential(t *testing.T) {
+	g := NewWithT(t)
+	successes := func() float64 { return testutil.ToFloat64(TokenAcquisitionsTotal.WithLabelValues("success")) }
+	failures := func() float64 { return testutil.ToFloat64(TokenAcquisitionsTotal.WithLabelValues("error")) }
+	before, beforeFailures := successes(), failures()
+
+	cred := InstrumentCredential(&credential{tokens: []string{"first", "first", "second"}})
+	for range 3 {
+		_, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{})
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	// the cached token served twice is counted once
+	g.Expect(successes() - before).To(Equal(2.0))
+
+	_, err := InstrumentCredential(&credential{err: errors.New("invalid client secret")}).GetToken(context.Background(), policy.TokenRequestOptions{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(failures() - beforeFailures).To(Equal(1.0))
+}
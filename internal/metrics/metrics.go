@@ -0,0 +1,66 @@
+// Package metrics defines the custom Prometheus metrics of the controller. All metrics are
+// registered with the controller-runtime registry and served on the manager metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "entra_governance"
+
+var (
+	// GraphRequestsTotal counts Microsoft Graph HTTP requests by operation and status code.
+	GraphRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "graph_requests_total",
+		Help:      "Number of Microsoft Graph requests by operation and HTTP status code.",
+	}, []string{"operation", "status_code"})
+
+	// GraphRequestDuration observes the latency of Microsoft Graph HTTP requests.
+	GraphRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graph_request_duration_seconds",
+		Help:      "Latency of Microsoft Graph requests by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"operation"})
+
+	// GraphThrottledTotal counts Microsoft Graph responses that asked the controller to back off.
+	GraphThrottledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "graph_throttled_total",
+		Help:      "Number of throttled Microsoft Graph requests (HTTP 429, or 503 with Retry-After) by operation.",
+	}, []string{"operation"})
+
+	// TokenAcquisitionsTotal counts access tokens issued for Microsoft Graph.
+	TokenAcquisitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_acquisitions_total",
+		Help:      "Number of Microsoft Graph access token acquisitions by result.",
+	}, []string{"result"})
+
+	// DriftDetectionsTotal counts differences found between the desired and the actual state in Entra.
+	DriftDetectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drift_detections_total",
+		Help:      "Number of drifts detected between Kubernetes and Entra by kind and reason.",
+	}, []string{"kind", "reason"})
+
+	// GroupMemberChangesTotal counts group membership changes written to Entra.
+	GroupMemberChangesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "group_member_changes_total",
+		Help:      "Number of group member additions and removals by result.",
+	}, []string{"operation", "result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		GraphRequestsTotal,
+		GraphRequestDuration,
+		GraphThrottledTotal,
+		TokenAcquisitionsTotal,
+		DriftDetectionsTotal,
+		GroupMemberChangesTotal,
+	)
+}
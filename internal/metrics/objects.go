@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+var managedObjectsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "managed_objects"),
+	"Number of managed Entra objects by kind and phase.",
+	[]string{"kind", "phase"}, nil,
+)
+
+// managedObjectsCollector counts the managed custom resources by phase at scrape time, so
+// that deleted resources never linger in the reported numbers.
+type managedObjectsCollector struct {
+	reader client.Reader
+}
+
+// RegisterManagedObjectsCollector registers the managed object gauge. The reader should be
+// the cached manager client so that scrapes never reach the API server.
+func RegisterManagedObjectsCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(&managedObjectsCollector{reader: reader})
+}
+
+func (c *managedObjectsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedObjectsDesc
+}
+
+func (c *managedObjectsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	groups := &v1alpha1.EntraSecurityGroupList{}
+	if err := c.reader.List(ctx, groups); err == nil {
+		phases := make(map[string]int)
+		for _, group := range groups.Items {
+			phases[phaseLabel(group.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraSecurityGroup", phases)
+	}
+
+	apps := &v1alpha1.EntraAppRegistrationList{}
+	if err := c.reader.List(ctx, apps); err == nil {
+		phases := make(map[string]int)
+		for _, app := range apps.Items {
+			phases[phaseLabel(app.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraAppRegistration", phases)
+	}
+
+	users := &v1alpha1.EntraUserList{}
+	if err := c.reader.List(ctx, users); err == nil {
+		phases := make(map[string]int)
+		for _, user := range users.Items {
+			phases[phaseLabel(user.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraUser", phases)
+	}
+
+	servicePrincipals := &v1alpha1.EntraServicePrincipalList{}
+	if err := c.reader.List(ctx, servicePrincipals); err == nil {
+		phases := make(map[string]int)
+		for _, sp := range servicePrincipals.Items {
+			phases[phaseLabel(sp.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraServicePrincipal", phases)
+	}
+
+	appRoleAssignments := &v1alpha1.EntraAppRoleAssignmentList{}
+	if err := c.reader.List(ctx, appRoleAssignments); err == nil {
+		phases := make(map[string]int)
+		for _, assignment := range appRoleAssignments.Items {
+			phases[phaseLabel(assignment.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraAppRoleAssignment", phases)
+	}
+
+	permissionGrants := &v1alpha1.EntraPermissionGrantList{}
+	if err := c.reader.List(ctx, permissionGrants); err == nil {
+		phases := make(map[string]int)
+		for _, grant := range permissionGrants.Items {
+			phases[phaseLabel(grant.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraPermissionGrant", phases)
+	}
+
+	directoryRoleAssignments := &v1alpha1.EntraDirectoryRoleAssignmentList{}
+	if err := c.reader.List(ctx, directoryRoleAssignments); err == nil {
+		phases := make(map[string]int)
+		for _, assignment := range directoryRoleAssignments.Items {
+			phases[phaseLabel(assignment.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraDirectoryRoleAssignment", phases)
+	}
+
+	eligibleRoleAssignments := &v1alpha1.EntraEligibleRoleAssignmentList{}
+	if err := c.reader.List(ctx, eligibleRoleAssignments); err == nil {
+		phases := make(map[string]int)
+		for _, assignment := range eligibleRoleAssignments.Items {
+			phases[phaseLabel(assignment.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraEligibleRoleAssignment", phases)
+	}
+
+	administrativeUnits := &v1alpha1.EntraAdministrativeUnitList{}
+	if err := c.reader.List(ctx, administrativeUnits); err == nil {
+		phases := make(map[string]int)
+		for _, unit := range administrativeUnits.Items {
+			phases[phaseLabel(unit.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraAdministrativeUnit", phases)
+	}
+
+	conditionalAccessPolicies := &v1alpha1.EntraConditionalAccessPolicyList{}
+	if err := c.reader.List(ctx, conditionalAccessPolicies); err == nil {
+		phases := make(map[string]int)
+		for _, policy := range conditionalAccessPolicies.Items {
+			phases[phaseLabel(policy.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraConditionalAccessPolicy", phases)
+	}
+
+	namedLocations := &v1alpha1.EntraNamedLocationList{}
+	if err := c.reader.List(ctx, namedLocations); err == nil {
+		phases := make(map[string]int)
+		for _, location := range namedLocations.Items {
+			phases[phaseLabel(location.Status.Phase)]++
+		}
+		collectPhases(ch, "EntraNamedLocation", phases)
+	}
+}
+
+func collectPhases(ch chan<- prometheus.Metric, kind string, phases map[string]int) {
+	for phase, count := range phases {
+		ch <- prometheus.MustNewConstMetric(managedObjectsDesc, prometheus.GaugeValue, float64(count), kind, phase)
+	}
+}
+
+func phaseLabel(phase string) string {
+	if phase == "" {
+		return "Unknown"
+	}
+	return phase
+}
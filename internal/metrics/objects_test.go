@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+)
+
+func TestManagedObjectsCollector(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	group := func(name, phase string) *v1alpha1.EntraSecurityGroup {
+		return &v1alpha1.EntraSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     v1alpha1.EntraSecurityGroupStatus{Phase: phase},
+		}
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		group("ready-1", "Ready"),
+		group("ready-2", "Ready"),
+		group("failed", "Failed"),
+		group("new", ""),
+		&v1alpha1.EntraUser{ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "default"}, Status: v1alpha1.EntraUserStatus{Phase: "Ready"}},
+	).Build()
+
+	expected := `
+# HELP entra_governance_managed_objects Number of managed Entra objects by kind and phase.
+# TYPE entra_governance_managed_objects gauge
+entra_governance_managed_objects{kind="EntraSecurityGroup",phase="Failed"} 1
+entra_governance_managed_objects{kind="EntraSecurityGroup",phase="Ready"} 2
+entra_governance_managed_objects{kind="EntraSecurityGroup",phase="Unknown"} 1
+entra_governance_managed_objects{kind="EntraUser",phase="Ready"} 1
+`
+	collector := &managedObjectsCollector{reader: reader}
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected), "entra_governance_managed_objects")).To(Succeed())
+}
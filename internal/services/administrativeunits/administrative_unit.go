@@ -0,0 +1,138 @@
+package administrativeunits
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphunits "github.com/vimal-vijayan/entra-governance/internal/graph/administrativeunits"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraAdministrativeUnit"
+
+// requiredPermissions are the Graph application permissions needed to create, update and
+// delete administrative units.
+var requiredPermissions = []client.Permission{
+	{Name: "AdministrativeUnit.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// API manages Entra administrative units on behalf of EntraAdministrativeUnit resources, using
+// the credentials referenced in their spec.
+type API interface {
+	Get(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) (unit *graphunits.AdministrativeUnitResponse, statusCode string, err error)
+	Create(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit) (*graphunits.AdministrativeUnitResponse, error)
+	Update(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) error
+	Delete(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) error
+	CheckCredentials(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+func (s *Service) Get(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) (unit *graphunits.AdministrativeUnitResponse, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "administrativeunits.Get", attribute.String("entra.administrativeunit.name", entraUnit.Name), attribute.String("entra.administrativeunit.id", unitID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUnit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := graphClient.AdministrativeUnits.Get(ctx, unitID)
+	if err != nil {
+		if resp == nil {
+			return nil, "", err
+		}
+		return nil, resp.HttpStatusCode, err
+	}
+
+	return &resp.AdministrativeUnitResponse, resp.HttpStatusCode, nil
+}
+
+// Create creates the administrative unit of entraUnit. An administrative unit created by an
+// earlier attempt whose status was never written is adopted instead.
+func (s *Service) Create(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit) (unit *graphunits.AdministrativeUnitResponse, err error) {
+	ctx, span := tracing.Start(ctx, "administrativeunits.Create", attribute.String("entra.administrativeunit.name", entraUnit.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueName := client.UniqueName(&entraUnit)
+	existing, err := graphClient.AdministrativeUnits.GetByUniqueName(ctx, uniqueName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		log.FromContext(ctx).Info("adopting existing Entra administrative unit created for the resource", "UnitID", existing.ID, "uniqueName", uniqueName)
+		return existing, nil
+	}
+
+	return graphClient.AdministrativeUnits.Create(ctx, entraUnit.Spec, uniqueName)
+}
+
+// Update applies the display name, the description and the membership of the spec to the
+// administrative unit.
+func (s *Service) Update(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) (err error) {
+	ctx, span := tracing.Start(ctx, "administrativeunits.Update", attribute.String("entra.administrativeunit.name", entraUnit.Name), attribute.String("entra.administrativeunit.id", unitID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUnit)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.AdministrativeUnits.Update(ctx, unitID, entraUnit.Spec)
+}
+
+func (s *Service) Delete(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit, unitID string) (err error) {
+	ctx, span := tracing.Start(ctx, "administrativeunits.Delete", attribute.String("entra.administrativeunit.name", entraUnit.Name), attribute.String("entra.administrativeunit.id", unitID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUnit)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.AdministrativeUnits.Delete(ctx, unitID)
+}
+
+// CheckCredentials returns the permissions required to manage administrative units that are
+// missing from the credential of entraUnit.
+func (s *Service) CheckCredentials(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "administrativeunits.CheckCredentials", attribute.String("entra.administrativeunit.name", entraUnit.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUnit)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, entraUnit v1alpha1.EntraAdministrativeUnit) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraUnit, entraUnit.Spec.ForProvider)
+}
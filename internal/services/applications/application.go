@@ -4,67 +4,124 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
 	appregistration "github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 	"github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
 )
 
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraAppRegistration"
+
+// requiredPermissions are the Graph application permissions needed to manage applications.
+var requiredPermissions = []client.Permission{
+	{Name: "Application.ReadWrite.All", Alternatives: []string{"Application.ReadWrite.OwnedBy"}},
+}
+
+// API manages Entra applications on behalf of EntraAppRegistration resources, using the
+// credentials referenced in their spec.
+type API interface {
+	Create(ctx context.Context, entraApp appregistration.EntraAppRegistration) (clientID string, objectID string, err error)
+	Delete(ctx context.Context, appID string, entraApp appregistration.EntraAppRegistration) error
+	CheckCredentials(ctx context.Context, entraApp appregistration.EntraAppRegistration) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
 type Service struct {
-	factory *client.ClientFactory
+	factory client.Factory
 }
 
-func NewService(factory *client.ClientFactory) *Service {
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
 	return &Service{factory: factory}
 }
 
-func (s *Service) Create(ctx context.Context, entraApp appregistration.EntraAppRegistration) (string, string, error) {
+func (s *Service) Create(ctx context.Context, entraApp appregistration.EntraAppRegistration) (clientID string, objectID string, err error) {
+	ctx, span := tracing.Start(ctx, "applications.Create", attribute.String("entra.application.name", entraApp.Spec.Name))
+	defer func() { tracing.End(span, err) }()
 
 	if entraApp.Spec.ForProvider == nil {
 		return "", "", fmt.Errorf("forProvider spec is nil")
 	}
 
-	secretRef := client.SecretRef{
-		Name:      entraApp.Spec.ForProvider.CredentialSecretRef,
-		Namespace: entraApp.Namespace,
-	}
+	if entraApp.Spec.ForProvider.CredentialSecretRef != "" || entraApp.Spec.ForProvider.ManagedIdentity != nil {
+		graphClient, err := s.forProvider(ctx, entraApp)
+		if err != nil {
+			return "", "", err
+		}
 
-	if entraApp.Spec.ForProvider.CredentialSecretRef != "" {
-		sdk, err := s.factory.ForClientSecret(ctx, secretRef)
+		// an application created by an earlier attempt whose status was never written is adopted
+		uniqueName := client.UniqueName(&entraApp)
+		existing, err := graphClient.AppRegistration.GetByUniqueName(ctx, uniqueName)
 		if err != nil {
 			return "", "", err
 		}
+		if existing != nil {
+			log.FromContext(ctx).Info("adopting existing Entra application created for the resource", "applicationID", existing.AppClientID, "uniqueName", uniqueName)
+			return existing.AppClientID, existing.AppObjectID, nil
+		}
 
-		graphClient := client.NewGraphClient(sdk)
 		// response, err := graphClient.CreateEntraApplication(ctx, entraApp.Spec)
-		response, err := graphClient.AppRegistration.Create(ctx, entraApp.Spec)
+		response, err := graphClient.AppRegistration.Create(ctx, entraApp.Spec, uniqueName)
 		if err != nil {
 			return "", "", err
 		}
 		return response.AppClientID, response.AppObjectID, nil
 	}
 
-	return "", "", fmt.Errorf("credential secret reference is empty in forProvider spec")
+	return "", "", fmt.Errorf("credential secret reference or managed identity is required in forProvider spec")
 }
 
-func (s *Service) Delete(ctx context.Context, appID string, entraApp appregistration.EntraAppRegistration) error {
+func (s *Service) Delete(ctx context.Context, appID string, entraApp appregistration.EntraAppRegistration) (err error) {
+	ctx, span := tracing.Start(ctx, "applications.Delete", attribute.String("entra.application.name", entraApp.Spec.Name), attribute.String("entra.application.id", appID))
+	defer func() { tracing.End(span, err) }()
 
 	if entraApp.Spec.ForProvider == nil {
 		return fmt.Errorf("forProvider spec is nil")
 	}
 
-	secretRef := client.SecretRef{
-		Name:      entraApp.Spec.ForProvider.CredentialSecretRef,
-		Namespace: entraApp.Namespace,
-	}
-
-	if entraApp.Spec.ForProvider.CredentialSecretRef != "" {
-		sdk, err := s.factory.ForClientSecret(ctx, secretRef)
+	if entraApp.Spec.ForProvider.CredentialSecretRef != "" || entraApp.Spec.ForProvider.ManagedIdentity != nil {
+		graphClient, err := s.forProvider(ctx, entraApp)
 		if err != nil {
 			return err
 		}
 
-		graphClient := client.NewGraphClient(sdk)
 		return graphClient.AppRegistration.Delete(ctx, appID)
 	}
 
-	return fmt.Errorf("credential secret reference is empty in forProvider spec")
+	return fmt.Errorf("credential secret reference or managed identity is required in forProvider spec")
+}
+
+// CheckCredentials returns the permissions required to manage applications that are missing
+// from the credential of entraApp.
+func (s *Service) CheckCredentials(ctx context.Context, entraApp appregistration.EntraAppRegistration) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "applications.CheckCredentials", attribute.String("entra.application.name", entraApp.Spec.Name))
+	defer func() { tracing.End(span, err) }()
+
+	if entraApp.Spec.ForProvider == nil {
+		return nil, fmt.Errorf("forProvider spec is nil")
+	}
+
+	graphClient, err := s.forProvider(ctx, entraApp)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+// forProvider returns a Graph client authenticated with the credential configured in the
+// provider spec of entraApp: the managed identity of the controller or a credential secret.
+func (s *Service) forProvider(ctx context.Context, entraApp appregistration.EntraAppRegistration) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraApp, client.AppRegistrationProvider(entraApp.Spec.ForProvider))
 }
@@ -0,0 +1,162 @@
+package approleassignments
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphsp "github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraAppRoleAssignment"
+
+// requiredPermissions are the Graph application permissions needed to read the app roles of
+// service principals and to grant and revoke app role assignments.
+var requiredPermissions = []client.Permission{
+	{Name: "AppRoleAssignment.ReadWrite.All"},
+	{Name: "Application.Read.All", Alternatives: []string{"Application.ReadWrite.All", "Directory.Read.All", "Directory.ReadWrite.All"}},
+}
+
+// API manages Entra app role assignments on behalf of EntraAppRoleAssignment resources, using
+// the credentials referenced in their spec.
+type API interface {
+	ResolveAppRole(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string) (appRoleID string, err error)
+	Get(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, assignmentID string) (resp *graphsp.AppRoleAssignmentResponse, statusCode string, err error)
+	Create(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, principalID string, appRoleID string) (resp *graphsp.AppRoleAssignmentResponse, adopted bool, err error)
+	Delete(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, assignmentID string) error
+	CheckCredentials(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+// ResolveAppRole returns the ID of the app role of the resource service principal with the value
+// of the spec, the default access role when the spec has none.
+func (s *Service) ResolveAppRole(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string) (appRoleID string, err error) {
+	ctx, span := tracing.Start(ctx, "approleassignments.ResolveAppRole", attribute.String("entra.approleassignment.name", assignment.Name), attribute.String("entra.approleassignment.app_role", assignment.Spec.AppRole))
+	defer func() { tracing.End(span, err) }()
+
+	if assignment.Spec.AppRole == "" {
+		return graphsp.DefaultAccessAppRoleID, nil
+	}
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return "", err
+	}
+
+	roles, err := graphClient.ServicePrincipals.AppRoles(ctx, resourceID)
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roles {
+		if role.Value != assignment.Spec.AppRole {
+			continue
+		}
+		if !role.IsEnabled {
+			return "", fmt.Errorf("app role %s of service principal %s is disabled", role.Value, resourceID)
+		}
+		return role.ID, nil
+	}
+	return "", fmt.Errorf("app role %s not found on service principal %s", assignment.Spec.AppRole, resourceID)
+}
+
+func (s *Service) Get(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, assignmentID string) (resp *graphsp.AppRoleAssignmentResponse, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "approleassignments.Get", attribute.String("entra.approleassignment.name", assignment.Name), attribute.String("entra.approleassignment.id", assignmentID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	getResp, err := graphClient.ServicePrincipals.GetAppRoleAssignment(ctx, resourceID, assignmentID)
+	if err != nil {
+		if getResp == nil {
+			return nil, "", err
+		}
+		return nil, getResp.HttpStatusCode, err
+	}
+
+	return &getResp.AppRoleAssignmentResponse, getResp.HttpStatusCode, nil
+}
+
+// Create assigns the app role to the principal. An assignment of the same app role to the
+// principal that already exists is adopted instead, Graph rejects duplicates.
+func (s *Service) Create(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, principalID string, appRoleID string) (resp *graphsp.AppRoleAssignmentResponse, adopted bool, err error) {
+	ctx, span := tracing.Start(ctx, "approleassignments.Create", attribute.String("entra.approleassignment.name", assignment.Name), attribute.String("entra.approleassignment.resource_id", resourceID), attribute.String("entra.approleassignment.principal_id", principalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := graphClient.ServicePrincipals.ListAppRoleAssignedTo(ctx, resourceID)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, candidate := range existing {
+		if candidate.PrincipalID == principalID && candidate.AppRoleID == appRoleID {
+			log.FromContext(ctx).Info("adopting existing Entra app role assignment", "assignmentID", candidate.ID, "principalID", principalID, "appRoleID", appRoleID)
+			return &candidate, true, nil
+		}
+	}
+
+	resp, err = graphClient.ServicePrincipals.CreateAppRoleAssignment(ctx, resourceID, principalID, appRoleID)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, false, nil
+}
+
+func (s *Service) Delete(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment, resourceID string, assignmentID string) (err error) {
+	ctx, span := tracing.Start(ctx, "approleassignments.Delete", attribute.String("entra.approleassignment.name", assignment.Name), attribute.String("entra.approleassignment.id", assignmentID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ServicePrincipals.DeleteAppRoleAssignment(ctx, resourceID, assignmentID)
+}
+
+// CheckCredentials returns the permissions required to manage app role assignments that are
+// missing from the credential of assignment.
+func (s *Service) CheckCredentials(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "approleassignments.CheckCredentials", attribute.String("entra.approleassignment.name", assignment.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, assignment v1alpha1.EntraAppRoleAssignment) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &assignment, assignment.Spec.ForProvider)
+}
@@ -0,0 +1,129 @@
+package conditionalaccesspolicies
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/conditionalaccess"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraConditionalAccessPolicy"
+
+// requiredPermissions are the Graph application permissions needed to create, update and
+// delete conditional access policies. Graph reads the policies and the named locations they
+// reference with Policy.Read.All.
+var requiredPermissions = []client.Permission{
+	{Name: "Policy.ReadWrite.ConditionalAccess"},
+	{Name: "Policy.Read.All"},
+}
+
+// API manages Entra conditional access policies on behalf of EntraConditionalAccessPolicy
+// resources, using the credentials referenced in their spec.
+type API interface {
+	Get(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string) (policy *conditionalaccess.Policy, statusCode string, err error)
+	Create(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policy conditionalaccess.Policy) (*conditionalaccess.Policy, error)
+	Update(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string, policy conditionalaccess.Policy) error
+	Delete(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string) error
+	CheckCredentials(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+func (s *Service) Get(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string) (policy *conditionalaccess.Policy, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "conditionalaccesspolicies.Get", attribute.String("entra.conditionalaccesspolicy.name", entraPolicy.Name), attribute.String("entra.conditionalaccesspolicy.id", policyID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraPolicy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := graphClient.ConditionalAccess.Get(ctx, policyID)
+	if err != nil {
+		if resp == nil {
+			return nil, "", err
+		}
+		return nil, resp.HttpStatusCode, err
+	}
+
+	return &resp.Policy, resp.HttpStatusCode, nil
+}
+
+// Create creates the conditional access policy of entraPolicy. The users and groups referenced
+// by entraPolicy are resolved in policy.
+func (s *Service) Create(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policy conditionalaccess.Policy) (created *conditionalaccess.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "conditionalaccesspolicies.Create", attribute.String("entra.conditionalaccesspolicy.name", entraPolicy.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.ConditionalAccess.Create(ctx, policy)
+}
+
+// Update applies policy, the spec of entraPolicy with its references resolved, to the
+// conditional access policy.
+func (s *Service) Update(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string, policy conditionalaccess.Policy) (err error) {
+	ctx, span := tracing.Start(ctx, "conditionalaccesspolicies.Update", attribute.String("entra.conditionalaccesspolicy.name", entraPolicy.Name), attribute.String("entra.conditionalaccesspolicy.id", policyID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraPolicy)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ConditionalAccess.Update(ctx, policyID, policy)
+}
+
+func (s *Service) Delete(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy, policyID string) (err error) {
+	ctx, span := tracing.Start(ctx, "conditionalaccesspolicies.Delete", attribute.String("entra.conditionalaccesspolicy.name", entraPolicy.Name), attribute.String("entra.conditionalaccesspolicy.id", policyID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraPolicy)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ConditionalAccess.Delete(ctx, policyID)
+}
+
+// CheckCredentials returns the permissions required to manage conditional access policies that
+// missing from the credential of entraPolicy.
+func (s *Service) CheckCredentials(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "conditionalaccesspolicies.CheckCredentials", attribute.String("entra.conditionalaccesspolicy.name", entraPolicy.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, entraPolicy v1alpha1.EntraConditionalAccessPolicy) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraPolicy, entraPolicy.Spec.ForProvider)
+}
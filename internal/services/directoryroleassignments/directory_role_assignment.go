@@ -0,0 +1,168 @@
+package directoryroleassignments
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphrm "github.com/vimal-vijayan/entra-governance/internal/graph/rolemanagement"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraDirectoryRoleAssignment"
+
+// requiredPermissions are the Graph application permissions needed to read directory roles and
+// to assign and revoke them.
+var requiredPermissions = []client.Permission{
+	{Name: "RoleManagement.ReadWrite.Directory"},
+}
+
+// API manages Entra directory role assignments on behalf of EntraDirectoryRoleAssignment
+// resources, using the credentials referenced in their spec.
+type API interface {
+	ResolveRole(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment) (role *graphrm.RoleDefinitionResponse, err error)
+	Get(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, assignmentID string) (resp *graphrm.RoleAssignmentResponse, statusCode string, err error)
+	Create(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, principalID string, roleDefinitionID string, directoryScopeID string) (resp *graphrm.RoleAssignmentResponse, adopted bool, err error)
+	Delete(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, assignmentID string) error
+	CheckCredentials(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+// DirectoryScopeID returns the directory scope of the assignment, the administrative unit of the
+// spec or the whole tenant.
+func DirectoryScopeID(administrativeUnitID string) string {
+	if administrativeUnitID == "" {
+		return graphrm.DirectoryScopeTenant
+	}
+	return "/administrativeUnits/" + administrativeUnitID
+}
+
+// ResolveRole returns the role definition selected by the template ID or display name of the
+// spec.
+func (s *Service) ResolveRole(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment) (role *graphrm.RoleDefinitionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "directoryroleassignments.ResolveRole", attribute.String("entra.directoryroleassignment.name", assignment.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := assignment.Spec.Role
+	if selector.TemplateID != "" {
+		role, err = graphClient.RoleManagement.GetRoleDefinitionByTemplateID(ctx, selector.TemplateID)
+	} else {
+		role, err = graphClient.RoleManagement.GetRoleDefinitionByDisplayName(ctx, selector.DisplayName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("directory role %s%s not found", selector.TemplateID, selector.DisplayName)
+	}
+	if !role.IsEnabled {
+		return nil, fmt.Errorf("directory role %s is disabled", role.DisplayName)
+	}
+	return role, nil
+}
+
+func (s *Service) Get(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, assignmentID string) (resp *graphrm.RoleAssignmentResponse, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "directoryroleassignments.Get", attribute.String("entra.directoryroleassignment.name", assignment.Name), attribute.String("entra.directoryroleassignment.id", assignmentID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	getResp, err := graphClient.RoleManagement.GetRoleAssignment(ctx, assignmentID)
+	if err != nil {
+		if getResp == nil {
+			return nil, "", err
+		}
+		return nil, getResp.HttpStatusCode, err
+	}
+
+	return &getResp.RoleAssignmentResponse, getResp.HttpStatusCode, nil
+}
+
+// Create assigns the directory role to the principal at the directory scope. An assignment of
+// the role to the principal at the same scope that already exists is adopted instead.
+func (s *Service) Create(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, principalID string, roleDefinitionID string, directoryScopeID string) (resp *graphrm.RoleAssignmentResponse, adopted bool, err error) {
+	ctx, span := tracing.Start(ctx, "directoryroleassignments.Create", attribute.String("entra.directoryroleassignment.name", assignment.Name), attribute.String("entra.directoryroleassignment.principal_id", principalID), attribute.String("entra.directoryroleassignment.role_definition_id", roleDefinitionID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := graphClient.RoleManagement.ListRoleAssignments(ctx, principalID)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, candidate := range existing {
+		if candidate.RoleDefinitionID == roleDefinitionID && candidate.DirectoryScopeID == directoryScopeID {
+			log.FromContext(ctx).Info("adopting existing Entra directory role assignment", "assignmentID", candidate.ID, "principalID", principalID, "roleDefinitionID", roleDefinitionID)
+			return &candidate, true, nil
+		}
+	}
+
+	resp, err = graphClient.RoleManagement.CreateRoleAssignment(ctx, principalID, roleDefinitionID, directoryScopeID)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, false, nil
+}
+
+func (s *Service) Delete(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment, assignmentID string) (err error) {
+	ctx, span := tracing.Start(ctx, "directoryroleassignments.Delete", attribute.String("entra.directoryroleassignment.name", assignment.Name), attribute.String("entra.directoryroleassignment.id", assignmentID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.RoleManagement.DeleteRoleAssignment(ctx, assignmentID)
+}
+
+// CheckCredentials returns the permissions required to manage directory role assignments that
+// are missing from the credential of assignment.
+func (s *Service) CheckCredentials(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "directoryroleassignments.CheckCredentials", attribute.String("entra.directoryroleassignment.name", assignment.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, assignment v1alpha1.EntraDirectoryRoleAssignment) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &assignment, assignment.Spec.ForProvider)
+}
@@ -0,0 +1,190 @@
+package eligibleroleassignments
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	"github.com/vimal-vijayan/entra-governance/internal/graph/pim"
+	graphrm "github.com/vimal-vijayan/entra-governance/internal/graph/rolemanagement"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraEligibleRoleAssignment"
+
+// directoryRolePermissions are the Graph application permissions needed to read directory roles
+// and to manage eligibilities for them.
+var directoryRolePermissions = []client.Permission{
+	{Name: "RoleEligibilitySchedule.ReadWrite.Directory", Alternatives: []string{"RoleManagement.ReadWrite.Directory"}},
+	{Name: "RoleManagement.Read.Directory", Alternatives: []string{"RoleManagement.ReadWrite.Directory", "Directory.Read.All"}},
+}
+
+// groupPermissions are the Graph application permissions needed to manage eligibilities for
+// groups managed by PIM for groups.
+var groupPermissions = []client.Permission{
+	{Name: "PrivilegedEligibilitySchedule.ReadWrite.AzureADGroup"},
+}
+
+// API manages PIM eligibilities on behalf of EntraEligibleRoleAssignment resources, using the
+// credentials referenced in their spec. Directory role or group eligibilities are managed
+// depending on the target of the spec.
+type API interface {
+	ResolveRole(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment) (role *graphrm.RoleDefinitionResponse, err error)
+	GetSchedule(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, scheduleID string) (schedule *pim.EligibilitySchedule, statusCode string, err error)
+	FindSchedule(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, request pim.EligibilityRequest) (schedule *pim.EligibilitySchedule, err error)
+	Request(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, request pim.EligibilityRequest) (resp *pim.ScheduleRequestResponse, err error)
+	CheckCredentials(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+// ResolveRole returns the directory role selected by the template ID or display name of the
+// spec.
+func (s *Service) ResolveRole(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment) (role *graphrm.RoleDefinitionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "eligibleroleassignments.ResolveRole", attribute.String("entra.eligibleroleassignment.name", assignment.Name))
+	defer func() { tracing.End(span, err) }()
+
+	if assignment.Spec.DirectoryRole == nil {
+		return nil, fmt.Errorf("directoryRole spec is nil")
+	}
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := assignment.Spec.DirectoryRole.Role
+	if selector.TemplateID != "" {
+		role, err = graphClient.RoleManagement.GetRoleDefinitionByTemplateID(ctx, selector.TemplateID)
+	} else {
+		role, err = graphClient.RoleManagement.GetRoleDefinitionByDisplayName(ctx, selector.DisplayName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("directory role %s%s not found", selector.TemplateID, selector.DisplayName)
+	}
+	if !role.IsEnabled {
+		return nil, fmt.Errorf("directory role %s is disabled", role.DisplayName)
+	}
+	return role, nil
+}
+
+func (s *Service) GetSchedule(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, scheduleID string) (schedule *pim.EligibilitySchedule, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "eligibleroleassignments.GetSchedule", attribute.String("entra.eligibleroleassignment.name", assignment.Name), attribute.String("entra.eligibleroleassignment.schedule_id", scheduleID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var getResp *pim.EligibilityScheduleGetResponse
+	if assignment.Spec.Group != nil {
+		getResp, err = graphClient.PIM.GetGroupEligibilitySchedule(ctx, scheduleID)
+	} else {
+		getResp, err = graphClient.PIM.GetRoleEligibilitySchedule(ctx, scheduleID)
+	}
+	if err != nil {
+		if getResp == nil {
+			return nil, "", err
+		}
+		return nil, getResp.HttpStatusCode, err
+	}
+
+	return &getResp.EligibilitySchedule, getResp.HttpStatusCode, nil
+}
+
+// FindSchedule returns the eligibility schedule of the principal for the directory role and
+// scope, or for the group access, of request. It returns nil when there is none.
+func (s *Service) FindSchedule(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, request pim.EligibilityRequest) (schedule *pim.EligibilitySchedule, err error) {
+	ctx, span := tracing.Start(ctx, "eligibleroleassignments.FindSchedule", attribute.String("entra.eligibleroleassignment.name", assignment.Name), attribute.String("entra.eligibleroleassignment.principal_id", request.PrincipalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GroupID != "" {
+		schedules, err := graphClient.PIM.ListGroupEligibilitySchedules(ctx, request.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range schedules {
+			if candidate.PrincipalID == request.PrincipalID && candidate.AccessID == request.AccessID {
+				return &candidate, nil
+			}
+		}
+		return nil, nil
+	}
+
+	schedules, err := graphClient.PIM.ListRoleEligibilitySchedules(ctx, request.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range schedules {
+		if candidate.RoleDefinitionID == request.RoleDefinitionID && candidate.DirectoryScopeID == request.DirectoryScopeID {
+			return &candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// Request submits a schedule request for the eligibility, with the justification of the spec.
+func (s *Service) Request(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment, request pim.EligibilityRequest) (resp *pim.ScheduleRequestResponse, err error) {
+	ctx, span := tracing.Start(ctx, "eligibleroleassignments.Request", attribute.String("entra.eligibleroleassignment.name", assignment.Name), attribute.String("entra.eligibleroleassignment.action", request.Action))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Justification = assignment.Spec.Justification
+	if request.GroupID != "" {
+		return graphClient.PIM.CreateGroupEligibilityRequest(ctx, request)
+	}
+	return graphClient.PIM.CreateRoleEligibilityRequest(ctx, request)
+}
+
+// CheckCredentials returns the permissions required to manage the eligibility of assignment
+// that are missing from its credential.
+func (s *Service) CheckCredentials(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "eligibleroleassignments.CheckCredentials", attribute.String("entra.eligibleroleassignment.name", assignment.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.Spec.Group != nil {
+		return client.MissingPermissions(roles, groupPermissions), nil
+	}
+	return client.MissingPermissions(roles, directoryRolePermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, assignment v1alpha1.EntraEligibleRoleAssignment) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &assignment, assignment.Spec.ForProvider)
+}
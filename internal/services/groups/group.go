@@ -3,67 +3,126 @@ package groups
 import (
 	"context"
 	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
 	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphgroups "github.com/vimal-vijayan/entra-governance/internal/graph/groups"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+const (
+	MemberTypeUser             = "User"
+	MemberTypeGroup            = "Group"
+	MemberTypeServicePrincipal = "ServicePrincipal"
+
+	// MemberOperationAdd and MemberOperationRemove are the operations of member failures and of
+	// the member change metrics.
+	MemberOperationAdd    = "Add"
+	MemberOperationRemove = "Remove"
+
+	// resourceKind is checked against the kinds allowed by CredentialGrants
+	resourceKind = "EntraSecurityGroup"
 )
 
+// requiredPermissions are the Graph application permissions needed to manage groups and
+// their members.
+var requiredPermissions = []client.Permission{
+	{Name: "Group.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// roleAssignablePermissions are additionally needed to create groups assignable to directory roles.
+var roleAssignablePermissions = []client.Permission{
+	{Name: "RoleManagement.ReadWrite.Directory"},
+}
+
+// administrativeUnitPermissions are additionally needed to create groups in administrative units.
+var administrativeUnitPermissions = []client.Permission{
+	{Name: "AdministrativeUnit.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// API manages Entra security groups on behalf of EntraSecurityGroup resources, using the
+// credentials referenced in their spec.
+type API interface {
+	Get(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) (id string, statusCode string, err error)
+	// Create creates the group, in the administrative unit with administrativeUnitID when not empty.
+	Create(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, administrativeUnitID string) (id string, displayName string, err error)
+	Delete(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) error
+	AddMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, memberType string, memberIDs []string) (added []string, failures []v1alpha1.MemberFailure, err error)
+	RemoveMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, memberType string, memberIDs []string) (removed []string, failures []v1alpha1.MemberFailure, err error)
+	ListMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, transitive bool) ([]graphgroups.DirectoryObject, error)
+	ListOwners(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) ([]graphgroups.DirectoryObject, error)
+	Delta(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, deltaLink string) (changedIDs []string, nextLink string, err error)
+	CheckCredentials(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
 type Service struct {
-	factory *client.ClientFactory
+	factory client.Factory
 }
 
-func NewService(factory *client.ClientFactory) *Service {
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
 	return &Service{factory: factory}
 }
 
-func (s *Service) Get(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) (string, string, error) {
+func (s *Service) Get(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) (id string, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "groups.Get", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", groupID))
+	defer func() { tracing.End(span, err) }()
 
 	if entraGroup.Spec.ForProvider == nil {
 		return "", "", fmt.Errorf("credential reference in forProvider spec is nil")
 	}
 
-	secretRef := client.SecretRef{
-		Name:      entraGroup.Spec.ForProvider.CredentialSecretRef,
-		Namespace: entraGroup.Namespace,
-	}
-
-	sdk, err := s.factory.ForClientSecret(ctx, secretRef)
+	graphClient, err := s.forProvider(ctx, entraGroup)
 	if err != nil {
 		return "", "", err
 	}
 
-	graphClient := client.NewGraphClient(sdk)
 	// id, statusCode, err := graphClient.GetEntraGroupByID(ctx, groupID)
 	resp, err := graphClient.Groups.Get(ctx, groupID)
-
 	if err != nil {
+		if resp == nil {
+			return "", "", err
+		}
 		return "", resp.HttpStatusCode, err
 	}
 
 	return resp.ID, resp.HttpStatusCode, nil
 }
 
-func (s *Service) Create(ctx context.Context, groupSpec v1alpha1.EntraSecurityGroup) (string, string, error) {
+func (s *Service) Create(ctx context.Context, groupSpec v1alpha1.EntraSecurityGroup, administrativeUnitID string) (id string, displayName string, err error) {
+	ctx, span := tracing.Start(ctx, "groups.Create", attribute.String("entra.group.name", groupSpec.Name))
+	defer func() { tracing.End(span, err) }()
 
 	if groupSpec.Spec.ForProvider == nil {
 		return "", "", fmt.Errorf("forProvider spec is nil")
 	}
 
-	secretRef := client.SecretRef{
-		Name:      groupSpec.Spec.ForProvider.CredentialSecretRef,
-		Namespace: groupSpec.Namespace,
-	}
+	if groupSpec.Spec.ForProvider.CredentialSecretRef != "" || groupSpec.Spec.ForProvider.ManagedIdentity != nil {
 
-	if groupSpec.Spec.ForProvider.CredentialSecretRef != "" {
+		graphClient, err := s.forProvider(ctx, groupSpec)
+		if err != nil {
+			return "", "", err
+		}
 
-		sdk, err := s.factory.ForClientSecret(ctx, secretRef)
+		// a group created by an earlier attempt whose status was never written is adopted
+		uniqueName := client.UniqueName(&groupSpec)
+		existing, err := graphClient.Groups.GetByUniqueName(ctx, uniqueName)
 		if err != nil {
 			return "", "", err
 		}
+		if existing != nil {
+			log.FromContext(ctx).Info("adopting existing Entra group created for the resource", "GroupID", existing.ID, "uniqueName", uniqueName)
+			return existing.ID, existing.DisplayName, nil
+		}
 
-		graphClient := client.NewGraphClient(sdk)
 		// resp, err := graphClient.CreateEntraGroup(ctx, groupSpec.Spec)
-		resp, err := graphClient.Groups.Create(ctx, groupSpec.Spec)
+		resp, err := graphClient.Groups.Create(ctx, groupSpec.Spec, uniqueName, administrativeUnitID)
 		if err != nil {
 			return "", "", err
 		}
@@ -81,102 +140,209 @@ func (s *Service) Create(ctx context.Context, groupSpec v1alpha1.EntraSecurityGr
 	return "", "", fmt.Errorf("no valid credential reference found in the EntraSecurityGroup spec")
 }
 
-func (s *Service) Delete(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) error {
+func (s *Service) Delete(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, groupID string) (err error) {
+	ctx, span := tracing.Start(ctx, "groups.Delete", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", groupID))
+	defer func() { tracing.End(span, err) }()
 
 	if entraGroup.Spec.ForProvider == nil {
 		return fmt.Errorf("forProvider spec is nil")
 	}
 
-	secretRef := client.SecretRef{
-		Name:      entraGroup.Spec.ForProvider.CredentialSecretRef,
-		Namespace: entraGroup.Namespace,
-	}
-
-	sdk, err := s.factory.ForClientSecret(ctx, secretRef)
+	graphClient, err := s.forProvider(ctx, entraGroup)
 	if err != nil {
 		return fmt.Errorf("failed to create SDK client: %v", err)
 	}
 
-	graphClient := client.NewGraphClient(sdk)
 	// return graphClient.DeleteEntraGroupByID(ctx, groupID)
 	return graphClient.Groups.Delete(ctx, groupID)
 }
 
-func (s *Service) AddMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) error {
+// AddMembers adds the given members of a single type to the group and returns the
+// member IDs that were added along with the members Graph rejected.
+func (s *Service) AddMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, memberType string, memberIDs []string) (added []string, failures []v1alpha1.MemberFailure, err error) {
+	ctx, span := tracing.Start(ctx, "groups.AddMembers", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", entraGroup.Status.ID), attribute.String("entra.member.type", memberType), attribute.Int("entra.member.count", len(memberIDs)))
+	defer func() { tracing.End(span, err) }()
 
-	if entraGroup.Spec.Members == nil || len(*entraGroup.Spec.Members) == 0 {
-		return nil
+	if len(memberIDs) == 0 {
+		return nil, nil, nil
 	}
 
-	// get users as members from the spec
-	var userIDs = getMemberIDs(entraGroup, "User")
-	// Fetch group ID using group name
-	var groupIDs = getMemberIDs(entraGroup, "Group")
-	// Fetch service principal IDs using spec
-	var servicePrincipalIDs = getMemberIDs(entraGroup, "ServicePrincipal")
+	resourceType, err := memberResourceType(memberType)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// sdk, err := s.factory.ForClientSecret(ctx, client.SecretRef{
-	// 	Name:      entraGroup.Spec.ForProvider.CredentialSecretRef,
-	// 	Namespace: entraGroup.Namespace,
-	// })
-	// if err != nil {
-	// 	return fmt.Errorf("failed to create SDK client: %v", err)
-	// }
+	graphClient, err := s.graphClient(ctx, entraGroup)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// graphClient := client.NewGraphClient(sdk)
+	resp, err := graphClient.Groups.AddMembers(ctx, entraGroup.Status.ID, resourceType, memberIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add %s members to group: %v", memberType, err)
+	}
+
+	return resp.Succeeded, memberFailures(resp.Failed, memberType, MemberOperationAdd), nil
+}
+
+// RemoveMembers removes the given members of a single type from the group and returns
+// the member IDs that were removed along with the members Graph failed to remove.
+func (s *Service) RemoveMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, memberType string, memberIDs []string) (removed []string, failures []v1alpha1.MemberFailure, err error) {
+	ctx, span := tracing.Start(ctx, "groups.RemoveMembers", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", entraGroup.Status.ID), attribute.String("entra.member.type", memberType), attribute.Int("entra.member.count", len(memberIDs)))
+	defer func() { tracing.End(span, err) }()
+
+	if len(memberIDs) == 0 {
+		return nil, nil, nil
+	}
 
-	// call the api to add members
-	if userIDs != nil {
-		// err := graphClient.Groups.AddMembers(ctx, entraGroup, entraGroup.Status.ID, "users", userIDs)
-		// if err != nil {
-		// 	return fmt.Errorf("failed to add users as members to group: %v", err)
-		// }
+	graphClient, err := s.graphClient(ctx, entraGroup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := graphClient.Groups.RemoveMembers(ctx, entraGroup.Status.ID, memberIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to remove %s members from group: %v", memberType, err)
 	}
 
-	if groupIDs != nil {
-		// add groups as members
+	return resp.Succeeded, memberFailures(resp.Failed, memberType, MemberOperationRemove), nil
+}
+
+// ListMembers returns the members of the group in Entra. When transitive is true,
+// members of nested groups are included.
+func (s *Service) ListMembers(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, transitive bool) (members []graphgroups.DirectoryObject, err error) {
+	ctx, span := tracing.Start(ctx, "groups.ListMembers", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", entraGroup.Status.ID), attribute.Bool("entra.members.transitive", transitive))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraGroup)
+	if err != nil {
+		return nil, err
 	}
 
-	if servicePrincipalIDs != nil {
-		// add service principals as members
+	return graphClient.Groups.ListMembers(ctx, entraGroup.Status.ID, transitive)
+}
+
+// ListOwners returns the owners of the group in Entra.
+func (s *Service) ListOwners(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) (owners []graphgroups.DirectoryObject, err error) {
+	ctx, span := tracing.Start(ctx, "groups.ListOwners", attribute.String("entra.group.name", entraGroup.Name), attribute.String("entra.group.id", entraGroup.Status.ID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraGroup)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return graphClient.Groups.ListOwners(ctx, entraGroup.Status.ID)
 }
 
-func (s *Service) CheckMemberIds(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) ([]string, error) {
+// Delta returns the IDs of the groups that changed in the tenant of the group's credentials
+// since deltaLink was issued, and the delta link to use for the next call.
+func (s *Service) Delta(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup, deltaLink string) (changedIDs []string, nextLink string, err error) {
+	ctx, span := tracing.Start(ctx, "groups.Delta", attribute.Bool("entra.delta.initial", deltaLink == ""))
+	defer func() { tracing.End(span, err) }()
 
-	// currentManagedMemberIds := entraGroup.Status.ManagedMemberGroups
+	graphClient, err := s.graphClient(ctx, entraGroup)
+	if err != nil {
+		return nil, "", err
+	}
 
-	// sdk, err := s.factory.ForClientSecret(ctx, client.SecretRef{
-	// 	Name:      entraGroup.Spec.ForProvider.CredentialSecretRef,
-	// 	Namespace: entraGroup.Namespace,
-	// })
+	resp, err := graphClient.Groups.Delta(ctx, deltaLink)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.ChangedGroupIDs, resp.DeltaLink, nil
+}
+
+func (s *Service) graphClient(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) (*client.GraphClient, error) {
+
+	if entraGroup.Spec.ForProvider == nil {
+		return nil, fmt.Errorf("forProvider spec is nil")
+	}
 
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to create SDK client: %v", err)
-	// }
+	graphClient, err := s.forProvider(ctx, entraGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SDK client: %v", err)
+	}
 
-	// graphClient := client.NewGraphClient(sdk)
+	return graphClient, nil
+}
 
-	var unManagedMemberIds = []string{}
-	// for _, member := range currentManagedMemberIds {
-	// err := graphClient.CheckGroupMembers(ctx, entraGroup.Status.ID, member)
-	// err := graphClient.Groups.CheckMembers(ctx, entraGroup, entraGroup.Status.ID, member)
-	// if err != nil {
-	// unManagedMemberIds = append(unManagedMemberIds, member)
-	// }
-	// }
+func memberResourceType(memberType string) (string, error) {
+	switch memberType {
+	case MemberTypeUser:
+		return "users", nil
+	case MemberTypeGroup:
+		return "groups", nil
+	case MemberTypeServicePrincipal:
+		return "servicePrincipals", nil
+	}
+	return "", fmt.Errorf("unsupported member type %q", memberType)
+}
 
-	return unManagedMemberIds, nil
+func memberFailures(failed []graphgroups.MemberFailure, memberType string, operation string) []v1alpha1.MemberFailure {
+	var failures []v1alpha1.MemberFailure
+	for _, f := range failed {
+		failures = append(failures, v1alpha1.MemberFailure{
+			ID:         f.ID,
+			Type:       memberType,
+			Operation:  operation,
+			StatusCode: f.StatusCode,
+			Message:    f.Message,
+		})
+	}
+	return failures
 }
 
-func getMemberIDs(entraGroup v1alpha1.EntraSecurityGroup, Type string) []string {
+// GetMemberIDs returns the IDs of the members of the given type declared in the spec. Members
+// referencing an EntraUser whose ID was not resolved are left out.
+func GetMemberIDs(entraGroup v1alpha1.EntraSecurityGroup, Type string) []string {
+	if entraGroup.Spec.Members == nil {
+		return nil
+	}
 	var ids []string
 	for _, member := range *entraGroup.Spec.Members {
-		if member.Type == Type {
+		if member.Type == Type && member.Id != "" {
 			ids = append(ids, member.Id)
 		}
 	}
 	return ids
 }
+
+// CheckCredentials returns the permissions required to manage groups that are missing from
+// the credential of entraGroup.
+func (s *Service) CheckCredentials(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "groups.CheckCredentials", attribute.String("entra.group.name", entraGroup.Name))
+	defer func() { tracing.End(span, err) }()
+
+	if entraGroup.Spec.ForProvider == nil {
+		return nil, fmt.Errorf("forProvider spec is nil")
+	}
+
+	graphClient, err := s.forProvider(ctx, entraGroup)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	required := requiredPermissions
+	if entraGroup.Spec.IsAssignableToRole {
+		required = append(slices.Clone(required), roleAssignablePermissions...)
+	}
+	if entraGroup.Spec.AdministrativeUnit != nil {
+		required = append(slices.Clone(required), administrativeUnitPermissions...)
+	}
+	return client.MissingPermissions(roles, required), nil
+}
+
+// forProvider returns a Graph client authenticated with the credential configured in the
+// provider spec of entraGroup: the managed identity of the controller or a credential secret.
+func (s *Service) forProvider(ctx context.Context, entraGroup v1alpha1.EntraSecurityGroup) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraGroup, entraGroup.Spec.ForProvider)
+}
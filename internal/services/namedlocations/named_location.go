@@ -0,0 +1,128 @@
+package namedlocations
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphlocations "github.com/vimal-vijayan/entra-governance/internal/graph/namedlocations"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraNamedLocation"
+
+// requiredPermissions are the Graph application permissions needed to create, update and
+// delete named locations. They are managed with the conditional access policies.
+var requiredPermissions = []client.Permission{
+	{Name: "Policy.ReadWrite.ConditionalAccess"},
+	{Name: "Policy.Read.All"},
+}
+
+// API manages Entra named locations on behalf of EntraNamedLocation
+// resources, using the credentials referenced in their spec.
+type API interface {
+	Get(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string) (location *graphlocations.NamedLocation, statusCode string, err error)
+	Create(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, location graphlocations.NamedLocation) (*graphlocations.NamedLocation, error)
+	Update(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string, location graphlocations.NamedLocation) error
+	Delete(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string) error
+	CheckCredentials(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+func (s *Service) Get(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string) (location *graphlocations.NamedLocation, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "namedlocations.Get", attribute.String("entra.namedlocation.name", entraLocation.Name), attribute.String("entra.namedlocation.id", locationID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraLocation)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := graphClient.NamedLocations.Get(ctx, locationID)
+	if err != nil {
+		if resp == nil {
+			return nil, "", err
+		}
+		return nil, resp.HttpStatusCode, err
+	}
+
+	return &resp.NamedLocation, resp.HttpStatusCode, nil
+}
+
+// Create creates the named location of entraLocation. location is its spec with the IP ranges
+// parsed.
+func (s *Service) Create(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, location graphlocations.NamedLocation) (created *graphlocations.NamedLocation, err error) {
+	ctx, span := tracing.Start(ctx, "namedlocations.Create", attribute.String("entra.namedlocation.name", entraLocation.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.NamedLocations.Create(ctx, location)
+}
+
+// Update applies location, the spec of entraLocation with the IP ranges parsed, to the named
+// location.
+func (s *Service) Update(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string, location graphlocations.NamedLocation) (err error) {
+	ctx, span := tracing.Start(ctx, "namedlocations.Update", attribute.String("entra.namedlocation.name", entraLocation.Name), attribute.String("entra.namedlocation.id", locationID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraLocation)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.NamedLocations.Update(ctx, locationID, location)
+}
+
+func (s *Service) Delete(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation, locationID string) (err error) {
+	ctx, span := tracing.Start(ctx, "namedlocations.Delete", attribute.String("entra.namedlocation.name", entraLocation.Name), attribute.String("entra.namedlocation.id", locationID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraLocation)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.NamedLocations.Delete(ctx, locationID)
+}
+
+// CheckCredentials returns the permissions required to manage named locations that
+// missing from the credential of entraLocation.
+func (s *Service) CheckCredentials(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "namedlocations.CheckCredentials", attribute.String("entra.namedlocation.name", entraLocation.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraLocation)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, entraLocation v1alpha1.EntraNamedLocation) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraLocation, entraLocation.Spec.ForProvider)
+}
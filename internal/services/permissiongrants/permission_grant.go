@@ -0,0 +1,244 @@
+package permissiongrants
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphgrants "github.com/vimal-vijayan/entra-governance/internal/graph/permissiongrants"
+	graphsp "github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraPermissionGrant"
+
+// requiredPermissions are the Graph application permissions needed to instantiate the service
+// principal of an application and grant it delegated and application permissions.
+var requiredPermissions = []client.Permission{
+	{Name: "DelegatedPermissionGrant.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+	{Name: "AppRoleAssignment.ReadWrite.All"},
+	{Name: "Application.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// PublishedPermissions are the permissions an API publishes for client applications.
+type PublishedPermissions struct {
+	// Scopes are the values of the delegated permissions.
+	Scopes []string
+	// AppRoles are the app roles, the application permissions among them allow the Application
+	// member type.
+	AppRoles []graphsp.AppRole
+}
+
+// API manages the admin consent of applications on behalf of EntraPermissionGrant resources,
+// using the credentials referenced in their spec.
+type API interface {
+	ClientServicePrincipal(ctx context.Context, grant v1alpha1.EntraPermissionGrant, appID string) (sp *graphsp.ServicePrincipalResponse, err error)
+	ResourceServicePrincipal(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceAppID string) (sp *graphsp.ServicePrincipalResponse, err error)
+	PublishedPermissions(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceID string) (*PublishedPermissions, error)
+	ListDelegatedGrants(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string) ([]graphgrants.OAuth2PermissionGrantResponse, error)
+	CreateDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string, resourceID string, scope string) (*graphgrants.OAuth2PermissionGrantResponse, error)
+	UpdateDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, grantID string, scope string) error
+	DeleteDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, grantID string) error
+	ListApplicationGrants(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string) ([]graphsp.AppRoleAssignmentResponse, error)
+	CreateApplicationGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string, resourceID string, appRoleID string) (*graphsp.AppRoleAssignmentResponse, error)
+	DeleteApplicationGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceID string, assignmentID string) error
+	CheckCredentials(ctx context.Context, grant v1alpha1.EntraPermissionGrant) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+// ClientServicePrincipal returns the service principal of the application consent is granted
+// to, creating it when the application was not instantiated in the tenant yet.
+func (s *Service) ClientServicePrincipal(ctx context.Context, grant v1alpha1.EntraPermissionGrant, appID string) (sp *graphsp.ServicePrincipalResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.ClientServicePrincipal", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.app_id", appID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err = graphClient.ServicePrincipals.GetByAppID(ctx, appID)
+	if err != nil || sp != nil {
+		return sp, err
+	}
+
+	log.FromContext(ctx).Info("creating service principal of the application", "appId", appID)
+	return graphClient.ServicePrincipals.Create(ctx, v1alpha1.EntraServicePrincipalSpec{AppID: appID})
+}
+
+// ResourceServicePrincipal returns the service principal of the API in the tenant.
+func (s *Service) ResourceServicePrincipal(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceAppID string) (sp *graphsp.ServicePrincipalResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.ResourceServicePrincipal", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.resource_app_id", resourceAppID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, err = graphClient.ServicePrincipals.GetByAppID(ctx, resourceAppID)
+	if err != nil {
+		return nil, err
+	}
+	if sp == nil {
+		return nil, fmt.Errorf("the API %s has no service principal in the tenant", resourceAppID)
+	}
+	return sp, nil
+}
+
+// PublishedPermissions returns the delegated permissions and app roles published by the API.
+func (s *Service) PublishedPermissions(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceID string) (permissions *PublishedPermissions, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.PublishedPermissions", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.resource_id", resourceID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := graphClient.ServicePrincipals.OAuth2PermissionScopes(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := graphClient.ServicePrincipals.AppRoles(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return &PublishedPermissions{Scopes: scopes, AppRoles: roles}, nil
+}
+
+// ListDelegatedGrants returns the delegated permission grants of the client consented on behalf
+// of all users.
+func (s *Service) ListDelegatedGrants(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string) (grants []graphgrants.OAuth2PermissionGrantResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.ListDelegatedGrants", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.client_id", clientID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := graphClient.PermissionGrants.ListByClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range all {
+		if candidate.ConsentType == graphgrants.ConsentTypeAllPrincipals {
+			grants = append(grants, candidate)
+		}
+	}
+	return grants, nil
+}
+
+func (s *Service) CreateDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string, resourceID string, scope string) (resp *graphgrants.OAuth2PermissionGrantResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.CreateDelegatedGrant", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.resource_id", resourceID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.PermissionGrants.Create(ctx, clientID, resourceID, scope)
+}
+
+func (s *Service) UpdateDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, grantID string, scope string) (err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.UpdateDelegatedGrant", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.grant_id", grantID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.PermissionGrants.Update(ctx, grantID, scope)
+}
+
+func (s *Service) DeleteDelegatedGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, grantID string) (err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.DeleteDelegatedGrant", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.grant_id", grantID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.PermissionGrants.Delete(ctx, grantID)
+}
+
+// ListApplicationGrants returns the app role assignments granted to the client service principal.
+func (s *Service) ListApplicationGrants(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string) (assignments []graphsp.AppRoleAssignmentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.ListApplicationGrants", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.client_id", clientID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.ServicePrincipals.ListAppRoleAssignments(ctx, clientID)
+}
+
+func (s *Service) CreateApplicationGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, clientID string, resourceID string, appRoleID string) (resp *graphsp.AppRoleAssignmentResponse, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.CreateApplicationGrant", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.resource_id", resourceID), attribute.String("entra.permissiongrant.app_role_id", appRoleID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.ServicePrincipals.CreateAppRoleAssignment(ctx, resourceID, clientID, appRoleID)
+}
+
+func (s *Service) DeleteApplicationGrant(ctx context.Context, grant v1alpha1.EntraPermissionGrant, resourceID string, assignmentID string) (err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.DeleteApplicationGrant", attribute.String("entra.permissiongrant.name", grant.Name), attribute.String("entra.permissiongrant.assignment_id", assignmentID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ServicePrincipals.DeleteAppRoleAssignment(ctx, resourceID, assignmentID)
+}
+
+// CheckCredentials returns the permissions required to grant admin consent that are missing
+// from the credential of grant.
+func (s *Service) CheckCredentials(ctx context.Context, grant v1alpha1.EntraPermissionGrant) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "permissiongrants.CheckCredentials", attribute.String("entra.permissiongrant.name", grant.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, grant v1alpha1.EntraPermissionGrant) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &grant, grant.Spec.ForProvider)
+}
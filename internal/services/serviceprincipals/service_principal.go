@@ -0,0 +1,213 @@
+package serviceprincipals
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphsp "github.com/vimal-vijayan/entra-governance/internal/graph/serviceprincipals"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraServicePrincipal"
+
+// requiredPermissions are the Graph application permissions needed to instantiate, update and
+// delete service principals and manage their owners.
+var requiredPermissions = []client.Permission{
+	{Name: "Application.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// API manages Entra service principals on behalf of EntraServicePrincipal resources, using the
+// credentials referenced in their spec.
+type API interface {
+	Get(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) (sp *graphsp.ServicePrincipalResponse, statusCode string, err error)
+	Create(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal) (sp *graphsp.ServicePrincipalResponse, adopted bool, err error)
+	Update(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) error
+	Delete(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) error
+	ListOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) ([]string, error)
+	AddOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string, ownerIDs []string) (added []string, err error)
+	RemoveOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string, ownerIDs []string) (removed []string, err error)
+	CheckCredentials(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+func (s *Service) Get(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) (sp *graphsp.ServicePrincipalResponse, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.Get", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := graphClient.ServicePrincipals.Get(ctx, servicePrincipalID)
+	if err != nil {
+		if resp == nil {
+			return nil, "", err
+		}
+		return nil, resp.HttpStatusCode, err
+	}
+
+	return &resp.ServicePrincipalResponse, resp.HttpStatusCode, nil
+}
+
+// Create instantiates the service principal of the application of entraSP. A service principal
+// that already exists for the application, e.g. after admin consent, is adopted instead.
+func (s *Service) Create(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal) (sp *graphsp.ServicePrincipalResponse, adopted bool, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.Create", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.app_id", entraSP.Spec.AppID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := graphClient.ServicePrincipals.GetByAppID(ctx, entraSP.Spec.AppID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		log.FromContext(ctx).Info("adopting existing Entra service principal of the application", "servicePrincipalID", existing.ID, "appId", existing.AppID)
+		return existing, true, nil
+	}
+
+	sp, err = graphClient.ServicePrincipals.Create(ctx, entraSP.Spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return sp, false, nil
+}
+
+// Update applies the tags, notes and app role assignment requirement of the spec.
+func (s *Service) Update(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) (err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.Update", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ServicePrincipals.Update(ctx, servicePrincipalID, entraSP.Spec)
+}
+
+func (s *Service) Delete(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) (err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.Delete", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.ServicePrincipals.Delete(ctx, servicePrincipalID)
+}
+
+// ListOwners returns the object IDs of the owners of the service principal in Entra.
+func (s *Service) ListOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string) (owners []string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.ListOwners", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.ServicePrincipals.ListOwners(ctx, servicePrincipalID)
+}
+
+// AddOwners adds the given owners to the service principal and returns the owners that were
+// added before the first failure.
+func (s *Service) AddOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string, ownerIDs []string) (added []string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.AddOwners", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID), attribute.Int("entra.owner.count", len(ownerIDs)))
+	defer func() { tracing.End(span, err) }()
+
+	if len(ownerIDs) == 0 {
+		return nil, nil
+	}
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ownerID := range ownerIDs {
+		if err := graphClient.ServicePrincipals.AddOwner(ctx, servicePrincipalID, ownerID); err != nil {
+			return added, err
+		}
+		added = append(added, ownerID)
+	}
+	return added, nil
+}
+
+// RemoveOwners removes the given owners from the service principal and returns the owners that
+// were removed before the first failure.
+func (s *Service) RemoveOwners(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal, servicePrincipalID string, ownerIDs []string) (removed []string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.RemoveOwners", attribute.String("entra.serviceprincipal.name", entraSP.Name), attribute.String("entra.serviceprincipal.id", servicePrincipalID), attribute.Int("entra.owner.count", len(ownerIDs)))
+	defer func() { tracing.End(span, err) }()
+
+	if len(ownerIDs) == 0 {
+		return nil, nil
+	}
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ownerID := range ownerIDs {
+		if err := graphClient.ServicePrincipals.RemoveOwner(ctx, servicePrincipalID, ownerID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, ownerID)
+	}
+	return removed, nil
+}
+
+// CheckCredentials returns the permissions required to manage service principals that are
+// missing from the credential of entraSP.
+func (s *Service) CheckCredentials(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceprincipals.CheckCredentials", attribute.String("entra.serviceprincipal.name", entraSP.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraSP)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+// OwnerIDs returns the object IDs of the owners declared in the spec.
+func OwnerIDs(entraSP v1alpha1.EntraServicePrincipal) []string {
+	ids := make([]string, 0, len(entraSP.Spec.Owners))
+	for _, owner := range entraSP.Spec.Owners {
+		ids = append(ids, owner.Id)
+	}
+	return ids
+}
+
+func (s *Service) graphClient(ctx context.Context, entraSP v1alpha1.EntraServicePrincipal) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraSP, entraSP.Spec.ForProvider)
+}
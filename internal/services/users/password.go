@@ -0,0 +1,56 @@
+package users
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	passwordLength = 24
+
+	lowerChars  = "abcdefghijkmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	digitChars  = "23456789"
+	symbolChars = "!#$%*+-=?@^_"
+)
+
+// GeneratePassword returns a random password satisfying the Entra password complexity rules,
+// with at least one lowercase letter, uppercase letter, digit and symbol.
+func GeneratePassword() (string, error) {
+	classes := []string{lowerChars, upperChars, digitChars, symbolChars}
+	all := lowerChars + upperChars + digitChars + symbolChars
+
+	password := make([]byte, 0, passwordLength)
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	for len(password) < passwordLength {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// shuffle so that the required classes are not always first
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i.Int64()], nil
+}
@@ -0,0 +1,163 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/vimal-vijayan/entra-governance/api/v1alpha1"
+	"github.com/vimal-vijayan/entra-governance/internal/client"
+	graphusers "github.com/vimal-vijayan/entra-governance/internal/graph/users"
+	"github.com/vimal-vijayan/entra-governance/internal/tracing"
+)
+
+// resourceKind is checked against the kinds allowed by CredentialGrants
+const resourceKind = "EntraUser"
+
+// requiredPermissions are the Graph application permissions needed to invite, create, enable,
+// disable and delete users.
+var requiredPermissions = []client.Permission{
+	{Name: "User.ReadWrite.All", Alternatives: []string{"Directory.ReadWrite.All"}},
+}
+
+// API manages Entra users on behalf of EntraUser resources, using the credentials referenced
+// in their spec.
+type API interface {
+	Get(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) (user *graphusers.UserResponse, statusCode string, err error)
+	FindByUserPrincipalName(ctx context.Context, entraUser v1alpha1.EntraUser) (*graphusers.UserResponse, error)
+	Create(ctx context.Context, entraUser v1alpha1.EntraUser, password string) (*graphusers.UserResponse, error)
+	Invite(ctx context.Context, entraUser v1alpha1.EntraUser) (*graphusers.InvitationResponse, error)
+	Update(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) error
+	Delete(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) error
+	CheckCredentials(ctx context.Context, entraUser v1alpha1.EntraUser) (missing []string, err error)
+}
+
+// Service implements API on top of the Graph clients created by a client.Factory.
+type Service struct {
+	factory client.Factory
+}
+
+var _ API = &Service{}
+
+func NewService(factory client.Factory) *Service {
+	return &Service{factory: factory}
+}
+
+func (s *Service) Get(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) (user *graphusers.UserResponse, statusCode string, err error) {
+	ctx, span := tracing.Start(ctx, "users.Get", attribute.String("entra.user.name", entraUser.Name), attribute.String("entra.user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := graphClient.Users.Get(ctx, userID)
+	if err != nil {
+		if resp == nil {
+			return nil, "", err
+		}
+		return nil, resp.HttpStatusCode, err
+	}
+
+	return &resp.UserResponse, resp.HttpStatusCode, nil
+}
+
+// FindByUserPrincipalName returns the user signing in with the user principal name of
+// entraUser, nil when there is none.
+func (s *Service) FindByUserPrincipalName(ctx context.Context, entraUser v1alpha1.EntraUser) (user *graphusers.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "users.FindByUserPrincipalName", attribute.String("entra.user.name", entraUser.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.Users.GetByUserPrincipalName(ctx, entraUser.Spec.UserPrincipalName)
+}
+
+// Create creates the cloud-only user of entraUser with the given password.
+func (s *Service) Create(ctx context.Context, entraUser v1alpha1.EntraUser, password string) (user *graphusers.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "users.Create", attribute.String("entra.user.name", entraUser.Name))
+	defer func() { tracing.End(span, err) }()
+
+	if entraUser.Spec.UserPrincipalName == "" {
+		return nil, fmt.Errorf("userPrincipalName is required to create a cloud-only user")
+	}
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.Users.Create(ctx, entraUser.Spec, password)
+}
+
+// Invite invites the external user of entraUser as a guest.
+func (s *Service) Invite(ctx context.Context, entraUser v1alpha1.EntraUser) (invitation *graphusers.InvitationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "users.Invite", attribute.String("entra.user.name", entraUser.Name))
+	defer func() { tracing.End(span, err) }()
+
+	if entraUser.Spec.Invitation == nil {
+		return nil, fmt.Errorf("invitation is required to invite a guest user")
+	}
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphClient.Users.Invite(ctx, entraUser.Spec)
+}
+
+// Update applies the display name and the account enabled state of the spec to the user.
+func (s *Service) Update(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "users.Update", attribute.String("entra.user.name", entraUser.Name), attribute.String("entra.user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.Users.Update(ctx, userID, entraUser.Spec)
+}
+
+func (s *Service) Delete(ctx context.Context, entraUser v1alpha1.EntraUser, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "users.Delete", attribute.String("entra.user.name", entraUser.Name), attribute.String("entra.user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return err
+	}
+
+	return graphClient.Users.Delete(ctx, userID)
+}
+
+// CheckCredentials returns the permissions required to manage users that are missing from
+// the credential of entraUser.
+func (s *Service) CheckCredentials(ctx context.Context, entraUser v1alpha1.EntraUser) (missing []string, err error) {
+	ctx, span := tracing.Start(ctx, "users.CheckCredentials", attribute.String("entra.user.name", entraUser.Name))
+	defer func() { tracing.End(span, err) }()
+
+	graphClient, err := s.graphClient(ctx, entraUser)
+	if err != nil {
+		return nil, err
+	}
+	if graphClient.Permissions == nil {
+		return nil, nil
+	}
+
+	roles, err := graphClient.Permissions.Roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.MissingPermissions(roles, requiredPermissions), nil
+}
+
+func (s *Service) graphClient(ctx context.Context, entraUser v1alpha1.EntraUser) (*client.GraphClient, error) {
+	return client.ForProvider(ctx, s.factory, resourceKind, &entraUser, entraUser.Spec.ForProvider)
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	khttp "github.com/microsoft/kiota-http-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vimal-vijayan/entra-governance/internal/metrics"
+)
+
+// GraphMiddleware is a Kiota middleware creating a client span for every HTTP request sent
+// to Microsoft Graph, including retries, so that a slow reconcile can be traced to the call.
+type GraphMiddleware struct{}
+
+// NewGraphMiddleware returns the tracing middleware. Like the metrics middleware, it belongs
+// at the end of the pipeline to observe every attempt made by the retry handler.
+func NewGraphMiddleware() *GraphMiddleware {
+	return &GraphMiddleware{}
+}
+
+// Intercept implements the Kiota middleware interface.
+func (m *GraphMiddleware) Intercept(pipeline khttp.Pipeline, middlewareIndex int, req *http.Request) (*http.Response, error) {
+	operation := metrics.Operation(req)
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "graph "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.Path),
+			semconv.ServerAddress(req.URL.Hostname()),
+			attribute.String("graph.operation", operation),
+		),
+	)
+	defer span.End()
+
+	resp, err := pipeline.Next(req.WithContext(ctx), middlewareIndex)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if requestID := resp.Header.Get("request-id"); requestID != "" {
+		// Graph request id, needed when opening a support case with Microsoft
+		span.SetAttributes(attribute.String("graph.request_id", requestID))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", resp.StatusCode))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,81 @@
+// Package tracing configures OpenTelemetry tracing for the controller and provides helpers
+// to create spans in the reconcile, service and Graph layers.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "entra-governance"
+	tracerName  = "github.com/vimal-vijayan/entra-governance"
+)
+
+// Options configures the OTLP trace exporter.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector. Tracing is disabled when empty.
+	Endpoint string
+	// Insecure disables TLS towards the collector.
+	Insecure bool
+	// SampleRatio is the fraction of new traces that are sampled, between 0 and 1.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider exporting spans over OTLP. The returned function
+// flushes pending spans and must be called on shutdown. Without an endpoint, the default no-op
+// provider is kept and spans are discarded.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// schemaless so that it merges with the default resource regardless of its schema version
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it. It is meant to be deferred with a named
+// error result: defer func() { tracing.End(span, err) }().
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a tracer provider recording every span for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+// pipeline answers every request with the given status code, or fails with err.
+type pipeline struct {
+	statusCode int
+	header     http.Header
+	err        error
+}
+
+func (p pipeline) Next(req *http.Request, _ int) (*http.Response, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	header := p.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: p.statusCode, Header: header, Request: req}, nil
+}
+
+func TestStartAndEnd(t *testing.T) {
+	g := NewWithT(t)
+	recorder := recordSpans(t)
+
+	ctx, parent := Start(context.Background(), "reconcile", attribute.String("entra.kind", "EntraSecurityGroup"))
+	_, child := Start(ctx, "groups.Create")
+	End(child, errors.New("request denied"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(HaveLen(2))
+	g.Expect(spans[0].Name()).To(Equal("groups.Create"))
+	g.Expect(spans[0].Parent().SpanID()).To(Equal(spans[1].SpanContext().SpanID()))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[0].Status().Description).To(Equal("request denied"))
+	g.Expect(spans[0].Events()).To(HaveLen(1))
+
+	g.Expect(spans[1].Name()).To(Equal("reconcile"))
+	g.Expect(spans[1].Status().Code).To(Equal(codes.Unset))
+	g.Expect(attributes(spans[1])).To(HaveKeyWithValue(attribute.Key("entra.kind"), attribute.StringValue("EntraSecurityGroup")))
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), Options{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(shutdown(context.Background())).To(Succeed())
+	g.Expect(otel.GetTracerProvider()).To(BeIdenticalTo(previous))
+}
+
+func TestGraphMiddleware(t *testing.T) {
+	g := NewWithT(t)
+	recorder := recordSpans(t)
+	middleware := NewGraphMiddleware()
+	target := "https://graph.microsoft.com/v1.0/groups/0f5e8a6c-3b2a-4c1d-9e8f-7a6b5c4d3e2f/members/$ref"
+
+	_, err := middleware.Intercept(pipeline{statusCode: http.StatusNoContent, header: http.Header{"Request-Id": {"4f2c"}}}, 0,
+		httptest.NewRequest(http.MethodPost, target, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = middleware.Intercept(pipeline{statusCode: http.StatusForbidden}, 0, httptest.NewRequest(http.MethodPost, target, nil))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = middleware.Intercept(pipeline{err: errors.New("connection reset")}, 0, httptest.NewRequest(http.MethodPost, target, nil))
+	g.Expect(err).To(HaveOccurred())
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(HaveLen(3))
+
+	succeeded := spans[0]
+	g.Expect(succeeded.Name()).To(Equal("graph POST /groups/{id}/members/$ref"))
+	g.Expect(succeeded.SpanKind()).To(Equal(trace.SpanKindClient))
+	g.Expect(succeeded.Status().Code).To(Equal(codes.Unset))
+	attrs := attributes(succeeded)
+	g.Expect(attrs).To(HaveKeyWithValue(attribute.Key("graph.operation"), attribute.StringValue("POST /groups/{id}/members/$ref")))
+	g.Expect(attrs).To(HaveKeyWithValue(attribute.Key("http.request.method"), attribute.StringValue(http.MethodPost)))
+	g.Expect(attrs).To(HaveKeyWithValue(attribute.Key("server.address"), attribute.StringValue("graph.microsoft.com")))
+	g.Expect(attrs).To(HaveKeyWithValue(attribute.Key("http.response.status_code"), attribute.IntValue(http.StatusNoContent)))
+	g.Expect(attrs).To(HaveKeyWithValue(attribute.Key("graph.request_id"), attribute.StringValue("4f2c")))
+
+	forbidden := spans[1]
+	g.Expect(forbidden.Status().Code).To(Equal(codes.Error))
+	g.Expect(forbidden.Status().Description).To(Equal("HTTP 403"))
+	g.Expect(attributes(forbidden)).NotTo(HaveKey(attribute.Key("graph.request_id")))
+
+	failed := spans[2]
+	g.Expect(failed.Status().Code).To(Equal(codes.Error))
+	g.Expect(failed.Status().Description).To(Equal("connection reset"))
+	g.Expect(attributes(failed)).NotTo(HaveKey(attribute.Key("http.response.status_code")))
+}